@@ -2,9 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/launcher"
+	"github.com/sol-eng/wbi/internal/operatingsystem"
+	"github.com/sol-eng/wbi/internal/positron"
+	"github.com/sol-eng/wbi/internal/proxy"
+	"github.com/sol-eng/wbi/internal/system"
+	"github.com/sol-eng/wbi/internal/vscode"
 	"github.com/sol-eng/wbi/internal/workbench"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -16,13 +24,47 @@ type configCmd struct {
 }
 
 type configOpts struct {
-	certPath string
-	keyPath  string
-	url      string
-	source   string
+	certPath  string
+	keyPath   string
+	url       string
+	source    string
+	header    string
+	signInURL string
+	proxy     string
+	apiURL    string
+	namespace string
+	tokenFile string
+	caCert    string
+	image     string
+	// slurm launcher options
+	slurmBinPath string
+	slurmUser    string
+	partitions   []string
+	sharedPath   string
+	maxCPUs      int
+	maxMemMB     int
+	// launcher profile options
+	user         string
+	group        string
+	defaultCPUs  int
+	defaultMemMB int
+	images       []string
+	// vscode options
+	vscodeArgs string
+	extensions []string
+	// r-versions options
+	rPath   string
+	rLabel  string
+	rModule string
+	// r-site options
+	httpProxy  string
+	httpsProxy string
+	noProxy    string
+	caBundle   string
 }
 
-func newConfig(configOpts configOpts, item string) error {
+func newConfig(configOpts configOpts, args []string) error {
+	item := args[0]
 	if item == "ssl" {
 		err := workbench.WriteSSLConfig(configOpts.certPath, configOpts.keyPath, configOpts.url)
 		if err != nil {
@@ -38,9 +80,184 @@ func newConfig(configOpts configOpts, item string) error {
 		if err != nil {
 			return fmt.Errorf("failed to write Connect URL config for Workbench: %w", err)
 		}
+	} else if item == "auth" {
+		err := newConfigAuthProxy(configOpts)
+		if err != nil {
+			return fmt.Errorf("failed to configure proxied authentication for Workbench: %w", err)
+		}
+	} else if item == "launcher" {
+		err := newConfigLauncher(configOpts, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to configure the Job Launcher: %w", err)
+		}
+	} else if item == "profiles" {
+		limits := launcher.ProfileLimits{
+			DefaultCPUs:  configOpts.defaultCPUs,
+			MaxCPUs:      configOpts.maxCPUs,
+			DefaultMemMB: configOpts.defaultMemMB,
+			MaxMemMB:     configOpts.maxMemMB,
+			Allowed:      append(configOpts.images, configOpts.partitions...),
+		}
+		sectionName := launcher.ProfileSectionName(configOpts.user, configOpts.group)
+		err := launcher.ManageProfiles(args[1], sectionName, limits)
+		if err != nil {
+			return fmt.Errorf("failed to manage launcher profiles: %w", err)
+		}
+	} else if item == "vscode" {
+		extensions := configOpts.extensions
+		if len(extensions) == 0 {
+			extensions = vscode.DefaultExtensions()
+		}
+		err := vscode.ConfigVSCode(configOpts.vscodeArgs, extensions)
+		if err != nil {
+			return fmt.Errorf("failed to configure VS Code for Workbench: %w", err)
+		}
+	} else if item == "positron" {
+		extensions := configOpts.extensions
+		if len(extensions) == 0 {
+			extensions = positron.DefaultExtensions()
+		}
+		err := positron.ConfigPositron(extensions)
+		if err != nil {
+			return fmt.Errorf("failed to configure Positron for Workbench: %w", err)
+		}
+	} else if item == "r-site" {
+		siteConfig := languages.RSiteConfig{
+			RepoURL:    configOpts.url,
+			HTTPProxy:  configOpts.httpProxy,
+			HTTPSProxy: configOpts.httpsProxy,
+			NoProxy:    configOpts.noProxy,
+			CABundle:   configOpts.caBundle,
+		}
+		// default to the CRAN repo Workbench is configured with
+		if siteConfig.RepoURL == "" {
+			siteConfig.RepoURL = languages.RepoURLFromReposConf()
+		}
+		err := languages.ConfigureRSiteFiles(siteConfig)
+		if err != nil {
+			return fmt.Errorf("failed to configure Rprofile.site and Renviron.site: %w", err)
+		}
+	} else if item == "r-versions" {
+		err := newConfigRVersions(configOpts, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to manage %s: %w", languages.RVersionsFilePath, err)
+		}
 	} else {
-		return fmt.Errorf("invalid item provided, please provide one of the following: ssl, repo, connect-url")
+		return fmt.Errorf("invalid item provided, please provide one of the following: ssl, repo, connect-url, auth, launcher, profiles, vscode, positron, r-site, r-versions")
+	}
+	return nil
+}
+
+func newConfigRVersions(configOpts configOpts, action string) error {
+	switch action {
+	case "add":
+		entry := languages.RVersionEntry{
+			Path:   configOpts.rPath,
+			Label:  configOpts.rLabel,
+			Module: configOpts.rModule,
+		}
+		err := languages.AddRVersionEntry(entry)
+		if err != nil {
+			return fmt.Errorf("issue adding %s: %w", configOpts.rPath, err)
+		}
+	case "remove":
+		err := languages.RemoveRVersionEntry(configOpts.rPath)
+		if err != nil {
+			return fmt.Errorf("issue removing %s: %w", configOpts.rPath, err)
+		}
+	}
+
+	entries, err := languages.ReadRVersionsFile()
+	if err != nil {
+		return err
 	}
+	languages.PrintRVersionEntries(entries)
+	return nil
+}
+
+func newConfigLauncher(configOpts configOpts, clusterType string) error {
+	if clusterType == "slurm" {
+		slurmConfig := launcher.SlurmConfig{
+			BinPath:     configOpts.slurmBinPath,
+			ServiceUser: configOpts.slurmUser,
+			SharedPath:  configOpts.sharedPath,
+			Partitions:  configOpts.partitions,
+			MaxCPUs:     configOpts.maxCPUs,
+			MaxMemMB:    configOpts.maxMemMB,
+		}
+		if slurmConfig.BinPath == "" {
+			slurmConfig.BinPath = "/usr/bin"
+		}
+		if slurmConfig.ServiceUser == "" {
+			slurmConfig.ServiceUser = "slurm"
+		}
+		return launcher.ConfigureSlurm(slurmConfig)
+	}
+
+	namespace := configOpts.namespace
+	if namespace == "" {
+		namespace = "rstudio"
+	}
+	kubernetesConfig := launcher.KubernetesConfig{
+		APIURL:     configOpts.apiURL,
+		Namespace:  namespace,
+		TokenPath:  configOpts.tokenFile,
+		CACertPath: configOpts.caCert,
+		Image:      configOpts.image,
+	}
+	return launcher.ConfigureKubernetes(kubernetesConfig)
+}
+
+func newConfigAuthProxy(configOpts configOpts) error {
+	osType, err := operatingsystem.DetectOS()
+	if err != nil {
+		return err
+	}
+	proxyServer := configOpts.proxy
+	if proxyServer == "" {
+		proxyServer = "nginx"
+	}
+
+	err = proxy.VerifyProxyCertAndKey(configOpts.certPath, configOpts.keyPath)
+	if err != nil {
+		return fmt.Errorf("issue verifying the TLS/SSL cert and key: %w", err)
+	}
+	serverName, err := proxy.ServerNameFromURL(configOpts.url)
+	if err != nil {
+		return fmt.Errorf("issue with the server URL: %w", err)
+	}
+	// Workbench has to listen on 127.0.0.1 only, so check it can before anything is written
+	err = workbench.VerifySSLDisabled()
+	if err != nil {
+		return err
+	}
+
+	proxyConfig := proxy.ProxyConfig{
+		ServerName: serverName,
+		CertPath:   configOpts.certPath,
+		KeyPath:    configOpts.keyPath,
+		UserHeader: configOpts.header,
+		SignInURL:  configOpts.signInURL,
+	}
+	// only switch Workbench to proxied authentication once the proxy config is known to be valid
+	configPath, err := proxy.WriteProxyConfig(proxyServer, proxyConfig, osType)
+	if err != nil {
+		return fmt.Errorf("failed to write %s config: %w", proxyServer, err)
+	}
+	system.PrintAndLogInfo("\nThe " + proxyServer + " config has been written to " + configPath)
+
+	// anyone who can reach Workbench directly could send the user header, so it must only be reachable through the proxy
+	err = workbench.WriteProxyListenConfig(configOpts.url)
+	if err != nil {
+		return fmt.Errorf("failed to write the Workbench listen config: %w", err)
+	}
+	err = workbench.WriteAuthProxyConfig(configOpts.header, configOpts.signInURL)
+	if err != nil {
+		return fmt.Errorf("failed to write auth proxy config: %w", err)
+	}
+
+	system.PrintAndLogInfo(proxy.ProxyNextSteps(proxyServer, osType))
+	system.PrintAndLogInfo("\nRestart Workbench with \"rstudio-server restart\" to apply the proxied authentication and the updated www-address, www-port and launcher-sessions-callback-address")
 	return nil
 }
 
@@ -49,41 +266,291 @@ func setConfigOpts(configOpts *configOpts) {
 	configOpts.keyPath = viper.GetString("key-path")
 	configOpts.url = viper.GetString("url")
 	configOpts.source = viper.GetString("source")
+	configOpts.header = viper.GetString("header")
+	configOpts.signInURL = viper.GetString("sign-in-url")
+	configOpts.proxy = viper.GetString("proxy")
+	configOpts.apiURL = viper.GetString("api-url")
+	configOpts.namespace = viper.GetString("namespace")
+	configOpts.tokenFile = viper.GetString("token-file")
+	configOpts.caCert = viper.GetString("ca-cert")
+	configOpts.image = viper.GetString("image")
+	configOpts.slurmBinPath = viper.GetString("slurm-bin-path")
+	configOpts.slurmUser = viper.GetString("slurm-user")
+	configOpts.partitions = viper.GetStringSlice("partitions")
+	configOpts.sharedPath = viper.GetString("shared-path")
+	configOpts.maxCPUs = viper.GetInt("max-cpus")
+	configOpts.maxMemMB = viper.GetInt("max-mem-mb")
+	configOpts.user = viper.GetString("user")
+	configOpts.group = viper.GetString("group")
+	configOpts.defaultCPUs = viper.GetInt("default-cpus")
+	configOpts.defaultMemMB = viper.GetInt("default-mem-mb")
+	configOpts.images = viper.GetStringSlice("images")
+	configOpts.vscodeArgs = viper.GetString("vscode-args")
+	configOpts.extensions = viper.GetStringSlice("extensions")
+	configOpts.rPath = viper.GetString("rversions-path")
+	configOpts.rLabel = viper.GetString("rversions-label")
+	configOpts.rModule = viper.GetString("rversions-module")
+	configOpts.httpProxy = viper.GetString("http-proxy")
+	configOpts.httpsProxy = viper.GetString("https-proxy")
+	configOpts.noProxy = viper.GetString("no-proxy")
+	configOpts.caBundle = viper.GetString("ca-bundle")
 }
 
 func (opts *configOpts) Validate(args []string) error {
 	// check args lengths
 	if len(args) == 0 {
 		return fmt.Errorf("no arguments provided, please provide one argument")
-	} else if len(args) > 1 {
+	} else if len(args) > 1 && args[0] != "auth" && args[0] != "launcher" && args[0] != "profiles" && args[0] != "r-versions" {
 		return fmt.Errorf("too many arguments provided, please provide only one argument")
 	}
 
-	// the cert-path flag is required for ssl
-	if opts.certPath == "" && args[0] == "ssl" {
-		return fmt.Errorf("the cert-path flag is required for ssl")
+	// auth requires the type of authentication as a second argument
+	if args[0] == "auth" {
+		if len(args) == 1 {
+			return fmt.Errorf("no authentication type provided, please provide one of the following: proxy")
+		} else if len(args) > 2 {
+			return fmt.Errorf("too many arguments provided, please provide only the item and the authentication type")
+		}
+		if args[1] != "proxy" {
+			return fmt.Errorf("invalid authentication type provided, please provide one of the following: proxy")
+		}
+	}
+
+	// launcher requires the type of cluster as a second argument
+	if args[0] == "launcher" {
+		if len(args) == 1 {
+			return fmt.Errorf("no cluster type provided, please provide one of the following: kubernetes, slurm")
+		} else if len(args) > 2 {
+			return fmt.Errorf("too many arguments provided, please provide only the item and the cluster type")
+		}
+		if args[1] != "kubernetes" && args[1] != "slurm" {
+			return fmt.Errorf("invalid cluster type provided, please provide one of the following: kubernetes, slurm")
+		}
+	}
+
+	// the cert-path flag is required for ssl and auth
+	if opts.certPath == "" && (args[0] == "ssl" || args[0] == "auth") {
+		return fmt.Errorf("the cert-path flag is required for %s", args[0])
+	}
+	// the key-path flag is required for ssl and auth
+	if opts.keyPath == "" && (args[0] == "ssl" || args[0] == "auth") {
+		return fmt.Errorf("the key-path flag is required for %s", args[0])
+	}
+	// the url flag is required for ssl and auth
+	if opts.url == "" && (args[0] == "ssl" || args[0] == "auth") {
+		return fmt.Errorf("the url flag is required for %s", args[0])
+	}
+
+	// the cert-path flag is only valid for ssl and auth
+	if opts.certPath != "" && args[0] != "ssl" && args[0] != "auth" {
+		return fmt.Errorf("the cert-path flag is only valid for ssl and auth")
+	}
+	// the key-path flag is only valid for ssl and auth
+	if opts.keyPath != "" && args[0] != "ssl" && args[0] != "auth" {
+		return fmt.Errorf("the key-path flag is only valid for ssl and auth")
+	}
+
+	// the url flag is only valid for repo, connect-url, ssl, auth and r-site
+	if opts.url != "" && (args[0] != "repo" && args[0] != "connect-url" && args[0] != "ssl" && args[0] != "auth" && args[0] != "r-site") {
+		return fmt.Errorf("the url flag is only valid for repo, connect-url, ssl, auth and r-site")
+	}
+
+	// the http-proxy, https-proxy, no-proxy and ca-bundle flags are only valid for r-site
+	if opts.httpProxy != "" && args[0] != "r-site" {
+		return fmt.Errorf("the http-proxy flag is only valid for r-site")
+	}
+	if opts.httpsProxy != "" && args[0] != "r-site" {
+		return fmt.Errorf("the https-proxy flag is only valid for r-site")
+	}
+	if opts.noProxy != "" && args[0] != "r-site" {
+		return fmt.Errorf("the no-proxy flag is only valid for r-site")
+	}
+	if opts.caBundle != "" && args[0] != "r-site" {
+		return fmt.Errorf("the ca-bundle flag is only valid for r-site")
+	}
+	// the CA bundle must exist for R to use it
+	if opts.caBundle != "" && !system.VerifyFileExists(opts.caBundle) {
+		return fmt.Errorf("the ca-bundle %s does not exist", opts.caBundle)
+	}
+
+	// the header flag is required for auth
+	if opts.header == "" && args[0] == "auth" {
+		return fmt.Errorf("the header flag is required for auth")
+	}
+	// the header, sign-in-url and proxy flags are only valid for auth
+	if opts.header != "" && args[0] != "auth" {
+		return fmt.Errorf("the header flag is only valid for auth")
+	}
+	if opts.signInURL != "" && args[0] != "auth" {
+		return fmt.Errorf("the sign-in-url flag is only valid for auth")
+	}
+	if opts.proxy != "" && args[0] != "auth" {
+		return fmt.Errorf("the proxy flag is only valid for auth")
+	}
+	// the only proxy servers allowed are nginx and apache
+	if args[0] == "auth" && opts.proxy != "" && (opts.proxy != "nginx" && opts.proxy != "apache") {
+		return fmt.Errorf("the proxy flag only allows nginx and apache")
+	}
+
+	// profiles requires the type of cluster as a second argument
+	if args[0] == "profiles" {
+		if len(args) == 1 {
+			return fmt.Errorf("no cluster type provided, please provide one of the following: local, kubernetes, slurm")
+		} else if len(args) > 2 {
+			return fmt.Errorf("too many arguments provided, please provide only the item and the cluster type")
+		}
+		if args[1] != "local" && args[1] != "kubernetes" && args[1] != "slurm" {
+			return fmt.Errorf("invalid cluster type provided, please provide one of the following: local, kubernetes, slurm")
+		}
+	}
+
+	// r-versions requires an action as a second argument
+	if args[0] == "r-versions" {
+		if len(args) == 1 {
+			return fmt.Errorf("no action provided, please provide one of the following: add, remove, list")
+		} else if len(args) > 2 {
+			return fmt.Errorf("too many arguments provided, please provide only the item and the action")
+		}
+		if args[1] != "add" && args[1] != "remove" && args[1] != "list" {
+			return fmt.Errorf("invalid action provided, please provide one of the following: add, remove, list")
+		}
+	}
+	rVersionsAction := ""
+	if args[0] == "r-versions" {
+		rVersionsAction = args[1]
+	}
+	// the path flag is required for r-versions add and remove
+	if opts.rPath == "" && (rVersionsAction == "add" || rVersionsAction == "remove") {
+		return fmt.Errorf("the path flag is required for r-versions %s", rVersionsAction)
+	}
+	// the path flag is only valid for r-versions add and remove
+	if opts.rPath != "" && rVersionsAction != "add" && rVersionsAction != "remove" {
+		return fmt.Errorf("the path flag is only valid for r-versions add and remove")
+	}
+	// the path must be absolute since Workbench reads it as is
+	if opts.rPath != "" && !filepath.IsAbs(opts.rPath) {
+		return fmt.Errorf("the path flag must be an absolute path")
+	}
+	// the label and module flags are only valid for r-versions add
+	if opts.rLabel != "" && rVersionsAction != "add" {
+		return fmt.Errorf("the label flag is only valid for r-versions add")
+	}
+	if opts.rModule != "" && rVersionsAction != "add" {
+		return fmt.Errorf("the module flag is only valid for r-versions add")
+	}
+
+	// the cluster type is only set for launcher
+	clusterType := ""
+	if args[0] == "launcher" {
+		clusterType = args[1]
+	}
+	// the profiles cluster type is only set for profiles
+	profilesType := ""
+	if args[0] == "profiles" {
+		profilesType = args[1]
+	}
+
+	// the api-url, token-file and image flags are required for launcher kubernetes
+	if opts.apiURL == "" && clusterType == "kubernetes" {
+		return fmt.Errorf("the api-url flag is required for launcher kubernetes")
+	}
+	if opts.tokenFile == "" && clusterType == "kubernetes" {
+		return fmt.Errorf("the token-file flag is required for launcher kubernetes")
+	}
+	if opts.image == "" && clusterType == "kubernetes" {
+		return fmt.Errorf("the image flag is required for launcher kubernetes")
+	}
+	// the api-url, namespace, token-file, ca-cert and image flags are only valid for launcher kubernetes
+	if opts.apiURL != "" && clusterType != "kubernetes" {
+		return fmt.Errorf("the api-url flag is only valid for launcher kubernetes")
 	}
-	// the key-path flag is required for ssl
-	if opts.keyPath == "" && args[0] == "ssl" {
-		return fmt.Errorf("the key-path flag is required for ssl")
+	if opts.namespace != "" && clusterType != "kubernetes" {
+		return fmt.Errorf("the namespace flag is only valid for launcher kubernetes")
 	}
-	// the url flag is required for ssl
-	if opts.url == "" && args[0] == "ssl" {
-		return fmt.Errorf("the url flag is required for ssl")
+	if opts.tokenFile != "" && clusterType != "kubernetes" {
+		return fmt.Errorf("the token-file flag is only valid for launcher kubernetes")
+	}
+	if opts.caCert != "" && clusterType != "kubernetes" {
+		return fmt.Errorf("the ca-cert flag is only valid for launcher kubernetes")
+	}
+	if opts.image != "" && clusterType != "kubernetes" {
+		return fmt.Errorf("the image flag is only valid for launcher kubernetes")
 	}
 
-	// the cert-path flag is only valid for ssl
-	if opts.certPath != "" && args[0] != "ssl" {
-		return fmt.Errorf("the cert-path flag is only valid for ssl")
+	// the slurm-bin-path, slurm-user and shared-path flags are only valid for launcher slurm
+	if opts.slurmBinPath != "" && clusterType != "slurm" {
+		return fmt.Errorf("the slurm-bin-path flag is only valid for launcher slurm")
+	}
+	if opts.slurmUser != "" && clusterType != "slurm" {
+		return fmt.Errorf("the slurm-user flag is only valid for launcher slurm")
+	}
+	if opts.sharedPath != "" && clusterType != "slurm" {
+		return fmt.Errorf("the shared-path flag is only valid for launcher slurm")
+	}
+	// the partitions, max-cpus and max-mem-mb flags are only valid for launcher slurm and profiles
+	if len(opts.partitions) != 0 && clusterType != "slurm" && profilesType == "" {
+		return fmt.Errorf("the partitions flag is only valid for launcher slurm and profiles")
+	}
+	if opts.maxCPUs != 0 && clusterType != "slurm" && profilesType == "" {
+		return fmt.Errorf("the max-cpus flag is only valid for launcher slurm and profiles")
 	}
-	// the key-path flag is only valid for ssl
-	if opts.keyPath != "" && args[0] != "ssl" {
-		return fmt.Errorf("the key-path flag is only valid for ssl")
+	if opts.maxMemMB != 0 && clusterType != "slurm" && profilesType == "" {
+		return fmt.Errorf("the max-mem-mb flag is only valid for launcher slurm and profiles")
+	}
+	// resource limits must be positive
+	if opts.maxCPUs < 0 || opts.maxMemMB < 0 || opts.defaultCPUs < 0 || opts.defaultMemMB < 0 {
+		return fmt.Errorf("the default-cpus, max-cpus, default-mem-mb and max-mem-mb flags must be positive numbers")
 	}
 
-	// the url flag is only valid for repo, connect-url and ssl
-	if opts.url != "" && (args[0] != "repo" && args[0] != "connect-url" && args[0] != "ssl") {
-		return fmt.Errorf("the url flag is only valid for repo, connect-url and url")
+	// the user, group, default-cpus, default-mem-mb and images flags are only valid for profiles
+	if opts.user != "" && profilesType == "" {
+		return fmt.Errorf("the user flag is only valid for profiles")
+	}
+	if opts.group != "" && profilesType == "" {
+		return fmt.Errorf("the group flag is only valid for profiles")
+	}
+	if opts.defaultCPUs != 0 && profilesType == "" {
+		return fmt.Errorf("the default-cpus flag is only valid for profiles")
+	}
+	if opts.defaultMemMB != 0 && profilesType == "" {
+		return fmt.Errorf("the default-mem-mb flag is only valid for profiles")
+	}
+	if len(opts.images) != 0 && profilesType == "" {
+		return fmt.Errorf("the images flag is only valid for profiles")
+	}
+	// a profile applies to either a user or a group
+	if opts.user != "" && opts.group != "" {
+		return fmt.Errorf("the user and group flags cannot be used together")
+	}
+	// images are only valid for kubernetes profiles and partitions for slurm profiles
+	if len(opts.images) != 0 && profilesType != "" && profilesType != "kubernetes" {
+		return fmt.Errorf("the images flag is only valid for kubernetes profiles")
+	}
+	if len(opts.partitions) != 0 && profilesType != "" && profilesType != "slurm" {
+		return fmt.Errorf("the partitions flag is only valid for slurm profiles")
+	}
+	// defaults can't be more than the maximums
+	if opts.maxCPUs > 0 && opts.defaultCPUs > opts.maxCPUs {
+		return fmt.Errorf("the default-cpus flag cannot be greater than the max-cpus flag")
+	}
+	if opts.maxMemMB > 0 && opts.defaultMemMB > opts.maxMemMB {
+		return fmt.Errorf("the default-mem-mb flag cannot be greater than the max-mem-mb flag")
+	}
+
+	// the vscode-args flag is only valid for vscode
+	if opts.vscodeArgs != "" && args[0] != "vscode" {
+		return fmt.Errorf("the vscode-args flag is only valid for vscode")
+	}
+	// the extensions flag is only valid for vscode and positron
+	if len(opts.extensions) != 0 && args[0] != "vscode" && args[0] != "positron" {
+		return fmt.Errorf("the extensions flag is only valid for vscode and positron")
+	}
+	// extensions must be publisher.name ids or .vsix files
+	if len(opts.extensions) != 0 {
+		err := vscode.ValidateExtensions(opts.extensions)
+		if err != nil {
+			return fmt.Errorf("invalid extensions: %w", err)
+		}
 	}
 
 	// the url flag is required for repo
@@ -129,11 +596,54 @@ func newConfigCmd() *configCmd {
 		"",
 		"To configure a default Posit Connect server:",
 		"  wbi config connect-url --url [CONNECT-SERVER-URL]",
+		"",
+		"To configure proxied authentication and generate a matching nginx or Apache config:",
+		"  wbi config auth proxy --header X-Remote-User --cert-path [PATH-TO-CERTIFICATE-FILE] --key-path [PATH-TO-KEY-FILE] --url [SERVER-URL]",
+		"  wbi config auth proxy --header X-Remote-User --sign-in-url [SIGN-IN-URL] --proxy apache --cert-path [PATH-TO-CERTIFICATE-FILE] --key-path [PATH-TO-KEY-FILE] --url [SERVER-URL]",
+		"",
+		"To configure the Job Launcher to launch sessions in Kubernetes:",
+		"  wbi config launcher kubernetes --api-url [KUBERNETES-API-URL] --token-file [PATH-TO-TOKEN-FILE] --image [SESSION-IMAGE]",
+		"  wbi config launcher kubernetes --api-url [KUBERNETES-API-URL] --namespace rstudio --token-file [PATH-TO-TOKEN-FILE] --ca-cert [PATH-TO-CA-CERTIFICATE] --image [SESSION-IMAGE]",
+		"",
+		"To configure the Job Launcher to launch sessions in Slurm:",
+		"  wbi config launcher slurm",
+		"  wbi config launcher slurm --slurm-bin-path /usr/bin --slurm-user slurm --partitions compute,gpu --shared-path [PATH-TO-SESSION-COMPONENTS] --max-cpus 16 --max-mem-mb 65536",
+		"",
+		"To show the launcher resource profiles for local, Kubernetes or Slurm sessions:",
+		"  wbi config profiles kubernetes",
+		"",
+		"To set resource limits for everyone, a group or a user:",
+		"  wbi config profiles local --default-cpus 1 --max-cpus 4",
+		"  wbi config profiles slurm --group datasci --default-cpus 2 --max-cpus 16 --default-mem-mb 4096 --max-mem-mb 65536 --partitions compute,gpu",
+		"  wbi config profiles kubernetes --user jdoe --max-cpus 8 --images rstudio/r-session-complete:ubuntu2204",
+		"",
+		"To enable VS Code sessions and install the default R, Python and Quarto extensions:",
+		"  wbi config vscode",
+		"",
+		"To enable VS Code sessions with custom session arguments and extensions (ids or .vsix files for offline installs):",
+		"  wbi config vscode --vscode-args \"--host=0.0.0.0 --verbose\" --extensions quarto.quarto,/path/to/extension.vsix",
+		"",
+		"To enable Positron sessions, set the default R and Python interpreters and install the default extensions:",
+		"  wbi config positron",
+		"  wbi config positron --extensions quarto.quarto,posit.shiny",
+		"",
+		"To point Rscript, cron jobs and other non-Workbench R sessions at the Workbench CRAN repo for every installed R version:",
+		"  wbi config r-site",
+		"",
+		"To also set the repo URL, proxy and CA bundle in Rprofile.site and Renviron.site:",
+		"  wbi config r-site --url [REPO-URL] --http-proxy [PROXY-URL] --https-proxy [PROXY-URL] --no-proxy localhost,127.0.0.1 --ca-bundle /etc/ssl/certs/ca-certificates.crt",
+		"",
+		"To register a custom R install with Workbench in /etc/rstudio/r-versions:",
+		"  wbi config r-versions add --path /shared/R/4.3.2 --label \"R 4.3 (validated)\" --module r/4.3",
+		"",
+		"To remove a registered R install or list the registered R installs:",
+		"  wbi config r-versions remove --path /shared/R/4.3.2",
+		"  wbi config r-versions list",
 	}
 
 	cmd := &cobra.Command{
 		Use:     "config [item]",
-		Short:   "Configure SSL, package repos, or a Connect server in Posit Workbench",
+		Short:   "Configure SSL, package repos, a Connect server, proxied authentication, the Job Launcher, launcher resource profiles, VS Code, Positron or custom R installs in Posit Workbench",
 		Example: strings.Join(exampleText, "\n"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			setConfigOpts(&root.opts)
@@ -144,7 +654,7 @@ func newConfigCmd() *configCmd {
 		},
 		RunE: func(_ *cobra.Command, args []string) error {
 			log.WithField("opts", fmt.Sprintf("%+v", root.opts)).Trace("config-opts")
-			if err := newConfig(root.opts, strings.ToLower(args[0])); err != nil {
+			if err := newConfig(root.opts, lowerArgs(args)); err != nil {
 				return err
 			}
 			return nil
@@ -164,6 +674,90 @@ func newConfigCmd() *configCmd {
 	cmd.Flags().StringP("source", "s", "", "Repository source (cran or pypi)")
 	viper.BindPFlag("source", cmd.Flags().Lookup("source"))
 
+	cmd.Flags().StringP("header", "", "", "Header containing the authenticated username for proxied authentication")
+	viper.BindPFlag("header", cmd.Flags().Lookup("header"))
+
+	cmd.Flags().StringP("sign-in-url", "", "", "URL users are sent to when they need to sign in for proxied authentication")
+	viper.BindPFlag("sign-in-url", cmd.Flags().Lookup("sign-in-url"))
+
+	cmd.Flags().StringP("proxy", "", "", "Reverse proxy server config to generate for proxied authentication (nginx or apache, defaults to nginx)")
+	viper.BindPFlag("proxy", cmd.Flags().Lookup("proxy"))
+
+	cmd.Flags().StringP("api-url", "", "", "Kubernetes API URL for the Job Launcher")
+	viper.BindPFlag("api-url", cmd.Flags().Lookup("api-url"))
+
+	cmd.Flags().StringP("namespace", "", "", "Kubernetes namespace sessions are launched in (defaults to rstudio)")
+	viper.BindPFlag("namespace", cmd.Flags().Lookup("namespace"))
+
+	cmd.Flags().StringP("token-file", "", "", "Path to a file containing the Kubernetes service account token")
+	viper.BindPFlag("token-file", cmd.Flags().Lookup("token-file"))
+
+	cmd.Flags().StringP("ca-cert", "", "", "Path to the Kubernetes cluster CA certificate")
+	viper.BindPFlag("ca-cert", cmd.Flags().Lookup("ca-cert"))
+
+	cmd.Flags().StringP("image", "", "", "Default session container image for the Job Launcher")
+	viper.BindPFlag("image", cmd.Flags().Lookup("image"))
+
+	cmd.Flags().StringP("slurm-bin-path", "", "", "Directory containing the Slurm binaries (defaults to /usr/bin)")
+	viper.BindPFlag("slurm-bin-path", cmd.Flags().Lookup("slurm-bin-path"))
+
+	cmd.Flags().StringP("slurm-user", "", "", "Slurm service user the Job Launcher submits jobs as (defaults to slurm)")
+	viper.BindPFlag("slurm-user", cmd.Flags().Lookup("slurm-user"))
+
+	cmd.Flags().StringSliceP("partitions", "", []string{}, "Slurm partitions sessions can be launched in. Multiple values can be passed by seperating each partition with a comma.")
+	viper.BindPFlag("partitions", cmd.Flags().Lookup("partitions"))
+
+	cmd.Flags().StringP("shared-path", "", "", "Shared filesystem path containing the Workbench session components used by Slurm compute nodes")
+	viper.BindPFlag("shared-path", cmd.Flags().Lookup("shared-path"))
+
+	cmd.Flags().IntP("max-cpus", "", 0, "Maximum number of CPUs a session can request")
+	viper.BindPFlag("max-cpus", cmd.Flags().Lookup("max-cpus"))
+
+	cmd.Flags().IntP("max-mem-mb", "", 0, "Maximum memory in MB a session can request")
+	viper.BindPFlag("max-mem-mb", cmd.Flags().Lookup("max-mem-mb"))
+
+	cmd.Flags().StringP("user", "", "", "User a launcher resource profile applies to")
+	viper.BindPFlag("user", cmd.Flags().Lookup("user"))
+
+	cmd.Flags().StringP("group", "", "", "Group a launcher resource profile applies to")
+	viper.BindPFlag("group", cmd.Flags().Lookup("group"))
+
+	cmd.Flags().IntP("default-cpus", "", 0, "Default number of CPUs for sessions in a launcher resource profile")
+	viper.BindPFlag("default-cpus", cmd.Flags().Lookup("default-cpus"))
+
+	cmd.Flags().IntP("default-mem-mb", "", 0, "Default memory in MB for sessions in a launcher resource profile")
+	viper.BindPFlag("default-mem-mb", cmd.Flags().Lookup("default-mem-mb"))
+
+	cmd.Flags().StringSliceP("images", "", []string{}, "Container images allowed in a Kubernetes resource profile. Multiple values can be passed by seperating each image with a comma.")
+	viper.BindPFlag("images", cmd.Flags().Lookup("images"))
+
+	cmd.Flags().StringP("vscode-args", "", "", "Arguments passed to VS Code sessions (defaults to --host=0.0.0.0). --extensions-dir /opt/code-server/extensions is added unless another extensions directory is given")
+	viper.BindPFlag("vscode-args", cmd.Flags().Lookup("vscode-args"))
+
+	cmd.Flags().StringSliceP("extensions", "", []string{}, "VS Code or Positron extension ids or .vsix files to install for all users. Multiple values can be passed by seperating each extension with a comma.")
+	viper.BindPFlag("extensions", cmd.Flags().Lookup("extensions"))
+
+	cmd.Flags().String("http-proxy", "", "HTTP proxy R uses to download packages, written to Renviron.site")
+	viper.BindPFlag("http-proxy", cmd.Flags().Lookup("http-proxy"))
+
+	cmd.Flags().String("https-proxy", "", "HTTPS proxy R uses to download packages, written to Renviron.site")
+	viper.BindPFlag("https-proxy", cmd.Flags().Lookup("https-proxy"))
+
+	cmd.Flags().String("no-proxy", "", "Comma separated hosts R connects to without the proxy, written to Renviron.site")
+	viper.BindPFlag("no-proxy", cmd.Flags().Lookup("no-proxy"))
+
+	cmd.Flags().String("ca-bundle", "", "CA certificate bundle R uses for HTTPS downloads, written to Renviron.site")
+	viper.BindPFlag("ca-bundle", cmd.Flags().Lookup("ca-bundle"))
+
+	cmd.Flags().String("path", "", "Directory of a custom R install to add to or remove from /etc/rstudio/r-versions")
+	viper.BindPFlag("rversions-path", cmd.Flags().Lookup("path"))
+
+	cmd.Flags().String("label", "", "Label shown for the R install in Workbench")
+	viper.BindPFlag("rversions-label", cmd.Flags().Lookup("label"))
+
+	cmd.Flags().String("module", "", "Environment module to load before starting the R install")
+	viper.BindPFlag("rversions-module", cmd.Flags().Lookup("module"))
+
 	root.cmd = cmd
 	return root
 }
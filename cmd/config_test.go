@@ -107,6 +107,288 @@ func TestConfigParamsValidate(t *testing.T) {
 			flags:       configOpts{url: "https://packagemanager.posit.co", keyPath: "cert.key"},
 			expectError: "the key-path flag is only valid for ssl",
 		},
+		// auth argument tests
+		"auth argument only fails": {
+			args:        []string{"auth"},
+			flags:       configOpts{},
+			expectError: "no authentication type provided, please provide one of the following: proxy",
+		},
+		"auth argument with an invalid type fails": {
+			args:        []string{"auth", "saml"},
+			flags:       configOpts{},
+			expectError: "invalid authentication type provided, please provide one of the following: proxy",
+		},
+		"auth proxy arguments without flags fails": {
+			args:        []string{"auth", "proxy"},
+			flags:       configOpts{},
+			expectError: "the cert-path flag is required for auth",
+		},
+		"auth proxy arguments without header flag fails": {
+			args:        []string{"auth", "proxy"},
+			flags:       configOpts{certPath: "cert.crt", keyPath: "cert.key", url: "myserverurl.com"},
+			expectError: "the header flag is required for auth",
+		},
+		"auth proxy arguments with cert-path, key-path, url and header flags succeeds": {
+			args:        []string{"auth", "proxy"},
+			flags:       configOpts{certPath: "cert.crt", keyPath: "cert.key", url: "myserverurl.com", header: "X-Remote-User"},
+			expectError: "",
+		},
+		"auth proxy arguments with apache proxy and sign-in-url flags succeeds": {
+			args:        []string{"auth", "proxy"},
+			flags:       configOpts{certPath: "cert.crt", keyPath: "cert.key", url: "myserverurl.com", header: "X-Remote-User", signInURL: "https://sso.example.com", proxy: "apache"},
+			expectError: "",
+		},
+		"auth proxy arguments with an invalid proxy flag fails": {
+			args:        []string{"auth", "proxy"},
+			flags:       configOpts{certPath: "cert.crt", keyPath: "cert.key", url: "myserverurl.com", header: "X-Remote-User", proxy: "haproxy"},
+			expectError: "the proxy flag only allows nginx and apache",
+		},
+		"auth proxy arguments with too many arguments fails": {
+			args:        []string{"auth", "proxy", "nginx"},
+			flags:       configOpts{},
+			expectError: "too many arguments provided, please provide only the item and the authentication type",
+		},
+		"ssl argument with header flag fails": {
+			args:        []string{"ssl"},
+			flags:       configOpts{certPath: "cert.crt", keyPath: "cert.key", url: "myserverurl.com", header: "X-Remote-User"},
+			expectError: "the header flag is only valid for auth",
+		},
+		// launcher argument tests
+		"launcher argument only fails": {
+			args:        []string{"launcher"},
+			flags:       configOpts{},
+			expectError: "no cluster type provided, please provide one of the following: kubernetes",
+		},
+		"launcher argument with an invalid type fails": {
+			args:        []string{"launcher", "pbs"},
+			flags:       configOpts{},
+			expectError: "invalid cluster type provided, please provide one of the following: kubernetes",
+		},
+		"launcher kubernetes arguments without flags fails": {
+			args:        []string{"launcher", "kubernetes"},
+			flags:       configOpts{},
+			expectError: "the api-url flag is required for launcher",
+		},
+		"launcher kubernetes arguments without token-file flag fails": {
+			args:        []string{"launcher", "kubernetes"},
+			flags:       configOpts{apiURL: "https://kubernetes.example.com:6443", image: "rstudio/r-session-complete:ubuntu2204"},
+			expectError: "the token-file flag is required for launcher",
+		},
+		"launcher kubernetes arguments without image flag fails": {
+			args:        []string{"launcher", "kubernetes"},
+			flags:       configOpts{apiURL: "https://kubernetes.example.com:6443", tokenFile: "token"},
+			expectError: "the image flag is required for launcher",
+		},
+		"launcher kubernetes arguments with api-url, token-file and image flags succeeds": {
+			args:        []string{"launcher", "kubernetes"},
+			flags:       configOpts{apiURL: "https://kubernetes.example.com:6443", tokenFile: "token", image: "rstudio/r-session-complete:ubuntu2204"},
+			expectError: "",
+		},
+		"launcher kubernetes arguments with namespace and ca-cert flags succeeds": {
+			args:        []string{"launcher", "kubernetes"},
+			flags:       configOpts{apiURL: "https://kubernetes.example.com:6443", namespace: "workbench", tokenFile: "token", caCert: "ca.crt", image: "rstudio/r-session-complete:ubuntu2204"},
+			expectError: "",
+		},
+		"ssl argument with namespace flag fails": {
+			args:        []string{"ssl"},
+			flags:       configOpts{certPath: "cert.crt", keyPath: "cert.key", url: "myserverurl.com", namespace: "rstudio"},
+			expectError: "the namespace flag is only valid for launcher",
+		},
+		"launcher slurm arguments without flags succeeds": {
+			args:        []string{"launcher", "slurm"},
+			flags:       configOpts{},
+			expectError: "",
+		},
+		"launcher slurm arguments with all slurm flags succeeds": {
+			args:        []string{"launcher", "slurm"},
+			flags:       configOpts{slurmBinPath: "/usr/bin", slurmUser: "slurm", partitions: []string{"compute", "gpu"}, sharedPath: "/shared/rstudio", maxCPUs: 16, maxMemMB: 65536},
+			expectError: "",
+		},
+		"launcher slurm arguments with a kubernetes flag fails": {
+			args:        []string{"launcher", "slurm"},
+			flags:       configOpts{image: "rstudio/r-session-complete:ubuntu2204"},
+			expectError: "the image flag is only valid for launcher kubernetes",
+		},
+		"launcher kubernetes arguments with a slurm flag fails": {
+			args:        []string{"launcher", "kubernetes"},
+			flags:       configOpts{apiURL: "https://kubernetes.example.com:6443", tokenFile: "token", image: "rstudio/r-session-complete:ubuntu2204", partitions: []string{"compute"}},
+			expectError: "the partitions flag is only valid for launcher slurm",
+		},
+		"launcher slurm arguments with negative max-cpus fails": {
+			args:        []string{"launcher", "slurm"},
+			flags:       configOpts{maxCPUs: -1},
+			expectError: "the default-cpus, max-cpus, default-mem-mb and max-mem-mb flags must be positive numbers",
+		},
+		// profiles argument tests
+		"profiles argument only fails": {
+			args:        []string{"profiles"},
+			flags:       configOpts{},
+			expectError: "no cluster type provided, please provide one of the following: local, kubernetes, slurm",
+		},
+		"profiles argument with an invalid type fails": {
+			args:        []string{"profiles", "pbs"},
+			flags:       configOpts{},
+			expectError: "invalid cluster type provided, please provide one of the following: local, kubernetes, slurm",
+		},
+		"profiles arguments without flags succeeds": {
+			args:        []string{"profiles", "local"},
+			flags:       configOpts{},
+			expectError: "",
+		},
+		"profiles slurm arguments with group limits and partitions succeeds": {
+			args:        []string{"profiles", "slurm"},
+			flags:       configOpts{group: "datasci", defaultCPUs: 2, maxCPUs: 16, defaultMemMB: 4096, maxMemMB: 65536, partitions: []string{"compute"}},
+			expectError: "",
+		},
+		"profiles kubernetes arguments with user limits and images succeeds": {
+			args:        []string{"profiles", "kubernetes"},
+			flags:       configOpts{user: "jdoe", maxCPUs: 8, images: []string{"rstudio/r-session-complete:ubuntu2204"}},
+			expectError: "",
+		},
+		"profiles arguments with user and group flags fails": {
+			args:        []string{"profiles", "local"},
+			flags:       configOpts{user: "jdoe", group: "datasci"},
+			expectError: "the user and group flags cannot be used together",
+		},
+		"profiles arguments with default-cpus greater than max-cpus fails": {
+			args:        []string{"profiles", "local"},
+			flags:       configOpts{defaultCPUs: 8, maxCPUs: 4},
+			expectError: "the default-cpus flag cannot be greater than the max-cpus flag",
+		},
+		"profiles arguments with default-mem-mb greater than max-mem-mb fails": {
+			args:        []string{"profiles", "local"},
+			flags:       configOpts{defaultMemMB: 8192, maxMemMB: 4096},
+			expectError: "the default-mem-mb flag cannot be greater than the max-mem-mb flag",
+		},
+		"profiles local arguments with images flag fails": {
+			args:        []string{"profiles", "local"},
+			flags:       configOpts{images: []string{"rstudio/r-session-complete:ubuntu2204"}},
+			expectError: "the images flag is only valid for kubernetes profiles",
+		},
+		"profiles kubernetes arguments with partitions flag fails": {
+			args:        []string{"profiles", "kubernetes"},
+			flags:       configOpts{partitions: []string{"compute"}},
+			expectError: "the partitions flag is only valid for slurm profiles",
+		},
+		"ssl argument with group flag fails": {
+			args:        []string{"ssl"},
+			flags:       configOpts{certPath: "cert.crt", keyPath: "cert.key", url: "myserverurl.com", group: "datasci"},
+			expectError: "the group flag is only valid for profiles",
+		},
+		// vscode argument tests
+		"vscode argument without flags succeeds": {
+			args:        []string{"vscode"},
+			flags:       configOpts{},
+			expectError: "",
+		},
+		"vscode argument with vscode-args and extensions flags succeeds": {
+			args:        []string{"vscode"},
+			flags:       configOpts{vscodeArgs: "--host=0.0.0.0", extensions: []string{"quarto.quarto", "REditorSupport.r"}},
+			expectError: "",
+		},
+		"vscode argument with an invalid extension id fails": {
+			args:        []string{"vscode"},
+			flags:       configOpts{extensions: []string{"quarto"}},
+			expectError: "the extension quarto is not a valid extension id",
+		},
+		"vscode argument with a missing vsix file fails": {
+			args:        []string{"vscode"},
+			flags:       configOpts{extensions: []string{"/path/does/not/exist/quarto.quarto-1.0.0.vsix"}},
+			expectError: "does not exist",
+		},
+		"vscode argument with too many arguments fails": {
+			args:        []string{"vscode", "positron"},
+			flags:       configOpts{},
+			expectError: "too many arguments provided, please provide only one argument",
+		},
+		"ssl argument with extensions flag fails": {
+			args:        []string{"ssl"},
+			flags:       configOpts{certPath: "cert.crt", keyPath: "cert.key", url: "myserverurl.com", extensions: []string{"quarto.quarto"}},
+			expectError: "the extensions flag is only valid for vscode and positron",
+		},
+		// positron argument tests
+		"positron argument without flags succeeds": {
+			args:        []string{"positron"},
+			flags:       configOpts{},
+			expectError: "",
+		},
+		"positron argument with extensions flag succeeds": {
+			args:        []string{"positron"},
+			flags:       configOpts{extensions: []string{"quarto.quarto"}},
+			expectError: "",
+		},
+		"positron argument with vscode-args flag fails": {
+			args:        []string{"positron"},
+			flags:       configOpts{vscodeArgs: "--host=0.0.0.0"},
+			expectError: "the vscode-args flag is only valid for vscode",
+		},
+		// r-site argument tests
+		"r-site argument without flags succeeds": {
+			args:        []string{"r-site"},
+			flags:       configOpts{},
+			expectError: "",
+		},
+		"r-site argument with url and proxy flags succeeds": {
+			args:        []string{"r-site"},
+			flags:       configOpts{url: "https://packagemanager.posit.co/cran/__linux__/jammy/latest", httpProxy: "http://proxy:3128", httpsProxy: "http://proxy:3128", noProxy: "localhost"},
+			expectError: "",
+		},
+		"r-site argument with a missing ca-bundle fails": {
+			args:        []string{"r-site"},
+			flags:       configOpts{caBundle: "/path/does/not/exist/ca.crt"},
+			expectError: "the ca-bundle /path/does/not/exist/ca.crt does not exist",
+		},
+		"repo argument with http-proxy flag fails": {
+			args:        []string{"repo"},
+			flags:       configOpts{url: "https://packagemanager.posit.co/cran/latest", source: "cran", httpProxy: "http://proxy:3128"},
+			expectError: "the http-proxy flag is only valid for r-site",
+		},
+		// r-versions argument tests
+		"r-versions argument without an action fails": {
+			args:        []string{"r-versions"},
+			flags:       configOpts{},
+			expectError: "no action provided, please provide one of the following: add, remove, list",
+		},
+		"r-versions argument with an invalid action fails": {
+			args:        []string{"r-versions", "update"},
+			flags:       configOpts{},
+			expectError: "invalid action provided, please provide one of the following: add, remove, list",
+		},
+		"r-versions list succeeds": {
+			args:        []string{"r-versions", "list"},
+			flags:       configOpts{},
+			expectError: "",
+		},
+		"r-versions add with path, label and module flags succeeds": {
+			args:        []string{"r-versions", "add"},
+			flags:       configOpts{rPath: "/shared/R/4.3.2", rLabel: "R 4.3 (validated)", rModule: "r/4.3"},
+			expectError: "",
+		},
+		"r-versions add without the path flag fails": {
+			args:        []string{"r-versions", "add"},
+			flags:       configOpts{rLabel: "R 4.3 (validated)"},
+			expectError: "the path flag is required for r-versions add",
+		},
+		"r-versions add with a relative path fails": {
+			args:        []string{"r-versions", "add"},
+			flags:       configOpts{rPath: "shared/R/4.3.2"},
+			expectError: "the path flag must be an absolute path",
+		},
+		"r-versions remove with the label flag fails": {
+			args:        []string{"r-versions", "remove"},
+			flags:       configOpts{rPath: "/shared/R/4.3.2", rLabel: "R 4.3 (validated)"},
+			expectError: "the label flag is only valid for r-versions add",
+		},
+		"r-versions list with the path flag fails": {
+			args:        []string{"r-versions", "list"},
+			flags:       configOpts{rPath: "/shared/R/4.3.2"},
+			expectError: "the path flag is only valid for r-versions add and remove",
+		},
+		"ssl argument with module flag fails": {
+			args:        []string{"ssl"},
+			flags:       configOpts{certPath: "cert.crt", keyPath: "cert.key", url: "myserverurl.com", rModule: "r/4.3"},
+			expectError: "the module flag is only valid for r-versions add",
+		},
 	}
 
 	for name, tc := range tests {
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/go-version"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/jupyter"
+	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/quarto"
+	"github.com/sol-eng/wbi/internal/system"
+	"github.com/sol-eng/wbi/internal/workbench"
+	"github.com/spf13/cobra"
+)
+
+type defaultCmd struct {
+	cmd  *cobra.Command
+	opts defaultOpts
+}
+
+type defaultOpts struct {
+}
+
+// currentDefault returns where the default of a language currently points
+func currentDefault(language string) string {
+	var current string
+	switch language {
+	case "r":
+		current = languages.CurrentDefaultR()
+	case "python":
+		current = languages.CurrentDefaultPython()
+	case "quarto":
+		current = quarto.CurrentDefaultQuarto()
+	}
+	if current == "" {
+		return "not set"
+	}
+	return current
+}
+
+func printDefaults(targets []string) {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "LANGUAGE\tDEFAULT")
+	for _, language := range targets {
+		fmt.Fprintln(writer, programDisplayName(language)+"\t"+currentDefault(language))
+	}
+	writer.Flush()
+	system.PrintAndLogInfo(strings.TrimSuffix(builder.String(), "\n"))
+}
+
+// setDefaultR repoints the R symlinks and Workbench's default R version
+func setDefaultR(rVersion string) error {
+	rPath, err := languages.SetDefaultR(rVersion)
+	if err != nil {
+		return fmt.Errorf("issue setting the default R version: %w", err)
+	}
+	// only update Workbench if it is installed
+	if system.VerifyFileExists("/etc/rstudio/rserver.conf") {
+		err = workbench.WriteDefaultRConfig(rPath)
+		if err != nil {
+			return fmt.Errorf("issue setting the default R version for Workbench: %w", err)
+		}
+		system.PrintAndLogInfo("\nWorkbench sessions will default to R " + rVersion + " once Workbench is restarted.")
+	}
+	return nil
+}
+
+// setDefaultPython repoints the Python PATH entry and registers a Jupyter kernel for the new default if Jupyter is configured
+func setDefaultPython(pythonVersion string) error {
+	pythonPath, err := languages.SetDefaultPython(pythonVersion)
+	if err != nil {
+		return fmt.Errorf("issue setting the default Python version: %w", err)
+	}
+
+	if jupyter.ConfiguredJupyterExe() == "" {
+		return nil
+	}
+	installDir := filepath.Dir(filepath.Dir(pythonPath)) + "/"
+	kernelExists := lo.ContainsBy(jupyter.RegisteredKernels(), func(kernel jupyter.Kernel) bool {
+		return strings.HasPrefix(kernel.Executable, installDir)
+	})
+	if !kernelExists {
+		err = jupyter.RegisterJupyterKernels([]string{pythonPath})
+		if err != nil {
+			return fmt.Errorf("issue registering a Jupyter kernel for Python %s: %w", pythonVersion, err)
+		}
+	}
+	return nil
+}
+
+func newDefault(defaultOpts defaultOpts, args []string) error {
+	// show the current defaults when no version is given
+	if len(args) < 2 {
+		targets := []string{"r", "python", "quarto"}
+		if len(args) == 1 {
+			targets = args
+		}
+		printDefaults(targets)
+		return nil
+	}
+
+	switch args[0] {
+	case "r":
+		return setDefaultR(args[1])
+	case "python":
+		return setDefaultPython(args[1])
+	case "quarto":
+		err := quarto.SetDefaultQuarto(args[1])
+		if err != nil {
+			return fmt.Errorf("issue setting the default Quarto version: %w", err)
+		}
+	}
+	return nil
+}
+
+func (opts *defaultOpts) Validate(args []string) error {
+	// check args lengths
+	if len(args) > 2 {
+		return fmt.Errorf("too many arguments provided, please provide only the language and the version")
+	}
+
+	// ensure only r, python or quarto is provided
+	if len(args) >= 1 && args[0] != "r" && args[0] != "python" && args[0] != "quarto" {
+		return fmt.Errorf("invalid language provided, please provide one of the following: r, python, quarto")
+	}
+
+	// the version must be an exact version
+	if len(args) == 2 {
+		if _, err := version.NewVersion(args[1]); err != nil {
+			return fmt.Errorf("invalid version %s provided, please provide an installed version such as 4.3.2", args[1])
+		}
+	}
+	return nil
+}
+
+func newDefaultCmd() *defaultCmd {
+	var defaultOpts defaultOpts
+
+	root := &defaultCmd{opts: defaultOpts}
+
+	// adding two spaces to have consistent formatting
+	exampleText := []string{
+		"To show the current default R, Python and Quarto:",
+		"  wbi default",
+		"  wbi default r",
+		"",
+		"To switch the default R, Python or Quarto to an installed version:",
+		"  wbi default r 4.3.2",
+		"  wbi default python 3.11.6",
+		"  wbi default quarto 1.4.550",
+	}
+
+	cmd := &cobra.Command{
+		Use:     "default [language] [version]",
+		Short:   "Show or switch the default version of R, Python or Quarto",
+		Example: strings.Join(exampleText, "\n"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := root.opts.Validate(lowerArgs(args)); err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			log.WithField("opts", fmt.Sprintf("%+v", root.opts)).Trace("default-opts")
+			if err := newDefault(root.opts, lowerArgs(args)); err != nil {
+				return err
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	root.cmd = cmd
+	return root
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDefaultParamsValidate tests the default command parameters
+func TestDefaultParamsValidate(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		flags       defaultOpts
+		expectError string
+	}{
+		"no argument succeeds": {
+			args:        []string{},
+			flags:       defaultOpts{},
+			expectError: "",
+		},
+		"r argument succeeds": {
+			args:        []string{"r"},
+			flags:       defaultOpts{},
+			expectError: "",
+		},
+		"r argument with version succeeds": {
+			args:        []string{"r", "4.3.2"},
+			flags:       defaultOpts{},
+			expectError: "",
+		},
+		"python argument with version succeeds": {
+			args:        []string{"python", "3.11.6"},
+			flags:       defaultOpts{},
+			expectError: "",
+		},
+		"quarto argument with version succeeds": {
+			args:        []string{"quarto", "1.4.550"},
+			flags:       defaultOpts{},
+			expectError: "",
+		},
+		"invalid version fails": {
+			args:        []string{"r", "latest"},
+			flags:       defaultOpts{},
+			expectError: "invalid version latest provided, please provide an installed version such as 4.3.2",
+		},
+		"too many arguments fails": {
+			args:        []string{"r", "4.3.2", "4.2.3"},
+			flags:       defaultOpts{},
+			expectError: "too many arguments provided, please provide only the language and the version",
+		},
+		"workbench argument fails": {
+			args:        []string{"workbench"},
+			flags:       defaultOpts{},
+			expectError: "invalid language provided, please provide one of the following: r, python, quarto",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			defaultCmd := newDefaultCmd()
+			// set the flags
+			defaultCmd.opts = tc.flags
+			// run validation
+			err := defaultCmd.opts.Validate(tc.args)
+
+			if err != nil && tc.expectError != "" {
+				// if we expect an error, check that it contains the expected error
+				assert.Containsf(t, err.Error(), tc.expectError, "expected error containing %q, got %s", tc.expectError, err)
+			} else if err != nil && tc.expectError == "" {
+				// if we expect no error but get one then fail
+				t.Fatalf("expected no error, but got %s", err)
+			} else if err == nil && tc.expectError != "" {
+				// if we expect an error but don't get one then fail
+				t.Fatalf("expected error containing %q, but the command ran without error", tc.expectError)
+			}
+		})
+	}
+}
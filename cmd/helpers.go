@@ -29,3 +29,32 @@ func setLogLevel(level string) {
 		log.Fatalf("Invalid log level: %s", logLevel)
 	}
 }
+
+// lowerArgs returns a copy of the positional arguments in lower case
+func lowerArgs(args []string) []string {
+	lowered := make([]string, len(args))
+	for i, arg := range args {
+		lowered[i] = strings.ToLower(arg)
+	}
+	return lowered
+}
+
+// programDisplayName returns the name of a program as it is shown to users
+func programDisplayName(program string) string {
+	switch program {
+	case "r":
+		return "R"
+	case "python":
+		return "Python"
+	case "quarto":
+		return "Quarto"
+	case "workbench":
+		return "Workbench"
+	case "prodrivers":
+		return "Pro Drivers"
+	case "jupyter":
+		return "Jupyter"
+	default:
+		return program
+	}
+}
@@ -2,14 +2,20 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/hashicorp/go-version"
 	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/conda"
+	"github.com/sol-eng/wbi/internal/config"
 	"github.com/sol-eng/wbi/internal/jupyter"
 	"github.com/sol-eng/wbi/internal/languages"
 	"github.com/sol-eng/wbi/internal/operatingsystem"
 	"github.com/sol-eng/wbi/internal/quarto"
 
+	"github.com/sol-eng/wbi/internal/packagemanager"
 	"github.com/sol-eng/wbi/internal/prodrivers"
 	"github.com/sol-eng/wbi/internal/system"
 	"github.com/sol-eng/wbi/internal/workbench"
@@ -23,13 +29,52 @@ type installCmd struct {
 }
 
 type installOpts struct {
-	versions  []string
-	path      string
-	symlink   bool
-	addToPATH bool
+	versions     []string
+	path         string
+	symlink      bool
+	addToPATH    bool
+	packagesFile string
+	packages     []string
+	dryRun       bool
+	url          string
+	repo         string
+	requirements string
+	constraints  string
+	installer    string
+	channels     []string
+	environments []string
+	file         string
+	fromSource   bool
+	rSource      string
+	check        bool
 }
 
 func newInstall(installOpts installOpts, program string) error {
+	// building R from source supports distributions Posit does not build R for, so it does not need a supported OS
+	if program == "r" && installOpts.fromSource {
+		family, err := operatingsystem.DetectDistroFamily()
+		if err != nil {
+			return fmt.Errorf("issue detecting the Linux distribution: %w", err)
+		}
+		for _, rVersion := range installOpts.versions {
+			err = languages.BuildRFromSource(rVersion, installOpts.rSource, family, installOpts.check)
+			if err != nil {
+				return fmt.Errorf("issue building R %s from source: %w", rVersion, err)
+			}
+		}
+		if installOpts.symlink {
+			fullRPath, err := languages.RPathForVersion(installOpts.versions[0])
+			if err != nil {
+				return fmt.Errorf("issue finding R %s: %w", installOpts.versions[0], err)
+			}
+			err = languages.CheckAndSetRSymlinks(fullRPath)
+			if err != nil {
+				return fmt.Errorf("issue setting R symlinks: %w", err)
+			}
+		}
+		return nil
+	}
+
 	// Determine OS
 	osType, err := operatingsystem.DetectOS()
 	if err != nil {
@@ -43,7 +88,13 @@ func newInstall(installOpts installOpts, program string) error {
 			return fmt.Errorf("issue installing pre-requisites: %w", err)
 		}
 		// install R
-		if len(installOpts.versions) == 0 {
+		if installOpts.file != "" {
+			rVersion, err := languages.InstallRFromFile(installOpts.file, osType)
+			if err != nil {
+				return fmt.Errorf("issue installing R from %s: %w", installOpts.file, err)
+			}
+			installOpts.versions = []string{rVersion}
+		} else if len(installOpts.versions) == 0 {
 			err = languages.ScanAndHandleRVersions(osType)
 			if err != nil {
 				return fmt.Errorf("ScanAndHandleRVersions: %w", err)
@@ -55,14 +106,71 @@ func newInstall(installOpts installOpts, program string) error {
 					return fmt.Errorf("issue installing R versions: %w", err)
 				}
 			}
-			if installOpts.symlink {
-				fullRPath := "/opt/R/" + installOpts.versions[0] + "/bin/R"
-				err = languages.CheckAndSetRSymlinks(fullRPath)
-				if err != nil {
-					return fmt.Errorf("issue setting R symlinks: %w", err)
-				}
+		}
+		if installOpts.symlink && len(installOpts.versions) != 0 {
+			fullRPath, err := languages.RPathForVersion(installOpts.versions[0])
+			if err != nil {
+				return fmt.Errorf("issue finding R %s: %w", installOpts.versions[0], err)
+			}
+			err = languages.CheckAndSetRSymlinks(fullRPath)
+			if err != nil {
+				return fmt.Errorf("issue setting R symlinks: %w", err)
 			}
 		}
+	} else if program == "r-packages" {
+		// install R packages into each selected R version
+		packages, err := languages.ReadRPackagesFile(installOpts.packagesFile)
+		if err != nil {
+			return fmt.Errorf("issue reading the R packages file: %w", err)
+		}
+		rHomes, err := languages.RPackageTargets(installOpts.versions)
+		if err != nil {
+			return fmt.Errorf("issue selecting R versions: %w", err)
+		}
+		_, err = languages.InstallRPackages(rHomes, packages)
+		if err != nil {
+			return fmt.Errorf("issue installing R packages: %w", err)
+		}
+	} else if program == "sysreqs" {
+		// install the system requirements of R packages
+		packages := installOpts.packages
+		if installOpts.packagesFile != "" {
+			filePackages, err := languages.ReadRPackagesFile(installOpts.packagesFile)
+			if err != nil {
+				return fmt.Errorf("issue reading the R packages file: %w", err)
+			}
+			for _, name := range filePackages {
+				packages = languages.AppendIfMissing(packages, name)
+			}
+		}
+		serverURL, repo := packagemanager.ConfiguredRepo()
+		if installOpts.url != "" {
+			serverURL = installOpts.url
+			repo = "cran"
+		}
+		if installOpts.repo != "" {
+			repo = installOpts.repo
+		}
+		err = packagemanager.InstallSystemRequirements(serverURL, repo, packages, osType, installOpts.dryRun)
+		if err != nil {
+			return fmt.Errorf("issue installing system requirements: %w", err)
+		}
+	} else if program == "python-packages" {
+		// install a requirements file into each selected Python version
+		pythonVersions, err := languages.PythonPackageTargets(installOpts.versions)
+		if err != nil {
+			return fmt.Errorf("issue selecting Python versions: %w", err)
+		}
+		_, err = languages.InstallPythonPackages(pythonVersions, installOpts.requirements, installOpts.constraints)
+		if err != nil {
+			return fmt.Errorf("issue installing Python packages: %w", err)
+		}
+	} else if program == "conda" {
+		// install Miniforge and create the shared environments
+		err = conda.InstallAndConfigConda(installOpts.installer, installOpts.channels, installOpts.environments)
+		if err != nil {
+			return fmt.Errorf("issue installing or configuring conda: %w", err)
+		}
 	} else if program == "python" {
 		// install prereqs
 		err = operatingsystem.InstallPrereqs(osType)
@@ -70,7 +178,13 @@ func newInstall(installOpts installOpts, program string) error {
 			return fmt.Errorf("issue installing pre-requisites: %w", err)
 		}
 		// install Python
-		if len(installOpts.versions) == 0 {
+		if installOpts.file != "" {
+			pythonVersion, err := languages.InstallPythonFromFile(installOpts.file, osType)
+			if err != nil {
+				return fmt.Errorf("issue installing Python from %s: %w", installOpts.file, err)
+			}
+			installOpts.versions = []string{pythonVersion}
+		} else if len(installOpts.versions) == 0 {
 			err = languages.ScanAndHandlePythonVersions(osType)
 			if err != nil {
 				return fmt.Errorf("ScanAndHandlePythonVersions: %w", err)
@@ -82,18 +196,24 @@ func newInstall(installOpts installOpts, program string) error {
 					return fmt.Errorf("issue installing Python versions: %w", err)
 				}
 			}
-			if installOpts.addToPATH {
-				// TODO add to PATH the latest version of Python (this just chooses the first version listed)
-				fullPythonPath := "/opt/python/" + installOpts.versions[0] + "/bin"
-				err = system.AddToPATH(fullPythonPath, "python")
-				if err != nil {
-					return fmt.Errorf("issue adding Python binary to PATH: %w", err)
-				}
+		}
+		if installOpts.addToPATH && len(installOpts.versions) != 0 {
+			// TODO add to PATH the latest version of Python (this just chooses the first version listed)
+			fullPythonPath := filepath.Join(config.DefaultInstallDir("python", installOpts.versions[0]), "bin")
+			err = system.AddToPATH(fullPythonPath, "python")
+			if err != nil {
+				return fmt.Errorf("issue adding Python binary to PATH: %w", err)
 			}
 		}
 	} else if program == "quarto" {
 		// install Quarto
-		if len(installOpts.versions) == 0 {
+		if installOpts.file != "" {
+			quartoVersion, err := quarto.InstallQuartoFromFile(installOpts.file, osType)
+			if err != nil {
+				return fmt.Errorf("issue installing Quarto from %s: %w", installOpts.file, err)
+			}
+			installOpts.versions = []string{quartoVersion}
+		} else if len(installOpts.versions) == 0 {
 			err = quarto.ScanAndHandleQuartoVersions(osType)
 			if err != nil {
 				return fmt.Errorf("ScanAndHandleQuartoVersions: %w", err)
@@ -103,11 +223,37 @@ func newInstall(installOpts installOpts, program string) error {
 			if err != nil {
 				return fmt.Errorf("issue installing Quarto versions: %w", err)
 			}
+		}
+		if installOpts.symlink && len(installOpts.versions) != 0 {
+			fullQuartoPath := quarto.QuartoBinary(installOpts.versions[0])
+			err = quarto.CheckAndSetQuartoSymlink(fullQuartoPath)
+			if err != nil {
+				return fmt.Errorf("issue setting Quarto symlink: %w", err)
+			}
+		}
+	} else if program == "julia" {
+		// install Julia
+		if len(installOpts.versions) == 0 {
+			err = languages.ScanAndHandleJuliaVersions(osType)
+			if err != nil {
+				return fmt.Errorf("ScanAndHandleJuliaVersions: %w", err)
+			}
+		} else {
+			for _, juliaVersion := range installOpts.versions {
+				err = languages.DownloadAndInstallJulia(juliaVersion)
+				if err != nil {
+					return fmt.Errorf("issue installing Julia versions: %w", err)
+				}
+				err = languages.RegisterIJuliaKernel(languages.JuliaBinary(juliaVersion))
+				if err != nil {
+					return fmt.Errorf("issue registering a Jupyter kernel for Julia: %w", err)
+				}
+			}
 			if installOpts.symlink {
-				fullQuartoPath := "/opt/quarto/" + installOpts.versions[0] + "/bin/quarto"
-				err = quarto.CheckAndSetQuartoSymlink(fullQuartoPath)
+				fullJuliaPath := languages.JuliaBinary(installOpts.versions[0])
+				err = languages.CheckAndSetJuliaSymlink(fullJuliaPath)
 				if err != nil {
-					return fmt.Errorf("issue setting Quarto symlink: %w", err)
+					return fmt.Errorf("issue setting Julia symlink: %w", err)
 				}
 			}
 		}
@@ -118,7 +264,11 @@ func newInstall(installOpts installOpts, program string) error {
 			return fmt.Errorf("issue installing pre-requisites: %w", err)
 		}
 		// install Workbench
-		err := workbench.CheckDownloadAndInstallWorkbench(osType)
+		if installOpts.file != "" {
+			err = workbench.InstallWorkbenchFromFile(installOpts.file, osType)
+		} else {
+			err = workbench.CheckDownloadAndInstallWorkbench(osType)
+		}
 		if err != nil {
 			return fmt.Errorf("issue installing Workbench: %w", err)
 		}
@@ -154,6 +304,20 @@ func setInstallOpts(installOpts *installOpts) {
 	installOpts.path = viper.GetString("path")
 	installOpts.symlink = viper.GetBool("symlink")
 	installOpts.addToPATH = viper.GetBool("add-to-path")
+	installOpts.packagesFile = viper.GetString("packages-file")
+	installOpts.packages = viper.GetStringSlice("packages")
+	installOpts.dryRun = viper.GetBool("dry-run")
+	installOpts.url = viper.GetString("sysreqs-url")
+	installOpts.repo = viper.GetString("sysreqs-repo")
+	installOpts.requirements = viper.GetString("requirements")
+	installOpts.constraints = viper.GetString("constraints")
+	installOpts.installer = viper.GetString("conda-installer")
+	installOpts.channels = viper.GetStringSlice("conda-channels")
+	installOpts.environments = viper.GetStringSlice("conda-environments")
+	installOpts.file = viper.GetString("install-file")
+	installOpts.fromSource = viper.GetBool("r-from-source")
+	installOpts.rSource = viper.GetString("r-source")
+	installOpts.check = viper.GetBool("r-check")
 }
 
 func (opts *installOpts) Validate(args []string) error {
@@ -169,9 +333,9 @@ func (opts *installOpts) Validate(args []string) error {
 		return fmt.Errorf("the path flag is only supported for jupyter")
 	}
 
-	// only the flag for symlink is supported for r and quarto
-	if opts.symlink && (args[0] != "r" && args[0] != "quarto") {
-		return fmt.Errorf("the symlink flag is only supported for r and quarto")
+	// only the flag for symlink is supported for r, quarto and julia
+	if opts.symlink && (args[0] != "r" && args[0] != "quarto" && args[0] != "julia") {
+		return fmt.Errorf("the symlink flag is only supported for r, quarto and julia")
 	}
 
 	// only the flag for add-to-path (addToPATH) is supported for python
@@ -179,26 +343,145 @@ func (opts *installOpts) Validate(args []string) error {
 		return fmt.Errorf("the add-to-path flag is only supported for python")
 	}
 
-	// ensure versions are valid if provided for r, python or quarto
-	if args[0] == "r" && len(opts.versions) != 0 {
-		err := languages.ValidateRVersions(opts.versions)
+	// the packages-file flag is required for r-packages and not supported for anything else
+	if args[0] == "r-packages" {
+		if opts.packagesFile == "" {
+			return fmt.Errorf("the packages-file flag is required for r-packages")
+		}
+		if !system.VerifyFileExists(opts.packagesFile) {
+			return fmt.Errorf("the packages file provided does not exist")
+		}
+		err := opts.validateInstalledVersions("R", "4.3.2")
+		if err != nil {
+			return err
+		}
+	} else if args[0] == "sysreqs" {
+		if len(opts.packages) == 0 && opts.packagesFile == "" {
+			return fmt.Errorf("the packages or packages-file flag is required for sysreqs")
+		}
+		if opts.packagesFile != "" && !system.VerifyFileExists(opts.packagesFile) {
+			return fmt.Errorf("the packages file provided does not exist")
+		}
+		if err := languages.ValidateRPackageNames(opts.packages); err != nil {
+			return fmt.Errorf("invalid packages provided: %w", err)
+		}
+		if len(opts.versions) != 0 {
+			return fmt.Errorf("sysreqs does not support specifying versions")
+		}
+	} else if opts.packagesFile != "" {
+		return fmt.Errorf("the packages-file flag is only supported for r-packages and sysreqs")
+	}
+
+	// the packages, dry-run, url and repo flags are only supported for sysreqs
+	if args[0] != "sysreqs" && (len(opts.packages) != 0 || opts.dryRun || opts.url != "" || opts.repo != "") {
+		return fmt.Errorf("the packages, dry-run, url and repo flags are only supported for sysreqs")
+	}
+
+	// the requirements flag is required for python-packages, and it and the constraints flag are not supported for anything else
+	if args[0] == "python-packages" {
+		if opts.requirements == "" {
+			return fmt.Errorf("the requirements flag is required for python-packages")
+		}
+		if !system.VerifyFileExists(opts.requirements) {
+			return fmt.Errorf("the requirements file provided does not exist")
+		}
+		if opts.constraints != "" && !system.VerifyFileExists(opts.constraints) {
+			return fmt.Errorf("the constraints file provided does not exist")
+		}
+		err := opts.validateInstalledVersions("Python", "3.11.6")
 		if err != nil {
-			return fmt.Errorf("invalid R versions: %w", err)
+			return err
+		}
+	} else if opts.requirements != "" || opts.constraints != "" {
+		return fmt.Errorf("the requirements and constraints flags are only supported for python-packages")
+	}
+
+	// the installer, channel and environment flags are only supported for conda
+	if args[0] == "conda" {
+		isURL := strings.HasPrefix(opts.installer, "http://") || strings.HasPrefix(opts.installer, "https://")
+		if opts.installer != "" && !isURL && !system.VerifyFileExists(opts.installer) {
+			return fmt.Errorf("the installer provided does not exist")
+		}
+		for _, environmentFile := range opts.environments {
+			if !system.VerifyFileExists(environmentFile) {
+				return fmt.Errorf("the environment file %s does not exist", environmentFile)
+			}
+		}
+		if len(opts.versions) != 0 {
+			return fmt.Errorf("conda does not support specifying versions")
+		}
+	} else if opts.installer != "" || len(opts.channels) != 0 || len(opts.environments) != 0 {
+		return fmt.Errorf("the installer, channel and environment flags are only supported for conda")
+	}
+
+	// the from-source flag builds exact R versions from a source tarball, and the source and check flags are only supported with it
+	if opts.fromSource {
+		if args[0] != "r" {
+			return fmt.Errorf("the from-source flag is only supported for r")
+		}
+		if opts.file != "" {
+			return fmt.Errorf("the file and from-source flags cannot be used together")
+		}
+		if len(opts.versions) == 0 {
+			return fmt.Errorf("the version flag is required with the from-source flag")
+		}
+		for _, rVersion := range opts.versions {
+			if !exactVersion.MatchString(rVersion) {
+				return fmt.Errorf("invalid R version %s provided, building from source requires exact versions such as 4.3.2", rVersion)
+			}
+		}
+		isURL := strings.HasPrefix(opts.rSource, "http://") || strings.HasPrefix(opts.rSource, "https://")
+		if opts.rSource != "" && !isURL && !system.VerifyFileExists(opts.rSource) {
+			return fmt.Errorf("the source provided does not exist")
+		}
+		if opts.rSource != "" && !isURL && len(opts.versions) > 1 {
+			return fmt.Errorf("a local source tarball can only be used to build one R version")
+		}
+	} else if opts.rSource != "" || opts.check {
+		return fmt.Errorf("the source and check flags are only supported with the from-source flag")
+	}
+
+	// the file flag installs a single local package or tarball instead of downloading versions
+	if opts.file != "" {
+		if args[0] != "r" && args[0] != "python" && args[0] != "quarto" && args[0] != "workbench" {
+			return fmt.Errorf("the file flag is only supported for r, python, quarto and workbench")
+		}
+		if len(opts.versions) != 0 {
+			return fmt.Errorf("the file and version flags cannot be used together")
+		}
+		if !system.VerifyFileExists(opts.file) {
+			return fmt.Errorf("the file provided does not exist")
 		}
-	} else if args[0] == "python" && len(opts.versions) != 0 {
+		lowerFile := strings.ToLower(opts.file)
+		if args[0] == "quarto" && !strings.HasSuffix(lowerFile, ".tar.gz") && !strings.HasSuffix(lowerFile, ".tgz") {
+			return fmt.Errorf("the file provided for quarto must be a .tar.gz tarball")
+		} else if args[0] != "quarto" && !strings.HasSuffix(lowerFile, ".deb") && !strings.HasSuffix(lowerFile, ".rpm") {
+			return fmt.Errorf("the file provided for %s must be a .deb or .rpm package", args[0])
+		}
+	}
+
+	// resolve versions such as 4.3, latest, latest-3 or >=4.1,<4.3 to exact versions if provided for r, python, quarto or julia
+	if (args[0] == "r" || args[0] == "python" || args[0] == "quarto" || args[0] == "julia") && len(opts.versions) != 0 && !opts.fromSource {
 		osType, err := operatingsystem.DetectOS()
 		if err != nil {
 			return fmt.Errorf("issue detecting OS: %w", err)
 		}
-		err = languages.ValidatePythonVersions(opts.versions, osType)
-		if err != nil {
-			return fmt.Errorf("invalid Python versions: %w", err)
+
+		var resolvedVersions []string
+		switch args[0] {
+		case "r":
+			resolvedVersions, err = languages.ResolveRVersions(opts.versions, osType)
+		case "python":
+			resolvedVersions, err = languages.ResolvePythonVersions(opts.versions, osType)
+		case "quarto":
+			resolvedVersions, err = quarto.ResolveQuartoVersions(opts.versions, osType)
+		case "julia":
+			resolvedVersions, err = languages.ResolveJuliaVersions(opts.versions, osType)
 		}
-	} else if args[0] == "quarto" && len(opts.versions) != 0 {
-		err := quarto.ValidateQuartoVersions(opts.versions)
 		if err != nil {
-			return fmt.Errorf("invalid Quarto versions: %w", err)
+			return fmt.Errorf("invalid %s versions: %w", programDisplayName(args[0]), err)
 		}
+		opts.versions = resolvedVersions
 	}
 
 	// ensure versions are not provided for workbench, prodrivers or jupyter
@@ -219,13 +502,30 @@ func (opts *installOpts) Validate(args []string) error {
 	}
 
 	// ensure program is valid
-	if args[0] != "r" && args[0] != "python" && args[0] != "workbench" && args[0] != "prodrivers" && args[0] != "jupyter" && args[0] != "quarto" {
+	if args[0] != "r" && args[0] != "r-packages" && args[0] != "sysreqs" && args[0] != "python" && args[0] != "python-packages" && args[0] != "conda" && args[0] != "workbench" && args[0] != "prodrivers" && args[0] != "jupyter" && args[0] != "quarto" && args[0] != "julia" {
 		return fmt.Errorf("invalid argument provided")
 	}
 
 	return nil
 }
 
+// validateInstalledVersions defaults the versions to all and ensures each one is all or an exact version, since packages can
+// only be installed into versions that are already installed
+func (opts *installOpts) validateInstalledVersions(language string, exampleVersion string) error {
+	if len(opts.versions) == 0 {
+		opts.versions = []string{"all"}
+	}
+	for _, installedVersion := range opts.versions {
+		if _, err := version.NewVersion(installedVersion); err != nil && installedVersion != "all" {
+			return fmt.Errorf("invalid %s version %s provided, please provide all or installed versions such as %s", language, installedVersion, exampleVersion)
+		}
+	}
+	return nil
+}
+
+// exactVersion matches a full major.minor.patch version such as 4.3.2
+var exactVersion = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
 func newInstallCmd() *installCmd {
 	var installOpts installOpts
 
@@ -243,14 +543,53 @@ func newInstallCmd() *installCmd {
 		"  wbi install python --version 3.11.2",
 		"  wbi install quarto --version 1.3.340",
 		"",
+		"To install the latest release, the latest release in a minor line, the latest release of the last three minor lines or a range of minor lines:",
+		"  wbi install r --version latest",
+		"  wbi install r --version 4.3",
+		"  wbi install python --version latest-3",
+		"  wbi install quarto --version \">=1.2,<1.4\"",
+		"",
 		"To install multiple R, Python or Quarto versions:",
 		"  wbi install r --version 4.2.2,4.1.3",
 		"  wbi install python --version 3.11.2,3.10.10",
 		"  wbi install quarto --version 1.3.340,1.2.475",
 		"",
+		"To install the R packages listed in a file, one per line, or in an renv.lock into every R version or specific R versions:",
+		"  wbi install r-packages --version all --packages-file baseline.txt",
+		"  wbi install r-packages --version 4.3.2,4.2.3 --packages-file renv.lock",
+		"",
+		"To install a requirements file, optionally with a constraints file, into every Python version or specific Python versions through the index in /etc/pip.conf:",
+		"  wbi install python-packages --version all -r requirements.txt",
+		"  wbi install python-packages --version 3.11.6 -r requirements.txt -c constraints.txt",
+		"",
+		"To install Miniforge into the conda directory of the install prefix (/opt/conda by default), use a channel mirror and create shared environments that are registered as Jupyter kernels:",
+		"  wbi install conda",
+		"  wbi install conda --installer /tmp/Miniforge3-Linux-x86_64.sh --channel https://conda.example.com/conda-forge",
+		"  wbi install conda --environment environment.yml,geo-environment.yml",
+		"",
+		"To list or install the OS libraries R packages need, using the Posit Package Manager repo in repos.conf or the public Package Manager:",
+		"  wbi install sysreqs --packages sf,xml2 --dry-run",
+		"  wbi install sysreqs --packages-file DESCRIPTION",
+		"  wbi install sysreqs --packages-file renv.lock --url https://packagemanager.example.com --repo cran",
+		"",
+		"To install Julia versions into the julia directory of the install prefix (/opt/julia by default), register each one as a Jupyter kernel with IJulia and symlink the first one:",
+		"  wbi install julia",
+		"  wbi install julia --version 1.10.2,1.9.4 --symlink",
+		"",
 		"To install Workbench:",
 		"  wbi install workbench",
 		"",
+		"To install R, Python, Quarto or Workbench from a local package or tarball instead of downloading it, reading the version from the file:",
+		"  wbi install r --file ./R-4.3.2-1-1.x86_64.rpm --symlink",
+		"  wbi install python --file ./python-3.11.6_1_amd64.deb --add-to-path",
+		"  wbi install quarto --file ./quarto-1.3.340-linux-amd64.tar.gz",
+		"  wbi install workbench --file ./rstudio-workbench-2023.09.1-494.pro2-x86_64.rpm",
+		"",
+		"To build R from source on distributions Posit does not build R for, from CRAN, a mirror or a local tarball, optionally running make check-devel:",
+		"  wbi install r --from-source --version 4.3.2",
+		"  wbi install r --from-source --version 4.3.2 --source https://cran.example.com --check",
+		"  wbi install r --from-source --version 4.3.2 --source ./R-4.3.2.tar.gz --symlink",
+		"",
 		"To install Pro Drivers:",
 		"  wbi install prodrivers",
 		"",
@@ -263,7 +602,7 @@ func newInstallCmd() *installCmd {
 
 	cmd := &cobra.Command{
 		Use:     "install [program]",
-		Short:   "Install R, Python, Quarto, Workbench, Pro Drivers, or Jupyter",
+		Short:   "Install R, R packages, Python, Python packages, conda, Quarto, Julia, Workbench, Pro Drivers, or Jupyter",
 		Example: strings.Join(exampleText, "\n"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			setInstallOpts(&root.opts)
@@ -283,18 +622,60 @@ func newInstallCmd() *installCmd {
 		SilenceUsage: true,
 	}
 
-	cmd.Flags().StringSliceP("version", "v", []string{}, "Version(s) of R, Python or Quarto to install. Multiple values can be passed by seperating each version with a comma.")
+	cmd.Flags().StringSliceP("version", "v", []string{}, "Version(s) of R, Python, Quarto or Julia to install. Accepts exact versions, major.minor versions, latest, latest-N or ranges such as >=4.1,<4.3, with several ranges separated by a semicolon. Multiple values can be passed by seperating each version with a comma.")
 	viper.BindPFlag("version", cmd.Flags().Lookup("version"))
 
 	cmd.Flags().StringP("path", "p", "", "Python location to install Jupyter to.")
 	viper.BindPFlag("path", cmd.Flags().Lookup("path"))
 
-	cmd.Flags().BoolP("symlink", "s", false, "Sets symlinks for R and Quarto. For R both R/Rscript for the first version of R specified to /usr/local/bin/R, for Quarto the first version of Quarto specified to /usr/local/bin/quarto.")
+	cmd.Flags().BoolP("symlink", "s", false, "Sets symlinks for R, Quarto and Julia. For R both R/Rscript for the first version of R specified to /usr/local/bin/R, for Quarto the first version of Quarto specified to /usr/local/bin/quarto and for Julia the first version of Julia specified to /usr/local/bin/julia.")
 	viper.BindPFlag("symlink", cmd.Flags().Lookup("symlink"))
 
 	cmd.Flags().BoolP("add-to-path", "a", false, "Adds the first Python version specified to users PATH by adding a file in /etc/profile.d/.")
 	viper.BindPFlag("add-to-path", cmd.Flags().Lookup("add-to-path"))
 
+	cmd.Flags().String("packages-file", "", "File listing the R packages for r-packages or sysreqs, one per line, or an renv.lock or DESCRIPTION file.")
+	viper.BindPFlag("packages-file", cmd.Flags().Lookup("packages-file"))
+
+	cmd.Flags().StringSlice("packages", []string{}, "R packages to install the system requirements of with sysreqs. Multiple values can be passed by seperating each package with a comma.")
+	viper.BindPFlag("packages", cmd.Flags().Lookup("packages"))
+
+	cmd.Flags().Bool("dry-run", false, "Lists the system requirements sysreqs would install without installing them.")
+	viper.BindPFlag("dry-run", cmd.Flags().Lookup("dry-run"))
+
+	cmd.Flags().String("url", "", "Posit Package Manager URL to retrieve system requirements from with sysreqs. Defaults to the repo in /etc/rstudio/repos.conf or the public Package Manager.")
+	viper.BindPFlag("sysreqs-url", cmd.Flags().Lookup("url"))
+
+	cmd.Flags().String("repo", "", "Posit Package Manager repo to retrieve system requirements from with sysreqs.")
+	viper.BindPFlag("sysreqs-repo", cmd.Flags().Lookup("repo"))
+
+	cmd.Flags().StringP("requirements", "r", "", "Requirements file to install with python-packages.")
+	viper.BindPFlag("requirements", cmd.Flags().Lookup("requirements"))
+
+	cmd.Flags().StringP("constraints", "c", "", "Constraints file to apply when installing the requirements file with python-packages.")
+	viper.BindPFlag("constraints", cmd.Flags().Lookup("constraints"))
+
+	cmd.Flags().String("installer", "", "URL of a Miniforge installer on a mirror, or the path to a downloaded installer, to install conda from. Defaults to the latest Miniforge release.")
+	viper.BindPFlag("conda-installer", cmd.Flags().Lookup("installer"))
+
+	cmd.Flags().StringSlice("channel", []string{}, "Channels to write to the conda condarc, such as an internal mirror. Defaults to conda-forge. Multiple values can be passed by seperating each channel with a comma.")
+	viper.BindPFlag("conda-channels", cmd.Flags().Lookup("channel"))
+
+	cmd.Flags().StringSlice("environment", []string{}, "environment.yml files to create shared conda environments from. Multiple values can be passed by seperating each file with a comma.")
+	viper.BindPFlag("conda-environments", cmd.Flags().Lookup("environment"))
+
+	cmd.Flags().String("file", "", "Local .deb or .rpm package to install R, Python or Workbench from, or a local tarball to install Quarto from, instead of downloading it.")
+	viper.BindPFlag("install-file", cmd.Flags().Lookup("file"))
+
+	cmd.Flags().Bool("from-source", false, "Builds the R versions from source into the install prefix instead of installing Posit's builds, for distributions Posit does not build R for.")
+	viper.BindPFlag("r-from-source", cmd.Flags().Lookup("from-source"))
+
+	cmd.Flags().String("source", "", "CRAN mirror URL, URL of an R source tarball or path to a local R source tarball to build R from with from-source. Defaults to CRAN.")
+	viper.BindPFlag("r-source", cmd.Flags().Lookup("source"))
+
+	cmd.Flags().Bool("check", false, "Runs make check-devel before installing R when building from source.")
+	viper.BindPFlag("r-check", cmd.Flags().Lookup("check"))
+
 	root.cmd = cmd
 	return root
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/sol-eng/wbi/internal/config"
 	"github.com/sol-eng/wbi/internal/languages"
 	"github.com/sol-eng/wbi/internal/operatingsystem"
 	"github.com/sol-eng/wbi/internal/quarto"
@@ -18,7 +19,7 @@ func TestInstallParamsValidate(t *testing.T) {
 		t.Fatalf("issue detecting OS: %v", err)
 	}
 
-	validRVersions, err := languages.RetrieveValidRVersions()
+	validRVersions, err := languages.RetrieveValidRVersions(osType)
 	if err != nil {
 		t.Fatalf("failed to retrieve valid R versions: %v", err)
 	}
@@ -26,7 +27,7 @@ func TestInstallParamsValidate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to retrieve valid Python versions: %v", err)
 	}
-	validQuartoVersions, err := quarto.RetrieveValidQuartoVersions()
+	validQuartoVersions, err := quarto.RetrieveValidQuartoVersions(osType)
 	if err != nil {
 		t.Fatalf("failed to retrieve valid Quarto versions: %v", err)
 	}
@@ -293,6 +294,208 @@ func TestInstallParamsValidate(t *testing.T) {
 			flags:       installOpts{addToPATH: true},
 			expectError: "the add-to-path flag is only supported for python",
 		},
+		// r-packages argument tests
+		"r-packages argument with a packages-file flag succeeds": {
+			args:        []string{"r-packages"},
+			flags:       installOpts{packagesFile: "testdata/packages.txt"},
+			expectError: "",
+		},
+		"r-packages argument with all and a packages-file flag succeeds": {
+			args:        []string{"r-packages"},
+			flags:       installOpts{versions: []string{"all"}, packagesFile: "testdata/packages.txt"},
+			expectError: "",
+		},
+		"r-packages argument without a packages-file flag fails": {
+			args:        []string{"r-packages"},
+			flags:       installOpts{},
+			expectError: "the packages-file flag is required for r-packages",
+		},
+		"r-packages argument with a packages-file that does not exist fails": {
+			args:        []string{"r-packages"},
+			flags:       installOpts{packagesFile: "does-not-exist.txt"},
+			expectError: "the packages file provided does not exist",
+		},
+		"r-packages argument with an invalid version fails": {
+			args:        []string{"r-packages"},
+			flags:       installOpts{versions: []string{"latest"}, packagesFile: "testdata/packages.txt"},
+			expectError: "invalid R version latest provided",
+		},
+		"r argument with a packages-file flag fails": {
+			args:        []string{"r"},
+			flags:       installOpts{packagesFile: "testdata/packages.txt"},
+			expectError: "the packages-file flag is only supported for r-packages",
+		},
+		// sysreqs argument tests
+		"sysreqs argument with a packages flag succeeds": {
+			args:        []string{"sysreqs"},
+			flags:       installOpts{packages: []string{"sf", "xml2"}, dryRun: true},
+			expectError: "",
+		},
+		"sysreqs argument with a packages-file flag succeeds": {
+			args:        []string{"sysreqs"},
+			flags:       installOpts{packagesFile: "testdata/packages.txt", url: "https://packagemanager.example.com", repo: "cran"},
+			expectError: "",
+		},
+		"sysreqs argument without a packages or packages-file flag fails": {
+			args:        []string{"sysreqs"},
+			flags:       installOpts{},
+			expectError: "the packages or packages-file flag is required for sysreqs",
+		},
+		"sysreqs argument with an invalid package fails": {
+			args:        []string{"sysreqs"},
+			flags:       installOpts{packages: []string{"not_a_package"}},
+			expectError: "not_a_package is not a valid R package name",
+		},
+		"sysreqs argument with a version flag fails": {
+			args:        []string{"sysreqs"},
+			flags:       installOpts{packages: []string{"sf"}, versions: []string{"4.3.2"}},
+			expectError: "sysreqs does not support specifying versions",
+		},
+		"r argument with a dry-run flag fails": {
+			args:        []string{"r"},
+			flags:       installOpts{dryRun: true},
+			expectError: "the packages, dry-run, url and repo flags are only supported for sysreqs",
+		},
+		// python-packages argument tests
+		"python-packages argument with a requirements flag succeeds": {
+			args:        []string{"python-packages"},
+			flags:       installOpts{requirements: "testdata/requirements.txt"},
+			expectError: "",
+		},
+		"python-packages argument with a version, requirements and constraints flag succeeds": {
+			args:        []string{"python-packages"},
+			flags:       installOpts{versions: []string{"3.11.6"}, requirements: "testdata/requirements.txt", constraints: "testdata/constraints.txt"},
+			expectError: "",
+		},
+		"python-packages argument without a requirements flag fails": {
+			args:        []string{"python-packages"},
+			flags:       installOpts{},
+			expectError: "the requirements flag is required for python-packages",
+		},
+		"python-packages argument with a constraints file that does not exist fails": {
+			args:        []string{"python-packages"},
+			flags:       installOpts{requirements: "testdata/requirements.txt", constraints: "does-not-exist.txt"},
+			expectError: "the constraints file provided does not exist",
+		},
+		"python-packages argument with an invalid version fails": {
+			args:        []string{"python-packages"},
+			flags:       installOpts{versions: []string{"latest"}, requirements: "testdata/requirements.txt"},
+			expectError: "invalid Python version latest provided",
+		},
+		"python argument with a requirements flag fails": {
+			args:        []string{"python"},
+			flags:       installOpts{requirements: "testdata/requirements.txt"},
+			expectError: "the requirements and constraints flags are only supported for python-packages",
+		},
+		// julia argument tests
+		"julia argument only succeeds": {
+			args:        []string{"julia"},
+			flags:       installOpts{},
+			expectError: "",
+		},
+		"julia argument with a symlink flag succeeds": {
+			args:        []string{"julia"},
+			flags:       installOpts{symlink: true},
+			expectError: "",
+		},
+		"julia argument with a add-to-path flag fails": {
+			args:        []string{"julia"},
+			flags:       installOpts{addToPATH: true},
+			expectError: "the add-to-path flag is only supported for python",
+		},
+		// conda argument tests
+		"conda argument only succeeds": {
+			args:        []string{"conda"},
+			flags:       installOpts{},
+			expectError: "",
+		},
+		"conda argument with an installer URL, channel and environment flag succeeds": {
+			args:        []string{"conda"},
+			flags:       installOpts{installer: "https://conda.example.com/Miniforge3-Linux-x86_64.sh", channels: []string{"https://conda.example.com/conda-forge"}, environments: []string{"install_test.go"}},
+			expectError: "",
+		},
+		"conda argument with an installer that does not exist fails": {
+			args:        []string{"conda"},
+			flags:       installOpts{installer: "Miniforge3-does-not-exist.sh"},
+			expectError: "the installer provided does not exist",
+		},
+		"conda argument with an environment file that does not exist fails": {
+			args:        []string{"conda"},
+			flags:       installOpts{environments: []string{"environment-does-not-exist.yml"}},
+			expectError: "the environment file environment-does-not-exist.yml does not exist",
+		},
+		"conda argument with a version flag fails": {
+			args:        []string{"conda"},
+			flags:       installOpts{versions: []string{"23.11.0"}},
+			expectError: "conda does not support specifying versions",
+		},
+		"python argument with a channel flag fails": {
+			args:        []string{"python"},
+			flags:       installOpts{channels: []string{"conda-forge"}},
+			expectError: "the installer, channel and environment flags are only supported for conda",
+		},
+		// file flag tests
+		"jupyter argument with a file flag fails": {
+			args:        []string{"jupyter"},
+			flags:       installOpts{file: "install_test.go"},
+			expectError: "the file flag is only supported for r, python, quarto and workbench",
+		},
+		"r argument with a file and version flag fails": {
+			args:        []string{"r"},
+			flags:       installOpts{file: "install_test.go", versions: []string{"4.3.2"}},
+			expectError: "the file and version flags cannot be used together",
+		},
+		"workbench argument with a file that does not exist fails": {
+			args:        []string{"workbench"},
+			flags:       installOpts{file: "rstudio-workbench-does-not-exist.rpm"},
+			expectError: "the file provided does not exist",
+		},
+		"python argument with a file that is not a package fails": {
+			args:        []string{"python"},
+			flags:       installOpts{file: "install_test.go"},
+			expectError: "the file provided for python must be a .deb or .rpm package",
+		},
+		"quarto argument with a file that is not a tarball fails": {
+			args:        []string{"quarto"},
+			flags:       installOpts{file: "install_test.go"},
+			expectError: "the file provided for quarto must be a .tar.gz tarball",
+		},
+		// from-source flag tests
+		"r argument with a from-source and version flag succeeds": {
+			args:        []string{"r"},
+			flags:       installOpts{fromSource: true, versions: []string{"4.3.2"}, check: true, rSource: "https://cran.example.com"},
+			expectError: "",
+		},
+		"python argument with a from-source flag fails": {
+			args:        []string{"python"},
+			flags:       installOpts{fromSource: true, versions: []string{"3.11.6"}},
+			expectError: "the from-source flag is only supported for r",
+		},
+		"r argument with a from-source flag and no version flag fails": {
+			args:        []string{"r"},
+			flags:       installOpts{fromSource: true},
+			expectError: "the version flag is required with the from-source flag",
+		},
+		"r argument with a from-source flag and a partial version fails": {
+			args:        []string{"r"},
+			flags:       installOpts{fromSource: true, versions: []string{"4.3"}},
+			expectError: "building from source requires exact versions such as 4.3.2",
+		},
+		"r argument with a from-source and file flag fails": {
+			args:        []string{"r"},
+			flags:       installOpts{fromSource: true, file: "install_test.go"},
+			expectError: "the file and from-source flags cannot be used together",
+		},
+		"r argument with a from-source flag and a source that does not exist fails": {
+			args:        []string{"r"},
+			flags:       installOpts{fromSource: true, versions: []string{"4.3.2"}, rSource: "R-does-not-exist.tar.gz"},
+			expectError: "the source provided does not exist",
+		},
+		"r argument with a check flag and no from-source flag fails": {
+			args:        []string{"r"},
+			flags:       installOpts{check: true},
+			expectError: "the source and check flags are only supported with the from-source flag",
+		},
 	}
 
 	for name, tc := range tests {
@@ -319,6 +522,69 @@ func TestInstallParamsValidate(t *testing.T) {
 
 }
 
+// TestInstallParamsValidateInstallPrefix tests the install command parameters with a custom install prefix
+func TestInstallParamsValidateInstallPrefix(t *testing.T) {
+	err := config.SetInstallPrefix("/apps/posit")
+	if err != nil {
+		t.Fatalf("issue setting the install prefix: %v", err)
+	}
+	defer config.SetInstallPrefix("")
+
+	tests := map[string]struct {
+		args        []string
+		flags       installOpts
+		expectError string
+	}{
+		"r argument succeeds and installs into /opt": {
+			args:        []string{"r"},
+			flags:       installOpts{},
+			expectError: "",
+		},
+		"python argument succeeds and installs into /opt": {
+			args:        []string{"python"},
+			flags:       installOpts{},
+			expectError: "",
+		},
+		"r argument with a from-source flag succeeds": {
+			args:        []string{"r"},
+			flags:       installOpts{fromSource: true, versions: []string{"4.3.2"}},
+			expectError: "",
+		},
+		"quarto argument succeeds": {
+			args:        []string{"quarto"},
+			flags:       installOpts{},
+			expectError: "",
+		},
+		"julia argument succeeds": {
+			args:        []string{"julia"},
+			flags:       installOpts{},
+			expectError: "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			installCmd := newInstallCmd()
+			// set the flags
+			installCmd.opts = tc.flags
+			// run validation
+			err := installCmd.opts.Validate(tc.args)
+
+			if err != nil && tc.expectError != "" {
+				// if we expect an error, check that it contains the expected error
+				assert.Containsf(t, err.Error(), tc.expectError, "expected error containing %q, got %s", tc.expectError, err)
+			} else if err != nil && tc.expectError == "" {
+				// if we expect no error but get one then fail
+				t.Fatalf("expected no error, but got %s", err)
+			} else if err == nil && tc.expectError != "" {
+				// if we expect an error but don't get one then fail
+				t.Fatalf("expected error containing %q, but the command ran without error", tc.expectError)
+			}
+			// otherwise we expect the command to succeed so pass the test
+		})
+	}
+}
+
 // TestInstallRCommandIntegration tests the install command with the r arg in a Docker container.
 func TestInstallRCommandIntegration(t *testing.T) {
 	if testing.Short() {
@@ -326,7 +592,13 @@ func TestInstallRCommandIntegration(t *testing.T) {
 	}
 	t.Parallel()
 
-	validRVersions, err := languages.RetrieveValidRVersions()
+	// Determine OS
+	osType, err := operatingsystem.DetectOS()
+	if err != nil {
+		t.Fatalf("issue detecting OS: %v", err)
+	}
+
+	validRVersions, err := languages.RetrieveValidRVersions(osType)
 	if err != nil {
 		t.Fatalf("failed to retrieve valid R versions: %v", err)
 	}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/operatingsystem"
+	"github.com/sol-eng/wbi/internal/quarto"
+	"github.com/sol-eng/wbi/internal/system"
+	"github.com/spf13/cobra"
+)
+
+type outdatedCmd struct {
+	cmd  *cobra.Command
+	opts outdatedOpts
+}
+
+type outdatedOpts struct {
+}
+
+// retrieveOutdatedVersions compares the installed versions of a language against the versions available for it. R and
+// Python are found in /opt where Posit's packages install, and Quarto in the install prefix
+func retrieveOutdatedVersions(language string, osType config.OperatingSystem) ([]languages.OutdatedVersion, error) {
+	var rootDir string
+	var available []string
+	var err error
+	switch language {
+	case "r":
+		rootDir = config.DefaultInstallRoot("R")
+		available, err = languages.RetrieveValidRVersions(osType)
+	case "python":
+		rootDir = config.DefaultInstallRoot("python")
+		available, err = languages.RetrieveValidPythonVersions(osType)
+	case "quarto":
+		rootDir = config.InstallRoot("quarto")
+		available, err = quarto.RetrieveValidQuartoVersions(osType)
+	default:
+		return nil, fmt.Errorf("language %s is not supported", language)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("issue retrieving valid %s versions: %w", programDisplayName(language), err)
+	}
+
+	installed, err := languages.ScanOptVersions(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("issue scanning for installed %s versions: %w", programDisplayName(language), err)
+	}
+	outdated, err := languages.FindNewerPatchVersions(installed, available)
+	if err != nil {
+		return nil, fmt.Errorf("issue comparing %s versions: %w", programDisplayName(language), err)
+	}
+	return outdated, nil
+}
+
+func newOutdated(outdatedOpts outdatedOpts, args []string) error {
+	// Determine OS
+	osType, err := operatingsystem.DetectOS()
+	if err != nil {
+		return err
+	}
+
+	targets := []string{"r", "python", "quarto"}
+	if len(args) == 1 {
+		targets = args
+	}
+
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "LANGUAGE\tINSTALLED\tLATEST PATCH")
+	var anyOutdated bool
+	for _, language := range targets {
+		outdated, err := retrieveOutdatedVersions(language, osType)
+		if err != nil {
+			return err
+		}
+		for _, o := range outdated {
+			anyOutdated = true
+			fmt.Fprintln(writer, programDisplayName(language)+"\t"+o.Installed+"\t"+o.Latest)
+		}
+	}
+	writer.Flush()
+
+	if !anyOutdated {
+		system.PrintAndLogInfo("\nAll installed versions in /opt are on the latest patch release.")
+		return nil
+	}
+	system.PrintAndLogInfo("\n" + strings.TrimSuffix(builder.String(), "\n"))
+	system.PrintAndLogInfo("\nTo install the newer patch versions side by side use \"wbi upgrade [language] --patch\"")
+	return nil
+}
+
+func (opts *outdatedOpts) Validate(args []string) error {
+	// check args lengths
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments provided, please provide at most one argument")
+	}
+
+	// ensure only r, python or quarto is provided
+	if len(args) == 1 && args[0] != "r" && args[0] != "python" && args[0] != "quarto" {
+		return fmt.Errorf("invalid language provided, please provide one of the following: r, python, quarto")
+	}
+	return nil
+}
+
+func newOutdatedCmd() *outdatedCmd {
+	var outdatedOpts outdatedOpts
+
+	root := &outdatedCmd{opts: outdatedOpts}
+
+	// adding two spaces to have consistent formatting
+	exampleText := []string{
+		"To list newer patch releases for every R, Python and Quarto version installed in /opt:",
+		"  wbi outdated",
+		"",
+		"To list newer patch releases for a single language:",
+		"  wbi outdated r",
+		"  wbi outdated python",
+		"  wbi outdated quarto",
+	}
+
+	cmd := &cobra.Command{
+		Use:     "outdated [language]",
+		Short:   "List installed versions of R, Python or Quarto with newer patch releases",
+		Example: strings.Join(exampleText, "\n"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := root.opts.Validate(lowerArgs(args)); err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			log.WithField("opts", fmt.Sprintf("%+v", root.opts)).Trace("outdated-opts")
+			if err := newOutdated(root.opts, lowerArgs(args)); err != nil {
+				return err
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	root.cmd = cmd
+	return root
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOutdatedParamsValidate tests the outdated command parameters
+func TestOutdatedParamsValidate(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		flags       outdatedOpts
+		expectError string
+	}{
+		"no argument succeeds": {
+			args:        []string{},
+			flags:       outdatedOpts{},
+			expectError: "",
+		},
+		"r argument succeeds": {
+			args:        []string{"r"},
+			flags:       outdatedOpts{},
+			expectError: "",
+		},
+		"quarto argument succeeds": {
+			args:        []string{"quarto"},
+			flags:       outdatedOpts{},
+			expectError: "",
+		},
+		"too many arguments": {
+			args:        []string{"r", "python"},
+			flags:       outdatedOpts{},
+			expectError: "too many arguments provided, please provide at most one argument",
+		},
+		"invalid argument": {
+			args:        []string{"workbench"},
+			flags:       outdatedOpts{},
+			expectError: "invalid language provided, please provide one of the following: r, python, quarto",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			outdatedCmd := newOutdatedCmd()
+			// set the flags
+			outdatedCmd.opts = tc.flags
+			// run validation
+			err := outdatedCmd.opts.Validate(tc.args)
+
+			if err != nil && tc.expectError != "" {
+				// if we expect an error, check that it contains the expected error
+				assert.Containsf(t, err.Error(), tc.expectError, "expected error containing %q, got %s", tc.expectError, err)
+			} else if err != nil && tc.expectError == "" {
+				// if we expect no error but get one then fail
+				t.Fatalf("expected no error, but got %s", err)
+			} else if err == nil && tc.expectError != "" {
+				// if we expect an error but don't get one then fail
+				t.Fatalf("expected error containing %q, but the command ran without error", tc.expectError)
+			}
+		})
+	}
+}
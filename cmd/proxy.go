@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/operatingsystem"
+	"github.com/sol-eng/wbi/internal/proxy"
+	"github.com/sol-eng/wbi/internal/system"
+	"github.com/sol-eng/wbi/internal/workbench"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type proxyCmd struct {
+	cmd  *cobra.Command
+	opts proxyOpts
+}
+
+type proxyOpts struct {
+	serverURL string
+	certPath  string
+	keyPath   string
+	install   bool
+}
+
+func newProxy(proxyOpts proxyOpts, proxyServer string) error {
+	osType, err := operatingsystem.DetectOS()
+	if err != nil {
+		return err
+	}
+
+	err = proxy.VerifyProxyCertAndKey(proxyOpts.certPath, proxyOpts.keyPath)
+	if err != nil {
+		return fmt.Errorf("issue verifying the TLS/SSL cert and key: %w", err)
+	}
+	serverName, err := proxy.ServerNameFromURL(proxyOpts.serverURL)
+	if err != nil {
+		return fmt.Errorf("issue with the server URL: %w", err)
+	}
+	// check Workbench can be rebound to 127.0.0.1 before the proxy is written, installed or restarted
+	err = workbench.VerifySSLDisabled()
+	if err != nil {
+		return err
+	}
+
+	if proxyOpts.install {
+		err = proxy.InstallProxyServer(proxyServer, osType)
+		if err != nil {
+			return fmt.Errorf("issue installing %s: %w", proxyServer, err)
+		}
+	}
+
+	proxyConfig := proxy.ProxyConfig{
+		ServerName: serverName,
+		CertPath:   proxyOpts.certPath,
+		KeyPath:    proxyOpts.keyPath,
+	}
+	// the config is tested once written, and the previous config is put back if the test fails
+	configPath, err := proxy.WriteProxyConfig(proxyServer, proxyConfig, osType)
+	if err != nil {
+		return fmt.Errorf("failed to write %s config: %w", proxyServer, err)
+	}
+	system.PrintAndLogInfo("\nThe " + proxyServer + " config has been written to " + configPath)
+
+	if proxyOpts.install {
+		err = proxy.EnableProxyServer(proxyServer, osType)
+		if err != nil {
+			return fmt.Errorf("issue enabling %s: %w", proxyServer, err)
+		}
+	}
+
+	// only rebind Workbench to 127.0.0.1 once the proxy config is known to be valid, otherwise Workbench would only be
+	// reachable through a broken proxy
+	err = workbench.WriteProxyListenConfig(proxyOpts.serverURL)
+	if err != nil {
+		return fmt.Errorf("failed to write the Workbench listen config: %w", err)
+	}
+
+	if !proxyOpts.install {
+		system.PrintAndLogInfo(proxy.ProxyNextSteps(proxyServer, osType))
+	}
+	system.PrintAndLogInfo("\nRestart Workbench with \"rstudio-server restart\" to apply the updated www-address, www-port and launcher-sessions-callback-address")
+	return nil
+}
+
+func setProxyOpts(proxyOpts *proxyOpts) {
+	proxyOpts.serverURL = viper.GetString("proxy-server-url")
+	proxyOpts.certPath = viper.GetString("proxy-cert")
+	proxyOpts.keyPath = viper.GetString("proxy-key")
+	proxyOpts.install = viper.GetBool("proxy-install")
+}
+
+func (opts *proxyOpts) Validate(args []string) error {
+	// check args lengths
+	if len(args) == 0 {
+		return fmt.Errorf("no arguments provided, please provide the action and the proxy server")
+	} else if len(args) == 1 {
+		return fmt.Errorf("no proxy server provided, please provide one of the following: %s", strings.Join(proxy.ValidProxyServers(), ", "))
+	} else if len(args) > 2 {
+		return fmt.Errorf("too many arguments provided, please provide only the action and the proxy server")
+	}
+
+	// generate is the only supported action
+	if args[0] != "generate" {
+		return fmt.Errorf("invalid action provided, please provide one of the following: generate")
+	}
+	// ensure the proxy server is valid
+	if !lo.Contains(proxy.ValidProxyServers(), args[1]) {
+		return fmt.Errorf("invalid proxy server provided, please provide one of the following: %s", strings.Join(proxy.ValidProxyServers(), ", "))
+	}
+
+	// the server-url, cert and key flags are required
+	if opts.serverURL == "" {
+		return fmt.Errorf("the server-url flag is required")
+	}
+	if opts.certPath == "" {
+		return fmt.Errorf("the cert flag is required")
+	}
+	if opts.keyPath == "" {
+		return fmt.Errorf("the key flag is required")
+	}
+
+	// traefik is not packaged by the supported operating systems
+	if opts.install && args[1] == "traefik" {
+		return fmt.Errorf("the install flag is not supported for traefik")
+	}
+
+	return nil
+}
+
+func newProxyCmd() *proxyCmd {
+	var proxyOpts proxyOpts
+
+	root := &proxyCmd{opts: proxyOpts}
+
+	// adding two spaces to have consistent formatting
+	exampleText := []string{
+		"To generate a reverse proxy config that terminates TLS in front of Workbench:",
+		"  wbi proxy generate nginx --server-url [SERVER-URL] --cert [PATH-TO-CERTIFICATE-FILE] --key [PATH-TO-KEY-FILE]",
+		"  wbi proxy generate apache --server-url [SERVER-URL] --cert [PATH-TO-CERTIFICATE-FILE] --key [PATH-TO-KEY-FILE]",
+		"  wbi proxy generate traefik --server-url [SERVER-URL] --cert [PATH-TO-CERTIFICATE-FILE] --key [PATH-TO-KEY-FILE]",
+		"",
+		"To also install and enable the proxy server package:",
+		"  wbi proxy generate nginx --server-url [SERVER-URL] --cert [PATH-TO-CERTIFICATE-FILE] --key [PATH-TO-KEY-FILE] --install",
+	}
+
+	cmd := &cobra.Command{
+		Use:     "proxy generate [proxy-server]",
+		Short:   "Generate a TLS terminating reverse proxy config for Workbench",
+		Example: strings.Join(exampleText, "\n"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			setProxyOpts(&root.opts)
+			if err := root.opts.Validate(lowerArgs(args)); err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			log.WithField("opts", fmt.Sprintf("%+v", root.opts)).Trace("proxy-opts")
+			if err := newProxy(root.opts, strings.ToLower(args[1])); err != nil {
+				return err
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP("server-url", "u", "", "Server URL that end users will use to access Workbench")
+	viper.BindPFlag("proxy-server-url", cmd.Flags().Lookup("server-url"))
+
+	cmd.Flags().StringP("cert", "c", "", "TLS/SSL certificate path")
+	viper.BindPFlag("proxy-cert", cmd.Flags().Lookup("cert"))
+
+	cmd.Flags().StringP("key", "k", "", "TLS/SSL key path")
+	viper.BindPFlag("proxy-key", cmd.Flags().Lookup("key"))
+
+	cmd.Flags().BoolP("install", "i", false, "Install and enable the proxy server package on the detected operating system")
+	viper.BindPFlag("proxy-install", cmd.Flags().Lookup("install"))
+
+	root.cmd = cmd
+	return root
+}
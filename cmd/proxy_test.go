@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProxyParamsValidate tests the proxy command parameters
+func TestProxyParamsValidate(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		flags       proxyOpts
+		expectError string
+	}{
+		// general arguement tests
+		"no argument or flag": {
+			args:        []string{},
+			flags:       proxyOpts{},
+			expectError: "no arguments provided, please provide the action and the proxy server",
+		},
+		"generate argument only fails": {
+			args:        []string{"generate"},
+			flags:       proxyOpts{},
+			expectError: "no proxy server provided, please provide one of the following: nginx, apache, traefik",
+		},
+		"too many arguments": {
+			args:        []string{"generate", "nginx", "apache"},
+			flags:       proxyOpts{},
+			expectError: "too many arguments provided, please provide only the action and the proxy server",
+		},
+		"invalid action fails": {
+			args:        []string{"remove", "nginx"},
+			flags:       proxyOpts{},
+			expectError: "invalid action provided, please provide one of the following: generate",
+		},
+		"invalid proxy server fails": {
+			args:        []string{"generate", "haproxy"},
+			flags:       proxyOpts{},
+			expectError: "invalid proxy server provided, please provide one of the following: nginx, apache, traefik",
+		},
+		// flag tests
+		"nginx argument without flags fails": {
+			args:        []string{"generate", "nginx"},
+			flags:       proxyOpts{},
+			expectError: "the server-url flag is required",
+		},
+		"nginx argument without cert flag fails": {
+			args:        []string{"generate", "nginx"},
+			flags:       proxyOpts{serverURL: "https://workbench.example.com"},
+			expectError: "the cert flag is required",
+		},
+		"nginx argument without key flag fails": {
+			args:        []string{"generate", "nginx"},
+			flags:       proxyOpts{serverURL: "https://workbench.example.com", certPath: "cert.crt"},
+			expectError: "the key flag is required",
+		},
+		"nginx argument with server-url, cert and key flags succeeds": {
+			args:        []string{"generate", "nginx"},
+			flags:       proxyOpts{serverURL: "https://workbench.example.com", certPath: "cert.crt", keyPath: "cert.key"},
+			expectError: "",
+		},
+		"apache argument with the install flag succeeds": {
+			args:        []string{"generate", "apache"},
+			flags:       proxyOpts{serverURL: "https://workbench.example.com", certPath: "cert.crt", keyPath: "cert.key", install: true},
+			expectError: "",
+		},
+		"traefik argument with server-url, cert and key flags succeeds": {
+			args:        []string{"generate", "traefik"},
+			flags:       proxyOpts{serverURL: "https://workbench.example.com", certPath: "cert.crt", keyPath: "cert.key"},
+			expectError: "",
+		},
+		"traefik argument with the install flag fails": {
+			args:        []string{"generate", "traefik"},
+			flags:       proxyOpts{serverURL: "https://workbench.example.com", certPath: "cert.crt", keyPath: "cert.key", install: true},
+			expectError: "the install flag is not supported for traefik",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			proxyCmd := newProxyCmd()
+			// set the flags
+			proxyCmd.opts = tc.flags
+			// run validation
+			err := proxyCmd.opts.Validate(tc.args)
+
+			if err != nil && tc.expectError != "" {
+				// if we expect an error, check that it contains the expected error
+				assert.Containsf(t, err.Error(), tc.expectError, "expected error containing %q, got %s", tc.expectError, err)
+			} else if err != nil && tc.expectError == "" {
+				// if we expect no error but get one then fail
+				t.Fatalf("expected no error, but got %s", err)
+			} else if err == nil && tc.expectError != "" {
+				// if we expect an error but don't get one then fail
+				t.Fatalf("expected error containing %q, but the command ran without error", tc.expectError)
+			}
+			// otherwise we expect the command to succeed so pass the test
+		})
+	}
+
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/config"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -13,6 +14,8 @@ import (
 type settings struct {
 	// logrus log level
 	loglevel string
+	// directory R, Python, Quarto, Julia and conda are installed under
+	installPrefix string
 }
 
 type rootCmd struct {
@@ -34,23 +37,36 @@ func (cmd *rootCmd) Execute(args []string) {
 	}
 }
 
-func setGlobalSettings(cfg *settings) {
+func setGlobalSettings(cfg *settings, prefixGiven bool) error {
 	cfg.loglevel = viper.GetString("loglevel")
 	setLogLevel(cfg.loglevel)
 	setUpLogger()
+	cfg.installPrefix = viper.GetString("install-prefix")
+	// without the flag or environment variable, use the prefix an earlier install was made under
+	if !prefixGiven {
+		savedPrefix, err := config.ReadSavedInstallPrefix()
+		if err != nil {
+			return err
+		}
+		if savedPrefix != "" {
+			cfg.installPrefix = savedPrefix
+		}
+	}
+	return config.SetInstallPrefix(cfg.installPrefix)
 }
 func newRootCmd(version string) *rootCmd {
 	root := &rootCmd{cfg: &settings{}}
 	cmd := &cobra.Command{
 		Use:   "wbi",
 		Short: "workbench installer",
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// need to set the config values here as the viper values
 			// will not be processed until Execute, so can't
 			// set them in the initializer.
 			// If persistentPreRun is used elsewhere, should
 			// remember to setGlobalSettings in the initializer
-			setGlobalSettings(root.cfg)
+			_, prefixEnvSet := os.LookupEnv(config.InstallPrefixEnv)
+			return setGlobalSettings(root.cfg, prefixEnvSet || cmd.Flags().Changed("install-prefix"))
 		},
 	}
 	cmd.Version = version
@@ -59,12 +75,20 @@ func newRootCmd(version string) *rootCmd {
 	cmd.SetVersionTemplate(`{{printf "%s\n" .Version}}`)
 	cmd.PersistentFlags().String("loglevel", "info", "log level")
 	viper.BindPFlag("loglevel", cmd.PersistentFlags().Lookup("loglevel"))
+	cmd.PersistentFlags().String("install-prefix", config.DefaultInstallPrefix, "Directory Quarto, Julia, conda and R built from source are installed under and scanned for. Can also be set with the "+config.InstallPrefixEnv+" environment variable, and defaults to the prefix of an earlier install.")
+	viper.BindPFlag("install-prefix", cmd.PersistentFlags().Lookup("install-prefix"))
+	viper.BindEnv("install-prefix", config.InstallPrefixEnv)
 	cmd.AddCommand(newSetupCmd().cmd)
 	cmd.AddCommand(newVerifyCmd().cmd)
 	cmd.AddCommand(newConfigCmd().cmd)
 	cmd.AddCommand(newInstallCmd().cmd)
 	cmd.AddCommand(newScanCmd().cmd)
 	cmd.AddCommand(newActivateCmd().cmd)
+	cmd.AddCommand(newProxyCmd().cmd)
+	cmd.AddCommand(newUninstallCmd().cmd)
+	cmd.AddCommand(newOutdatedCmd().cmd)
+	cmd.AddCommand(newUpgradeCmd().cmd)
+	cmd.AddCommand(newDefaultCmd().cmd)
 
 	root.cmd = cmd
 	return root
@@ -1,13 +1,23 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 
+	"github.com/samber/lo"
 	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/jupyter"
 	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/prodrivers"
+	"github.com/sol-eng/wbi/internal/quarto"
 	"github.com/sol-eng/wbi/internal/system"
+	"github.com/sol-eng/wbi/internal/workbench"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 type scanCmd struct {
@@ -16,30 +26,228 @@ type scanCmd struct {
 }
 
 type scanOpts struct {
+	json bool
 }
 
-func newScan(scanOpts scanOpts, language string) error {
-	if language == "r" {
-		rVersions, err := languages.ScanForRVersions()
-		if err != nil {
-			return fmt.Errorf("issue occured in scanning for R versions: %w", err)
+var validScanTargets = []string{"r", "python", "julia", "quarto", "jupyter", "workbench", "prodrivers", "all"}
+
+// inventory is the document produced by wbi scan all
+type inventory struct {
+	R          []languages.Installation `json:"r"`
+	Python     []languages.Installation `json:"python"`
+	Julia      []languages.Installation `json:"julia"`
+	Quarto     quarto.Inventory         `json:"quarto"`
+	Jupyter    jupyter.Inventory        `json:"jupyter"`
+	Workbench  workbench.Inventory      `json:"workbench"`
+	ProDrivers prodrivers.Inventory     `json:"prodrivers"`
+}
+
+// printTable prints rows with a header as an aligned table
+func printTable(header string, rows []string) {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, header)
+	for _, row := range rows {
+		fmt.Fprintln(writer, row)
+	}
+	writer.Flush()
+	system.PrintAndLogInfo(strings.TrimSuffix(builder.String(), "\n"))
+}
+
+// printJSON prints a scan result as indented JSON
+func printJSON(result any) error {
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("issue converting the scan result to JSON: %w", err)
+	}
+	system.PrintAndLogInfo(string(output))
+	return nil
+}
+
+// printInstallations prints the installations found by a scan as a table
+func printInstallations(installations []languages.Installation) {
+	rows := lo.Map(installations, func(i languages.Installation, _ int) string {
+		path := i.Path
+		if i.Target != i.Path {
+			path = i.Path + " -> " + i.Target
 		}
-		system.PrintAndLogInfo(strings.Join(rVersions, "\n"))
-	} else if language == "python" {
-		pythonVersions, err := languages.ScanForPythonVersions()
-		if err != nil {
-			return fmt.Errorf("issue occured in scanning for Python versions: %w", err)
+		return path + "\t" + i.Version + "\t" + i.Arch + "\t" + i.Origin + "\t" + i.Status()
+	})
+	printTable("PATH\tVERSION\tARCH\tORIGIN\tSTATUS", rows)
+}
+
+func printQuartoInventory(quartoInventory quarto.Inventory) {
+	rows := lo.Map(quartoInventory.Installations, func(i quarto.Installation, _ int) string {
+		status := "ok"
+		if i.Problem != "" {
+			status = "broken: " + i.Problem
 		}
-		system.PrintAndLogInfo(strings.Join(pythonVersions, "\n"))
+		return i.Path + "\t" + i.Version + "\t" + i.Origin + "\t" + status
+	})
+	printTable("PATH\tVERSION\tORIGIN\tSTATUS", rows)
+	if quartoInventory.SymlinkTarget != "" {
+		system.PrintAndLogInfo("\n/usr/local/bin/quarto points to " + quartoInventory.SymlinkTarget)
 	} else {
-		return fmt.Errorf("language %s is not supported", language)
+		system.PrintAndLogInfo("\n/usr/local/bin/quarto does not exist")
 	}
+}
 
+func printJupyterInventory(jupyterInventory jupyter.Inventory) {
+	if jupyterInventory.JupyterExe == "" {
+		system.PrintAndLogInfo("jupyter-exe is not set in /etc/rstudio/jupyter.conf")
+	} else {
+		system.PrintAndLogInfo("jupyter-exe: " + jupyterInventory.JupyterExe)
+	}
+
+	if len(jupyterInventory.Components) > 0 {
+		components := lo.Keys(jupyterInventory.Components)
+		sort.Strings(components)
+		rows := lo.Map(components, func(component string, _ int) string {
+			return component + "\t" + jupyterInventory.Components[component]
+		})
+		system.PrintAndLogInfo("")
+		printTable("COMPONENT\tVERSION", rows)
+	}
+
+	if len(jupyterInventory.Kernels) == 0 {
+		system.PrintAndLogInfo("\nNo Jupyter kernels are registered.")
+		return
+	}
+	rows := lo.Map(jupyterInventory.Kernels, func(k jupyter.Kernel, _ int) string {
+		return k.Name + "\t" + k.DisplayName + "\t" + k.Executable
+	})
+	system.PrintAndLogInfo("")
+	printTable("KERNEL\tDISPLAY NAME\tEXECUTABLE", rows)
+}
+
+func printWorkbenchInventory(workbenchInventory workbench.Inventory) {
+	if !workbenchInventory.Installed {
+		system.PrintAndLogInfo("Workbench is not installed.")
+		return
+	}
+	rows := []string{
+		"version\t" + workbenchInventory.Version,
+		"license\t" + workbenchInventory.License,
+	}
+	services := lo.Keys(workbenchInventory.Services)
+	sort.Strings(services)
+	for _, service := range services {
+		rows = append(rows, service+"\t"+workbenchInventory.Services[service])
+	}
+	printTable("WORKBENCH\tVALUE", rows)
+}
+
+func printProDriversInventory(proDriversInventory prodrivers.Inventory) {
+	if len(proDriversInventory.Drivers) == 0 {
+		system.PrintAndLogInfo("No drivers are listed in /etc/odbcinst.ini.")
+		return
+	}
+	rows := lo.Map(proDriversInventory.Drivers, func(d prodrivers.Driver, _ int) string {
+		return d.Name + "\t" + d.Driver + "\t" + strconv.FormatBool(d.IsProDriver()) + "\t" + strconv.FormatBool(!d.Missing)
+	})
+	printTable("DRIVER\tLIBRARY\tPRO DRIVER\tLIBRARY EXISTS", rows)
+}
+
+// scanLanguage scans for R, Python or Julia and prints the result
+func scanLanguage(scanOpts scanOpts, language string) error {
+	var installations []languages.Installation
+	var err error
+	switch language {
+	case "r":
+		installations, err = languages.InspectRInstallations()
+	case "python":
+		installations, err = languages.InspectPythonInstallations()
+	case "julia":
+		installations, err = languages.InspectJuliaInstallations()
+	}
+	if err != nil {
+		return err
+	}
+
+	if scanOpts.json {
+		return printJSON(installations)
+	}
+	if len(installations) == 0 {
+		system.PrintAndLogInfo("No " + programDisplayName(language) + " versions were found.")
+		return nil
+	}
+	printInstallations(installations)
 	return nil
 }
 
-func setScanOpts(scanOpts *scanOpts) {
+// scanAll scans every program and prints a single inventory document
+func scanAll() error {
+	var result inventory
+	var err error
+	result.R, err = languages.InspectRInstallations()
+	if err != nil {
+		return err
+	}
+	result.Python, err = languages.InspectPythonInstallations()
+	if err != nil {
+		return err
+	}
+	result.Julia, err = languages.InspectJuliaInstallations()
+	if err != nil {
+		return err
+	}
+	result.Quarto, err = quarto.ScanQuarto()
+	if err != nil {
+		return fmt.Errorf("issue occured in scanning for Quarto versions: %w", err)
+	}
+	result.Jupyter = jupyter.ScanJupyter()
+	result.Workbench = workbench.ScanWorkbench()
+	result.ProDrivers, err = prodrivers.ScanProDrivers()
+	if err != nil {
+		return fmt.Errorf("issue occured in scanning for Pro Drivers: %w", err)
+	}
+	return printJSON(result)
+}
 
+func newScan(scanOpts scanOpts, program string) error {
+	switch program {
+	case "r", "python", "julia":
+		return scanLanguage(scanOpts, program)
+	case "quarto":
+		quartoInventory, err := quarto.ScanQuarto()
+		if err != nil {
+			return fmt.Errorf("issue occured in scanning for Quarto versions: %w", err)
+		}
+		if scanOpts.json {
+			return printJSON(quartoInventory)
+		}
+		printQuartoInventory(quartoInventory)
+	case "jupyter":
+		jupyterInventory := jupyter.ScanJupyter()
+		if scanOpts.json {
+			return printJSON(jupyterInventory)
+		}
+		printJupyterInventory(jupyterInventory)
+	case "workbench":
+		workbenchInventory := workbench.ScanWorkbench()
+		if scanOpts.json {
+			return printJSON(workbenchInventory)
+		}
+		printWorkbenchInventory(workbenchInventory)
+	case "prodrivers":
+		proDriversInventory, err := prodrivers.ScanProDrivers()
+		if err != nil {
+			return fmt.Errorf("issue occured in scanning for Pro Drivers: %w", err)
+		}
+		if scanOpts.json {
+			return printJSON(proDriversInventory)
+		}
+		printProDriversInventory(proDriversInventory)
+	case "all":
+		return scanAll()
+	default:
+		return fmt.Errorf("program %s is not supported", program)
+	}
+	return nil
+}
+
+func setScanOpts(scanOpts *scanOpts) {
+	scanOpts.json = viper.GetBool("scan-json")
 }
 
 func (opts *scanOpts) Validate(args []string) error {
@@ -50,9 +258,9 @@ func (opts *scanOpts) Validate(args []string) error {
 		return fmt.Errorf("too many arguments provided, please provide only one argument")
 	}
 
-	// ensure only r or python is provided
-	if args[0] != "r" && args[0] != "python" {
-		return fmt.Errorf("invalid language provided, please provide one of the following: r, python")
+	// ensure only a supported program is provided
+	if !lo.Contains(validScanTargets, args[0]) {
+		return fmt.Errorf("invalid program provided, please provide one of the following: %s", strings.Join(validScanTargets, ", "))
 	}
 	return nil
 }
@@ -64,18 +272,31 @@ func newScanCmd() *scanCmd {
 
 	// adding two spaces to have consistent formatting
 	exampleText := []string{
-		"To scan for existing R and Python installations:",
+		"To scan for existing R, Python and Julia installations and run each one to report its version, architecture, origin and whether it works:",
 		"  wbi scan r",
 		"  wbi scan python",
+		"  wbi scan julia",
+		"",
+		"To scan for Quarto installs, the Jupyter installation and kernels, Workbench or the drivers in odbcinst.ini:",
+		"  wbi scan quarto",
+		"  wbi scan jupyter",
+		"  wbi scan workbench",
+		"  wbi scan prodrivers",
+		"",
+		"To produce a single JSON inventory of everything on the server:",
+		"  wbi scan all",
+		"",
+		"To print the result of a single scan as JSON:",
+		"  wbi scan r --json",
 	}
 
 	cmd := &cobra.Command{
-		Use:     "scan [lanaguage]",
-		Short:   "Scan for installed versions of R or Python",
+		Use:     "scan [program]",
+		Short:   "Scan for installed versions of R, Python, Julia, Quarto, Jupyter, Workbench or Pro Drivers",
 		Example: strings.Join(exampleText, "\n"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			setScanOpts(&root.opts)
-			if err := root.opts.Validate(args); err != nil {
+			if err := root.opts.Validate(lowerArgs(args)); err != nil {
 				return err
 			}
 			return nil
@@ -90,6 +311,9 @@ func newScanCmd() *scanCmd {
 		SilenceUsage: true,
 	}
 
+	cmd.Flags().Bool("json", false, "Prints the scan result as JSON. wbi scan all always prints JSON.")
+	viper.BindPFlag("scan-json", cmd.Flags().Lookup("json"))
+
 	root.cmd = cmd
 	return root
 }
@@ -36,11 +36,47 @@ func TestScanParamsValidate(t *testing.T) {
 			flags:       scanOpts{},
 			expectError: "",
 		},
-		// non r or python argument test
-		"non r or python argument only fails": {
+		// other program argument tests
+		"julia argument only succeeds": {
+			args:        []string{"julia"},
+			flags:       scanOpts{},
+			expectError: "",
+		},
+		"quarto argument only succeeds": {
+			args:        []string{"quarto"},
+			flags:       scanOpts{},
+			expectError: "",
+		},
+		"jupyter argument only succeeds": {
+			args:        []string{"jupyter"},
+			flags:       scanOpts{},
+			expectError: "",
+		},
+		"workbench argument only succeeds": {
 			args:        []string{"workbench"},
 			flags:       scanOpts{},
-			expectError: "invalid language provided, please provide one of the following: r, python",
+			expectError: "",
+		},
+		"prodrivers argument only succeeds": {
+			args:        []string{"prodrivers"},
+			flags:       scanOpts{},
+			expectError: "",
+		},
+		"all argument only succeeds": {
+			args:        []string{"all"},
+			flags:       scanOpts{},
+			expectError: "",
+		},
+		"all argument with json flag succeeds": {
+			args:        []string{"all"},
+			flags:       scanOpts{json: true},
+			expectError: "",
+		},
+		// unsupported argument test
+		"unsupported argument fails": {
+			args:        []string{"connect"},
+			flags:       scanOpts{},
+			expectError: "invalid program provided, please provide one of the following: r, python, julia, quarto, jupyter, workbench, prodrivers, all",
 		},
 	}
 
@@ -10,13 +10,16 @@ import (
 	"github.com/sol-eng/wbi/internal/connect"
 	"github.com/sol-eng/wbi/internal/jupyter"
 	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/launcher"
 	"github.com/sol-eng/wbi/internal/license"
 	"github.com/sol-eng/wbi/internal/operatingsystem"
 	"github.com/sol-eng/wbi/internal/packagemanager"
+	"github.com/sol-eng/wbi/internal/positron"
 	"github.com/sol-eng/wbi/internal/prodrivers"
 	"github.com/sol-eng/wbi/internal/quarto"
 	"github.com/sol-eng/wbi/internal/ssl"
 	"github.com/sol-eng/wbi/internal/system"
+	"github.com/sol-eng/wbi/internal/vscode"
 	"github.com/sol-eng/wbi/internal/workbench"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -148,6 +151,17 @@ func newSetup(setupOpts setupOpts) error {
 				return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step python\"", err)
 			}
 		}
+		step = "julia"
+	}
+
+	if step == "julia" {
+		// Julia
+		if lo.Contains(selectedLanguages, "julia") || setupOpts.step == "julia" {
+			err := languages.ScanAndHandleJuliaVersions(osType)
+			if err != nil {
+				return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step julia\"", err)
+			}
+		}
 		step = "workbench"
 	}
 
@@ -184,6 +198,24 @@ func newSetup(setupOpts setupOpts) error {
 		if err != nil {
 			return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step jupyter\"", err)
 		}
+		step = "vscode"
+	}
+
+	if step == "vscode" {
+		// VS Code
+		err = vscode.PromptAndConfigVSCode()
+		if err != nil {
+			return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step vscode\"", err)
+		}
+		step = "positron"
+	}
+
+	if step == "positron" {
+		// Positron
+		err = positron.CheckPromptAndConfigPositron()
+		if err != nil {
+			return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step positron\"", err)
+		}
 		step = "prodrivers"
 	}
 
@@ -236,6 +268,26 @@ func newSetup(setupOpts setupOpts) error {
 				return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step packagemanager\"", err)
 			}
 		}
+		step = "r-packages"
+	}
+
+	if step == "r-packages" {
+		// Baseline R packages, installed after Package Manager so they come from the configured repo
+		err = languages.PromptAndInstallRPackages()
+		if err != nil {
+			return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step r-packages\"", err)
+		}
+		step = "python-packages"
+	}
+
+	if step == "python-packages" {
+		// Baseline Python packages, installed after Package Manager writes /etc/pip.conf
+		if lo.Contains(selectedLanguages, "python") || setupOpts.step == "python-packages" {
+			err := languages.PromptAndInstallPythonPackages()
+			if err != nil {
+				return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step python-packages\"", err)
+			}
+		}
 		step = "connect"
 	}
 
@@ -251,6 +303,26 @@ func newSetup(setupOpts setupOpts) error {
 				return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step connect\"", err)
 			}
 		}
+		step = "launcher"
+	}
+
+	if step == "launcher" {
+		// Job Launcher Kubernetes backend
+		launcherChoice, err := launcher.PromptLauncherClusterChoice()
+		if err != nil {
+			return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step launcher\"", err)
+		}
+		if launcherChoice == "Kubernetes" {
+			err = launcher.PromptVerifyAndConfigKubernetes()
+			if err != nil {
+				return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step launcher\"", err)
+			}
+		} else if launcherChoice == "Slurm" {
+			err = launcher.PromptVerifyAndConfigSlurm()
+			if err != nil {
+				return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step launcher\"", err)
+			}
+		}
 		step = "restart"
 	}
 
@@ -275,6 +347,11 @@ func newSetup(setupOpts setupOpts) error {
 	}
 
 	if step == "verify" {
+		err := languages.PromptAndVerifyLanguages()
+		if err != nil {
+			return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step verify\"", err)
+		}
+
 		verifyChoice, err := workbench.PromptInstallVerify()
 		if err != nil {
 			return fmt.Errorf("%w.\nTo return to this step in the setup process use \"wbi setup --step verify\"", err)
@@ -338,7 +415,7 @@ func (opts *setupOpts) Validate(args []string) error {
 	}
 
 	// ensure step is valid
-	validSteps := []string{"start", "prereqs", "firewall", "security", "languages", "r", "python", "workbench", "license", "quarto", "jupyter", "prodrivers", "ssl", "packagemanager", "connect", "restart", "status", "verify"}
+	validSteps := []string{"start", "prereqs", "firewall", "security", "languages", "r", "python", "julia", "workbench", "license", "quarto", "jupyter", "vscode", "positron", "prodrivers", "ssl", "packagemanager", "r-packages", "python-packages", "connect", "launcher", "restart", "status", "verify"}
 	if opts.step != "" && !lo.Contains(validSteps, opts.step) {
 		return fmt.Errorf("invalid step: %s", opts.step)
 	}
@@ -380,7 +457,7 @@ func newSetupCmd() *setupCmd {
 		SilenceUsage: true,
 	}
 
-	stepHelp := `The step to start at. Valid steps are: start, prereqs, firewall, security, languages, r, python, workbench, license, quarto, jupyter, prodrivers, ssl, packagemanager, connect, restart, status, verify.`
+	stepHelp := `The step to start at. Valid steps are: start, prereqs, firewall, security, languages, r, python, julia, workbench, license, quarto, jupyter, vscode, positron, prodrivers, ssl, packagemanager, r-packages, python-packages, connect, launcher, restart, status, verify.`
 
 	cmd.Flags().StringP("step", "s", "", stepHelp)
 	viper.BindPFlag("step", cmd.Flags().Lookup("step"))
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/operatingsystem"
+	"github.com/sol-eng/wbi/internal/uninstall"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type uninstallCmd struct {
+	cmd  *cobra.Command
+	opts uninstallOpts
+}
+
+type uninstallOpts struct {
+	versions []string
+	force    bool
+	yes      bool
+}
+
+func newUninstall(uninstallOpts uninstallOpts, program string) error {
+	// Determine OS
+	osType, err := operatingsystem.DetectOS()
+	if err != nil {
+		return err
+	}
+
+	if program == "r" || program == "python" || program == "quarto" {
+		// plan every version first so nothing is removed if any version is still in use
+		var actions []uninstall.Action
+		for _, version := range uninstallOpts.versions {
+			versionActions, err := uninstall.PlanLanguageUninstall(program, version, osType, uninstallOpts.force)
+			if err != nil {
+				return fmt.Errorf("issue uninstalling %s version %s: %w", program, version, err)
+			}
+			actions = append(actions, versionActions...)
+		}
+		target := programDisplayName(program) + " version(s) " + strings.Join(uninstallOpts.versions, ", ")
+		err = uninstall.ConfirmAndRun(target, actions, uninstallOpts.yes)
+		if err != nil {
+			return fmt.Errorf("issue uninstalling %s: %w", target, err)
+		}
+	} else if program == "workbench" {
+		actions, err := uninstall.PlanWorkbenchUninstall(osType)
+		if err != nil {
+			return fmt.Errorf("issue uninstalling Workbench: %w", err)
+		}
+		err = uninstall.ConfirmAndRun("Workbench", actions, uninstallOpts.yes)
+		if err != nil {
+			return fmt.Errorf("issue uninstalling Workbench: %w", err)
+		}
+	} else if program == "prodrivers" {
+		actions, err := uninstall.PlanProDriversUninstall(osType)
+		if err != nil {
+			return fmt.Errorf("issue uninstalling Pro Drivers: %w", err)
+		}
+		err = uninstall.ConfirmAndRun("Pro Drivers", actions, uninstallOpts.yes)
+		if err != nil {
+			return fmt.Errorf("issue uninstalling Pro Drivers: %w", err)
+		}
+	} else if program == "jupyter" {
+		actions, err := uninstall.PlanJupyterUninstall()
+		if err != nil {
+			return fmt.Errorf("issue uninstalling Jupyter: %w", err)
+		}
+		err = uninstall.ConfirmAndRun("Jupyter", actions, uninstallOpts.yes)
+		if err != nil {
+			return fmt.Errorf("issue uninstalling Jupyter: %w", err)
+		}
+	}
+	return nil
+}
+
+func setUninstallOpts(uninstallOpts *uninstallOpts) {
+	uninstallOpts.versions = viper.GetStringSlice("uninstall-version")
+	uninstallOpts.force = viper.GetBool("uninstall-force")
+	uninstallOpts.yes = viper.GetBool("uninstall-yes")
+}
+
+func (opts *uninstallOpts) Validate(args []string) error {
+	// check args lengths
+	if len(args) == 0 {
+		return fmt.Errorf("no arguments provided, please provide one argument")
+	} else if len(args) > 1 {
+		return fmt.Errorf("too many arguments provided, please provide only one argument")
+	}
+
+	// ensure program is valid
+	if args[0] != "r" && args[0] != "python" && args[0] != "quarto" && args[0] != "workbench" && args[0] != "prodrivers" && args[0] != "jupyter" {
+		return fmt.Errorf("invalid argument provided, please provide one of the following: r, python, quarto, workbench, prodrivers, jupyter")
+	}
+
+	// versions are required for r, python and quarto
+	if len(opts.versions) == 0 && (args[0] == "r" || args[0] == "python" || args[0] == "quarto") {
+		return fmt.Errorf("the version flag is required for %s", args[0])
+	}
+	// versions must be exact so they can only ever name one install directory
+	for _, version := range opts.versions {
+		if !exactVersion.MatchString(version) {
+			return fmt.Errorf("invalid %s version %s provided, please provide exact versions such as 4.3.2", args[0], version)
+		}
+	}
+	// versions are not supported for workbench, prodrivers or jupyter
+	if len(opts.versions) != 0 && (args[0] == "workbench" || args[0] == "prodrivers" || args[0] == "jupyter") {
+		return fmt.Errorf("%s does not support specifying versions", args[0])
+	}
+
+	// the force flag is only supported for r, python and quarto
+	if opts.force && args[0] != "r" && args[0] != "python" && args[0] != "quarto" {
+		return fmt.Errorf("the force flag is only supported for r, python and quarto")
+	}
+
+	return nil
+}
+
+func newUninstallCmd() *uninstallCmd {
+	var uninstallOpts uninstallOpts
+
+	root := &uninstallCmd{opts: uninstallOpts}
+
+	// adding two spaces to have consistent formatting
+	exampleText := []string{
+		"To uninstall a specific R, Python or Quarto version:",
+		"  wbi uninstall r --version 4.1.3",
+		"  wbi uninstall python --version 3.10.10",
+		"  wbi uninstall quarto --version 1.2.475",
+		"",
+		"To uninstall a version that is still symlinked, on PATH, used by jupyter-exe or a Jupyter kernel, removing those references first:",
+		"  wbi uninstall python --version 3.10.10 --force",
+		"",
+		"To uninstall Workbench, Pro Drivers or Jupyter:",
+		"  wbi uninstall workbench",
+		"  wbi uninstall prodrivers",
+		"  wbi uninstall jupyter",
+		"",
+		"To uninstall without the confirmation prompt:",
+		"  wbi uninstall r --version 4.1.3 --yes",
+	}
+
+	cmd := &cobra.Command{
+		Use:     "uninstall [program]",
+		Short:   "Uninstall R, Python, Quarto, Workbench, Pro Drivers, or Jupyter",
+		Example: strings.Join(exampleText, "\n"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			setUninstallOpts(&root.opts)
+			if err := root.opts.Validate(lowerArgs(args)); err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			log.WithField("opts", fmt.Sprintf("%+v", root.opts)).Trace("uninstall-opts")
+			if err := newUninstall(root.opts, strings.ToLower(args[0])); err != nil {
+				return err
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringSliceP("version", "v", []string{}, "Version(s) of R, Python or Quarto to uninstall. Multiple values can be passed by seperating each version with a comma.")
+	viper.BindPFlag("uninstall-version", cmd.Flags().Lookup("version"))
+
+	cmd.Flags().BoolP("force", "f", false, "Removes symlinks, PATH entries, jupyter-exe and Jupyter kernels that point to the R, Python or Quarto version before uninstalling it.")
+	viper.BindPFlag("uninstall-force", cmd.Flags().Lookup("force"))
+
+	cmd.Flags().BoolP("yes", "y", false, "Uninstalls without asking for confirmation.")
+	viper.BindPFlag("uninstall-yes", cmd.Flags().Lookup("yes"))
+
+	root.cmd = cmd
+	return root
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUninstallParamsValidate tests the uninstall command parameters
+func TestUninstallParamsValidate(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		flags       uninstallOpts
+		expectError string
+	}{
+		// general arguement tests
+		"no argument": {
+			args:        []string{},
+			flags:       uninstallOpts{},
+			expectError: "no arguments provided, please provide one argument",
+		},
+		"too many arguments": {
+			args:        []string{"r", "python"},
+			flags:       uninstallOpts{},
+			expectError: "too many arguments provided, please provide only one argument",
+		},
+		"invalid argument": {
+			args:        []string{"rstudio"},
+			flags:       uninstallOpts{},
+			expectError: "invalid argument provided, please provide one of the following: r, python, quarto, workbench, prodrivers, jupyter",
+		},
+		// r, python and quarto argument tests
+		"r argument without a version fails": {
+			args:        []string{"r"},
+			flags:       uninstallOpts{},
+			expectError: "the version flag is required for r",
+		},
+		"r argument with a version succeeds": {
+			args:        []string{"r"},
+			flags:       uninstallOpts{versions: []string{"4.1.3"}},
+			expectError: "",
+		},
+		"r argument with a relative path as the version fails": {
+			args:        []string{"r"},
+			flags:       uninstallOpts{versions: []string{".."}},
+			expectError: "invalid r version .. provided, please provide exact versions such as 4.3.2",
+		},
+		"quarto argument with a partial version fails": {
+			args:        []string{"quarto"},
+			flags:       uninstallOpts{versions: []string{"1.3.340", "1.3"}},
+			expectError: "invalid quarto version 1.3 provided",
+		},
+		"python argument with multiple versions and force succeeds": {
+			args:        []string{"python"},
+			flags:       uninstallOpts{versions: []string{"3.10.10", "3.9.16"}, force: true},
+			expectError: "",
+		},
+		"quarto argument with a version and yes succeeds": {
+			args:        []string{"quarto"},
+			flags:       uninstallOpts{versions: []string{"1.2.475"}, yes: true},
+			expectError: "",
+		},
+		// workbench, prodrivers and jupyter argument tests
+		"workbench argument succeeds": {
+			args:        []string{"workbench"},
+			flags:       uninstallOpts{},
+			expectError: "",
+		},
+		"prodrivers argument with a version fails": {
+			args:        []string{"prodrivers"},
+			flags:       uninstallOpts{versions: []string{"2023.05.0"}},
+			expectError: "prodrivers does not support specifying versions",
+		},
+		"jupyter argument with force fails": {
+			args:        []string{"jupyter"},
+			flags:       uninstallOpts{force: true},
+			expectError: "the force flag is only supported for r, python and quarto",
+		},
+		"jupyter argument with yes succeeds": {
+			args:        []string{"jupyter"},
+			flags:       uninstallOpts{yes: true},
+			expectError: "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			uninstallCmd := newUninstallCmd()
+			// set the flags
+			uninstallCmd.opts = tc.flags
+			// run validation
+			err := uninstallCmd.opts.Validate(tc.args)
+
+			if err != nil && tc.expectError != "" {
+				// if we expect an error, check that it contains the expected error
+				assert.Containsf(t, err.Error(), tc.expectError, "expected error containing %q, got %s", tc.expectError, err)
+			} else if err != nil && tc.expectError == "" {
+				// if we expect no error but get one then fail
+				t.Fatalf("expected no error, but got %s", err)
+			} else if err == nil && tc.expectError != "" {
+				// if we expect an error but don't get one then fail
+				t.Fatalf("expected error containing %q, but the command ran without error", tc.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/operatingsystem"
+	"github.com/sol-eng/wbi/internal/quarto"
+	"github.com/sol-eng/wbi/internal/system"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type upgradeCmd struct {
+	cmd  *cobra.Command
+	opts upgradeOpts
+}
+
+type upgradeOpts struct {
+	patch       bool
+	moveDefault bool
+}
+
+func newUpgrade(upgradeOpts upgradeOpts, language string) error {
+	// Determine OS
+	osType, err := operatingsystem.DetectOS()
+	if err != nil {
+		return err
+	}
+
+	outdated, err := retrieveOutdatedVersions(language, osType)
+	if err != nil {
+		return err
+	}
+	if len(outdated) == 0 {
+		system.PrintAndLogInfo("\nAll installed " + programDisplayName(language) + " versions are on the latest patch release.")
+		return nil
+	}
+
+	newVersions := languages.UniqueLatestVersions(outdated)
+	system.PrintAndLogInfo("\nInstalling " + programDisplayName(language) + " version(s) " + strings.Join(newVersions, ", ") + " side by side with the existing versions.")
+
+	if language == "r" || language == "python" {
+		// install prereqs
+		err = operatingsystem.InstallPrereqs(osType)
+		if err != nil {
+			return fmt.Errorf("issue installing pre-requisites: %w", err)
+		}
+	}
+	for _, newVersion := range newVersions {
+		switch language {
+		case "r":
+			err = languages.DownloadAndInstallR(newVersion, osType)
+		case "python":
+			err = languages.DownloadAndInstallPython(newVersion, osType)
+		case "quarto":
+			err = quarto.DownloadAndInstallQuarto(newVersion, osType)
+		}
+		if err != nil {
+			return fmt.Errorf("issue installing %s version %s: %w", programDisplayName(language), newVersion, err)
+		}
+	}
+
+	if upgradeOpts.moveDefault {
+		for _, o := range outdated {
+			var moved bool
+			switch language {
+			case "r":
+				moved, err = languages.MoveRSymlinks(o.Installed, o.Latest)
+			case "python":
+				moved, err = languages.MovePythonPATH(o.Installed, o.Latest)
+			case "quarto":
+				moved, err = quarto.MoveQuartoSymlink(o.Installed, o.Latest)
+			}
+			if err != nil {
+				return fmt.Errorf("issue moving the default %s from %s to %s: %w", programDisplayName(language), o.Installed, o.Latest, err)
+			}
+			if moved {
+				system.PrintAndLogInfo("\nThe default " + programDisplayName(language) + " has been moved from " + o.Installed + " to " + o.Latest + ".")
+			}
+		}
+	}
+	return nil
+}
+
+func setUpgradeOpts(upgradeOpts *upgradeOpts) {
+	upgradeOpts.patch = viper.GetBool("upgrade-patch")
+	upgradeOpts.moveDefault = viper.GetBool("upgrade-move-default")
+}
+
+func (opts *upgradeOpts) Validate(args []string) error {
+	// check args lengths
+	if len(args) == 0 {
+		return fmt.Errorf("no arguments provided, please provide one argument")
+	} else if len(args) > 1 {
+		return fmt.Errorf("too many arguments provided, please provide only one argument")
+	}
+
+	// ensure only r, python or quarto is provided
+	if args[0] != "r" && args[0] != "python" && args[0] != "quarto" {
+		return fmt.Errorf("invalid language provided, please provide one of the following: r, python, quarto")
+	}
+
+	// only patch upgrades are supported so the flag must be explicitly provided
+	if !opts.patch {
+		return fmt.Errorf("the patch flag is required, only patch upgrades are supported")
+	}
+	return nil
+}
+
+func newUpgradeCmd() *upgradeCmd {
+	var upgradeOpts upgradeOpts
+
+	root := &upgradeCmd{opts: upgradeOpts}
+
+	// adding two spaces to have consistent formatting
+	exampleText := []string{
+		"To install the newer patch versions of R, Python or Quarto side by side with the installed versions:",
+		"  wbi upgrade r --patch",
+		"  wbi upgrade python --patch",
+		"  wbi upgrade quarto --patch",
+		"",
+		"To also move the default symlink or PATH entry to the newer patch version:",
+		"  wbi upgrade r --patch --move-default",
+	}
+
+	cmd := &cobra.Command{
+		Use:     "upgrade [language]",
+		Short:   "Install newer patch releases of R, Python or Quarto side by side",
+		Example: strings.Join(exampleText, "\n"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			setUpgradeOpts(&root.opts)
+			if err := root.opts.Validate(lowerArgs(args)); err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			log.WithField("opts", fmt.Sprintf("%+v", root.opts)).Trace("upgrade-opts")
+			if err := newUpgrade(root.opts, strings.ToLower(args[0])); err != nil {
+				return err
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolP("patch", "p", false, "Installs the newest patch release within the minor line of each installed version.")
+	viper.BindPFlag("upgrade-patch", cmd.Flags().Lookup("patch"))
+
+	cmd.Flags().BoolP("move-default", "m", false, "Moves the R or Quarto symlink or the Python PATH entry from the old version to the newer patch version.")
+	viper.BindPFlag("upgrade-move-default", cmd.Flags().Lookup("move-default"))
+
+	root.cmd = cmd
+	return root
+}
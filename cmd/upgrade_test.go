@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUpgradeParamsValidate tests the upgrade command parameters
+func TestUpgradeParamsValidate(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		flags       upgradeOpts
+		expectError string
+	}{
+		"no argument": {
+			args:        []string{},
+			flags:       upgradeOpts{patch: true},
+			expectError: "no arguments provided, please provide one argument",
+		},
+		"too many arguments": {
+			args:        []string{"r", "python"},
+			flags:       upgradeOpts{patch: true},
+			expectError: "too many arguments provided, please provide only one argument",
+		},
+		"invalid argument": {
+			args:        []string{"workbench"},
+			flags:       upgradeOpts{patch: true},
+			expectError: "invalid language provided, please provide one of the following: r, python, quarto",
+		},
+		"r argument without patch flag fails": {
+			args:        []string{"r"},
+			flags:       upgradeOpts{},
+			expectError: "the patch flag is required, only patch upgrades are supported",
+		},
+		"r argument with patch flag succeeds": {
+			args:        []string{"r"},
+			flags:       upgradeOpts{patch: true},
+			expectError: "",
+		},
+		"python argument with patch and move-default flags succeeds": {
+			args:        []string{"python"},
+			flags:       upgradeOpts{patch: true, moveDefault: true},
+			expectError: "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			upgradeCmd := newUpgradeCmd()
+			// set the flags
+			upgradeCmd.opts = tc.flags
+			// run validation
+			err := upgradeCmd.opts.Validate(tc.args)
+
+			if err != nil && tc.expectError != "" {
+				// if we expect an error, check that it contains the expected error
+				assert.Containsf(t, err.Error(), tc.expectError, "expected error containing %q, got %s", tc.expectError, err)
+			} else if err != nil && tc.expectError == "" {
+				// if we expect no error but get one then fail
+				t.Fatalf("expected no error, but got %s", err)
+			} else if err == nil && tc.expectError != "" {
+				// if we expect an error but don't get one then fail
+				t.Fatalf("expected error containing %q, but the command ran without error", tc.expectError)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/sol-eng/wbi/internal/connect"
+	"github.com/sol-eng/wbi/internal/languages"
 	"github.com/sol-eng/wbi/internal/license"
 	"github.com/sol-eng/wbi/internal/packagemanager"
 	"github.com/sol-eng/wbi/internal/ssl"
@@ -86,6 +87,24 @@ func newVerify(verifyOpts verifyOpts, item string) error {
 		}
 
 		fmt.Println("SSL successfully verified")
+	} else if item == "r" {
+		rHomes, err := languages.RPackageTargets([]string{"all"})
+		if err != nil {
+			return err
+		}
+		_, err = languages.VerifyRInstallations(rHomes)
+		if err != nil {
+			return fmt.Errorf("issue verifying R: %w", err)
+		}
+	} else if item == "python" {
+		pythonVersions, err := languages.PythonPackageTargets([]string{"all"})
+		if err != nil {
+			return err
+		}
+		_, err = languages.VerifyPythonInstallations(pythonVersions)
+		if err != nil {
+			return fmt.Errorf("issue verifying Python: %w", err)
+		}
 	} else if item == "license" {
 		_, err := license.CheckLicenseActivation()
 		if err != nil {
@@ -188,6 +207,12 @@ func newVerifyCmd() *verifyCmd {
 		"",
 		"To verify a license is activated:",
 		"  wbi verify license",
+		"",
+		"To verify every installed R version can draw graphics, install packages from its repo and use BLAS/LAPACK:",
+		"  wbi verify r",
+		"",
+		"To verify every installed Python version has ssl, sqlite3 and venv and can download packages from its index:",
+		"  wbi verify python",
 	}
 
 	cmd := &cobra.Command{
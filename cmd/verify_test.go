@@ -184,6 +184,27 @@ func TestVerifyParamsValidate(t *testing.T) {
 			flags:       verifyOpts{keyPath: "cert.key"},
 			expectError: "the key-path flag is only supported for ssl",
 		},
+		// r and python argument tests
+		"r argument only succeeds": {
+			args:        []string{"r"},
+			flags:       verifyOpts{},
+			expectError: "",
+		},
+		"r argument and a url flag fails": {
+			args:        []string{"r"},
+			flags:       verifyOpts{url: "https://packagemanager.posit.co/"},
+			expectError: "the url flag is only supported for packagemanager and connect",
+		},
+		"python argument only succeeds": {
+			args:        []string{"python"},
+			flags:       verifyOpts{},
+			expectError: "",
+		},
+		"python argument and a repo flag fails": {
+			args:        []string{"python"},
+			flags:       verifyOpts{repo: "pypi"},
+			expectError: "the repo flag is only supported for packagemanager",
+		},
 	}
 
 	for name, tc := range tests {
@@ -0,0 +1,227 @@
+package conda
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/install"
+	"github.com/sol-eng/wbi/internal/system"
+	"gopkg.in/yaml.v3"
+)
+
+// CondaRoot returns the directory Miniforge is installed into, such as /opt/conda
+func CondaRoot() string {
+	return config.InstallRoot("conda")
+}
+
+// profileScriptPath makes the conda command available in every login shell, including Workbench sessions
+const profileScriptPath = "/etc/profile.d/conda.sh"
+
+var environmentName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// condarc is the system wide conda configuration in the .condarc of the conda root
+type condarc struct {
+	Channels         []string `yaml:"channels"`
+	ChannelPriority  string   `yaml:"channel_priority"`
+	EnvsDirs         []string `yaml:"envs_dirs"`
+	PkgsDirs         []string `yaml:"pkgs_dirs"`
+	ShowChannelURLs  bool     `yaml:"show_channel_urls"`
+	AutoActivateBase bool     `yaml:"auto_activate_base"`
+}
+
+// CondaBinary returns the path to the conda binary
+func CondaBinary() string {
+	return filepath.Join(CondaRoot(), "bin", "conda")
+}
+
+// MiniforgeInstallerURL returns the URL of the latest Miniforge installer for the architecture wbi is running on
+func MiniforgeInstallerURL() (string, error) {
+	var arch string
+	switch runtime.GOARCH {
+	case "amd64":
+		arch = "x86_64"
+	case "arm64":
+		arch = "aarch64"
+	default:
+		return "", errors.New("Miniforge is not available for the " + runtime.GOARCH + " architecture")
+	}
+	return "https://github.com/conda-forge/miniforge/releases/latest/download/Miniforge3-Linux-" + arch + ".sh", nil
+}
+
+// InstallMiniforge installs Miniforge into the conda root from an installer URL or a local installer, using the latest release
+// when neither is given. Nothing is done if conda is already installed in the conda root
+func InstallMiniforge(installer string) error {
+	if system.VerifyFileExists(CondaBinary()) {
+		system.PrintAndLogInfo("\nconda is already installed in " + CondaRoot() + ", skipping the Miniforge installation.")
+		return nil
+	}
+
+	var err error
+	if installer == "" {
+		installer, err = MiniforgeInstallerURL()
+		if err != nil {
+			return err
+		}
+	}
+	installerPath := installer
+	if strings.HasPrefix(installer, "http://") || strings.HasPrefix(installer, "https://") {
+		installerPath, err = install.DownloadFile("Miniforge", installer, filepath.Base(installer))
+		if err != nil {
+			return fmt.Errorf("issue downloading the Miniforge installer: %w", err)
+		}
+	}
+
+	installCommand := "bash " + system.ShellQuote(installerPath) + " -b -p " + CondaRoot()
+	err = system.RunCommand(installCommand, true, 1, true)
+	if err != nil {
+		return fmt.Errorf("issue installing Miniforge with the command '%s': %w", installCommand, err)
+	}
+	err = config.SaveInstallPrefix()
+	if err != nil {
+		return fmt.Errorf("issue saving the install prefix: %w", err)
+	}
+	system.PrintAndLogInfo("\nMiniforge has been successfully installed into " + CondaRoot() + "!")
+	return nil
+}
+
+// WriteCondarc writes the system wide condarc so every user resolves packages from the channels and finds the shared
+// environments. The condarc is backed up the first time it is replaced
+func WriteCondarc(channels []string) error {
+	condarcPath := filepath.Join(CondaRoot(), ".condarc")
+	if len(channels) == 0 {
+		channels = []string{"conda-forge"}
+	}
+	config := condarc{
+		Channels:        channels,
+		ChannelPriority: "strict",
+		EnvsDirs:        []string{filepath.Join(CondaRoot(), "envs")},
+		PkgsDirs:        []string{filepath.Join(CondaRoot(), "pkgs")},
+		ShowChannelURLs: true,
+	}
+	contents, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("issue creating the condarc: %w", err)
+	}
+
+	err = system.MoveToBackup(condarcPath)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(condarcPath, contents, 0644)
+	if err != nil {
+		return fmt.Errorf("issue writing %s: %w", condarcPath, err)
+	}
+	system.PrintAndLogInfo("\n" + condarcPath + " has been written with the channels " + strings.Join(channels, ", "))
+	return nil
+}
+
+// EnableCondaForAllUsers links conda's profile script into /etc/profile.d so the conda command and the shared environments
+// are available in Workbench sessions and terminals
+func EnableCondaForAllUsers() error {
+	err := system.ReplaceSymlink(filepath.Join(CondaRoot(), "etc", "profile.d", "conda.sh"), profileScriptPath)
+	if err != nil {
+		return fmt.Errorf("issue making conda available to all users: %w", err)
+	}
+	system.PrintAndLogInfo("\nconda will be available to users in new sessions through " + profileScriptPath)
+	return nil
+}
+
+// EnvironmentName reads the name of an environment from its environment.yml file
+func EnvironmentName(environmentFile string) (string, error) {
+	contents, err := os.ReadFile(environmentFile)
+	if err != nil {
+		return "", fmt.Errorf("issue reading %s: %w", environmentFile, err)
+	}
+	var environment struct {
+		Name string `yaml:"name"`
+	}
+	err = yaml.Unmarshal(contents, &environment)
+	if err != nil {
+		return "", fmt.Errorf("issue parsing %s: %w", environmentFile, err)
+	}
+	if !environmentName.MatchString(environment.Name) {
+		return "", errors.New(environmentFile + " must set a name made of letters, numbers, dots, dashes or underscores")
+	}
+	return environment.Name, nil
+}
+
+// CreateEnvironment creates the shared environment described by an environment.yml file in the conda root's envs, or updates it
+// if it already exists, and returns the environment's prefix
+func CreateEnvironment(environmentFile string) (string, error) {
+	name, err := EnvironmentName(environmentFile)
+	if err != nil {
+		return "", err
+	}
+	prefix := filepath.Join(CondaRoot(), "envs", name)
+
+	action := "create"
+	if system.VerifyFileExists(filepath.Join(prefix, "conda-meta")) {
+		action = "update --prune"
+	}
+	envCommand := CondaBinary() + " env " + action + " --file " + system.ShellQuote(environmentFile) + " --prefix " + prefix
+	err = system.RunCommand(envCommand, true, 1, true)
+	if err != nil {
+		return "", fmt.Errorf("issue creating the conda environment %s with the command '%s': %w", name, envCommand, err)
+	}
+
+	// make the environment readable by every user
+	permissionsCommand := "chmod -R a+rX " + prefix
+	err = system.RunCommand(permissionsCommand, false, 0, true)
+	if err != nil {
+		return "", fmt.Errorf("issue setting permissions on %s with the command '%s': %w", prefix, permissionsCommand, err)
+	}
+	system.PrintAndLogInfo("\nThe conda environment " + name + " is available in " + prefix)
+	return prefix, nil
+}
+
+// RegisterEnvironmentKernel installs ipykernel into a conda environment and registers it as a system wide Jupyter kernel
+func RegisterEnvironmentKernel(prefix string) error {
+	name := filepath.Base(prefix)
+	ipykernelCommand := CondaBinary() + " install --yes --prefix " + prefix + " ipykernel"
+	err := system.RunCommand(ipykernelCommand, true, 1, true)
+	if err != nil {
+		return fmt.Errorf("issue installing ipykernel with the command '%s': %w", ipykernelCommand, err)
+	}
+
+	kernelCommand := filepath.Join(prefix, "bin", "python") + " -m ipykernel install --name conda-" + name + " --display-name \"Python (conda " + name + ")\""
+	err = system.RunCommand(kernelCommand, true, 0, true)
+	if err != nil {
+		return fmt.Errorf("issue registering the conda kernel with the command '%s': %w", kernelCommand, err)
+	}
+	return nil
+}
+
+// InstallAndConfigConda installs Miniforge, configures its channels, makes it available to all users and creates and
+// registers each environment
+func InstallAndConfigConda(installer string, channels []string, environmentFiles []string) error {
+	err := InstallMiniforge(installer)
+	if err != nil {
+		return err
+	}
+	err = WriteCondarc(channels)
+	if err != nil {
+		return err
+	}
+	err = EnableCondaForAllUsers()
+	if err != nil {
+		return err
+	}
+
+	for _, environmentFile := range environmentFiles {
+		prefix, err := CreateEnvironment(environmentFile)
+		if err != nil {
+			return err
+		}
+		err = RegisterEnvironmentKernel(prefix)
+		if err != nil {
+			return fmt.Errorf("issue registering a Jupyter kernel for %s: %w", prefix, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package conda
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCondarcKeepsTheOriginalBackup(t *testing.T) {
+	prefix := t.TempDir()
+	if err := config.SetInstallPrefix(prefix); err != nil {
+		t.Fatalf("issue setting the install prefix: %v", err)
+	}
+	defer config.SetInstallPrefix("")
+
+	condarcPath := filepath.Join(CondaRoot(), ".condarc")
+	if err := os.MkdirAll(CondaRoot(), 0755); err != nil {
+		t.Fatalf("issue creating %s: %v", CondaRoot(), err)
+	}
+	original := "channels:\n  - defaults\n"
+	if err := os.WriteFile(condarcPath, []byte(original), 0644); err != nil {
+		t.Fatalf("issue writing %s: %v", condarcPath, err)
+	}
+
+	assert.NoError(t, WriteCondarc([]string{"https://conda.example.com/conda-forge"}))
+	assert.NoError(t, WriteCondarc(nil))
+
+	backup, err := os.ReadFile(condarcPath + ".bak")
+	assert.NoError(t, err)
+	assert.Equal(t, original, string(backup))
+
+	written, err := os.ReadFile(condarcPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(written), "- conda-forge")
+	assert.Contains(t, string(written), filepath.Join(CondaRoot(), "envs"))
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultInstallPrefix is the directory R, Python, Quarto, Julia and conda are installed under unless another prefix is configured
+const DefaultInstallPrefix = "/opt"
+
+// InstallPrefixEnv is the environment variable the install prefix can be configured with
+const InstallPrefixEnv = "WBI_INSTALL_PREFIX"
+
+var installPrefix = DefaultInstallPrefix
+
+// savedInstallPrefixPath records the install prefix programs were installed under, so later runs find them without the flag
+var savedInstallPrefixPath = "/etc/wbi/install-prefix"
+
+// SetInstallPrefix sets the directory programs are installed under. An empty prefix restores the default
+func SetInstallPrefix(prefix string) error {
+	if prefix == "" {
+		installPrefix = DefaultInstallPrefix
+		return nil
+	}
+	if !filepath.IsAbs(prefix) {
+		return errors.New("the install prefix " + prefix + " must be an absolute path")
+	}
+	installPrefix = filepath.Clean(prefix)
+	return nil
+}
+
+// InstallPrefix returns the directory programs are installed under, such as /opt
+func InstallPrefix() string {
+	return installPrefix
+}
+
+// IsDefaultInstallPrefix returns true if programs are installed under the default /opt prefix
+func IsDefaultInstallPrefix() bool {
+	return installPrefix == DefaultInstallPrefix
+}
+
+// InstallRoot returns the directory the versions of a program are installed in, such as /opt/R
+func InstallRoot(program string) string {
+	return filepath.Join(installPrefix, program)
+}
+
+// InstallDir returns the directory one version of a program is installed in, such as /opt/R/4.3.2
+func InstallDir(program string, version string) string {
+	return filepath.Join(installPrefix, program, version)
+}
+
+// DefaultInstallRoot returns the directory the versions of a program are installed in under /opt, such as /opt/python.
+// Posit's R and Python packages always install there, whatever the install prefix is
+func DefaultInstallRoot(program string) string {
+	return filepath.Join(DefaultInstallPrefix, program)
+}
+
+// DefaultInstallDir returns the directory one version of a program is installed in under /opt, such as /opt/python/3.11.6
+func DefaultInstallDir(program string, version string) string {
+	return filepath.Join(DefaultInstallPrefix, program, version)
+}
+
+// ReadSavedInstallPrefix returns the install prefix saved by an earlier install, or "" if none was saved
+func ReadSavedInstallPrefix() (string, error) {
+	contents, err := os.ReadFile(savedInstallPrefixPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("issue reading %s: %w", savedInstallPrefixPath, err)
+	}
+	return strings.TrimSpace(string(contents)), nil
+}
+
+// SaveInstallPrefix records a custom install prefix after something is installed under it, so later runs without the flag
+// or environment variable use it too. Nothing is saved for the default prefix
+func SaveInstallPrefix() error {
+	if IsDefaultInstallPrefix() {
+		return nil
+	}
+	saved, err := ReadSavedInstallPrefix()
+	if err != nil || saved == installPrefix {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(savedInstallPrefixPath), 0755)
+	if err != nil {
+		return fmt.Errorf("issue creating %s: %w", filepath.Dir(savedInstallPrefixPath), err)
+	}
+	err = os.WriteFile(savedInstallPrefixPath, []byte(installPrefix+"\n"), 0644)
+	if err != nil {
+		return fmt.Errorf("issue saving the install prefix to %s: %w", savedInstallPrefixPath, err)
+	}
+	return nil
+}
+
+// RInstallRoots returns the directories R versions are installed in: /opt/R, where Posit's R packages always install,
+// followed by the R directory of the install prefix when it differs, where R built from source is installed
+func RInstallRoots() []string {
+	roots := []string{DefaultInstallRoot("R")}
+	if !IsDefaultInstallPrefix() {
+		roots = append(roots, InstallRoot("R"))
+	}
+	return roots
+}
+
+// RInstallDir returns the directory an R version is installed in, checking /opt/R before the install prefix.
+// The /opt/R directory is returned if the version isn't installed
+func RInstallDir(version string) string {
+	for _, root := range RInstallRoots() {
+		dir := filepath.Join(root, version)
+		if _, err := os.Stat(filepath.Join(dir, "bin", "R")); err == nil {
+			return dir
+		}
+	}
+	return DefaultInstallDir("R", version)
+}
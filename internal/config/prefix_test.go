@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveInstallPrefix(t *testing.T) {
+	savedInstallPrefixPath = filepath.Join(t.TempDir(), "wbi", "install-prefix")
+	defer func() { savedInstallPrefixPath = "/etc/wbi/install-prefix" }()
+	defer SetInstallPrefix("")
+
+	// nothing is saved for the default prefix
+	assert.NoError(t, SaveInstallPrefix())
+	saved, err := ReadSavedInstallPrefix()
+	assert.NoError(t, err)
+	assert.Equal(t, "", saved)
+
+	assert.NoError(t, SetInstallPrefix("/apps/posit/"))
+	assert.NoError(t, SaveInstallPrefix())
+	saved, err = ReadSavedInstallPrefix()
+	assert.NoError(t, err)
+	assert.Equal(t, "/apps/posit", saved)
+}
+
+func TestRInstallDir(t *testing.T) {
+	prefix := t.TempDir()
+	assert.NoError(t, SetInstallPrefix(prefix))
+	defer SetInstallPrefix("")
+
+	assert.Equal(t, []string{"/opt/R", filepath.Join(prefix, "R")}, RInstallRoots())
+	assert.Equal(t, "/opt/python", DefaultInstallRoot("python"))
+
+	// a version built from source into the prefix is found there, anything else is expected in /opt/R
+	sourceBuild := filepath.Join(prefix, "R", "4.3.2")
+	if err := os.MkdirAll(filepath.Join(sourceBuild, "bin"), 0755); err != nil {
+		t.Fatalf("issue creating %s: %v", sourceBuild, err)
+	}
+	if err := os.WriteFile(filepath.Join(sourceBuild, "bin", "R"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("issue writing the R binary: %v", err)
+	}
+	assert.Equal(t, sourceBuild, RInstallDir("4.3.2"))
+	assert.Equal(t, "/opt/R/4.2.3", RInstallDir("4.2.3"))
+
+	assert.NoError(t, SetInstallPrefix(""))
+	assert.Equal(t, []string{"/opt/R"}, RInstallRoots())
+}
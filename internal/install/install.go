@@ -9,9 +9,34 @@ import (
 	"github.com/sol-eng/wbi/internal/system"
 )
 
+// languageTitle returns the language as it is written in messages, such as R or Python
+func languageTitle(language string) string {
+	if language == "" {
+		return language
+	}
+	return strings.ToUpper(language[:1]) + strings.ToLower(language[1:])
+}
+
+// NoteLanguagePrefix tells the user where R or Python is installed when a custom install prefix is configured, since
+// Posit's R and Python packages always install into /opt and cannot be relocated
+func NoteLanguagePrefix(language string) {
+	if config.IsDefaultInstallPrefix() {
+		return
+	}
+	languageTitleCase := languageTitle(language)
+	// R is installed in /opt/R and Python in /opt/python
+	program := strings.ToLower(language)
+	if program == "r" {
+		program = "R"
+	}
+	system.PrintAndLogInfo("Posit's " + languageTitleCase + " packages cannot be relocated, so " + languageTitleCase + " is installed into " + config.DefaultInstallRoot(program) + " instead of " + config.InstallPrefix())
+}
+
 // Installs R/Python in a certain way based on the operating system
 func InstallLanguage(language string, filepath string, osType config.OperatingSystem, version string) error {
-	languageTitleCase := strings.Title(language)
+	languageTitleCase := languageTitle(language)
+
+	NoteLanguagePrefix(language)
 
 	installCommand, err := RetrieveInstallCommand(filepath, osType)
 	if err != nil {
@@ -32,9 +57,9 @@ func InstallLanguage(language string, filepath string, osType config.OperatingSy
 func RetrieveInstallCommand(filepath string, osType config.OperatingSystem) (string, error) {
 	switch osType {
 	case config.Ubuntu22, config.Ubuntu20:
-		return "DEBIAN_FRONTEND=noninteractive gdebi -n " + filepath, nil
+		return "DEBIAN_FRONTEND=noninteractive gdebi -n " + system.ShellQuote(filepath), nil
 	case config.Redhat7, config.Redhat8, config.Redhat9:
-		return "yum install -y " + filepath, nil
+		return "yum install -y " + system.ShellQuote(filepath), nil
 	default:
 		return "", errors.New("operating system not supported")
 	}
@@ -0,0 +1,103 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/sol-eng/wbi/internal/config"
+)
+
+// PackageMetadata is the name and version recorded in a .deb or .rpm package
+type PackageMetadata struct {
+	Name    string
+	Version string
+}
+
+var languagePackageName = regexp.MustCompile(`^(?i)(r|python)-(\d+\.\d+\.\d+)$`)
+
+// ReadPackageMetadata reads the name and version of a local .deb package with dpkg-deb or a local .rpm package with rpm
+func ReadPackageMetadata(packagePath string) (PackageMetadata, error) {
+	var queryCommand *exec.Cmd
+	switch strings.ToLower(filepath.Ext(packagePath)) {
+	case ".deb":
+		queryCommand = exec.Command("dpkg-deb", "--show", "--showformat=${Package}\t${Version}", packagePath)
+	case ".rpm":
+		queryCommand = exec.Command("rpm", "-qp", "--queryformat", "%{NAME}\t%{VERSION}", packagePath)
+	default:
+		return PackageMetadata{}, errors.New(packagePath + " is not a .deb or .rpm package")
+	}
+
+	output, err := queryCommand.Output()
+	if err != nil {
+		return PackageMetadata{}, fmt.Errorf("issue reading the package metadata of %s with %s: %w", packagePath, queryCommand.Path, err)
+	}
+	name, packageVersion, found := strings.Cut(strings.TrimSpace(string(output)), "\t")
+	if !found || name == "" {
+		return PackageMetadata{}, errors.New("the package metadata of " + packagePath + " does not include a name and version")
+	}
+	return PackageMetadata{Name: name, Version: packageVersion}, nil
+}
+
+// LanguageVersionFromPackage returns the R or Python version of a package built by Posit, which are named after the version
+// they install such as r-4.3.2 or python-3.11.6
+func LanguageVersionFromPackage(language string, metadata PackageMetadata) (string, error) {
+	match := languagePackageName.FindStringSubmatch(metadata.Name)
+	if match == nil || !strings.EqualFold(match[1], language) {
+		return "", errors.New("the package " + metadata.Name + " is not a Posit " + languageTitle(language) + " build")
+	}
+	if _, err := version.NewVersion(match[2]); err != nil {
+		return "", fmt.Errorf("issue parsing the version of the package %s: %w", metadata.Name, err)
+	}
+	return match[2], nil
+}
+
+// VerifyPackageFormat returns an error if the package format does not match the package manager of the operating system
+func VerifyPackageFormat(packagePath string, osType config.OperatingSystem) error {
+	extension := strings.ToLower(filepath.Ext(packagePath))
+	switch osType {
+	case config.Ubuntu22, config.Ubuntu20:
+		if extension != ".deb" {
+			return errors.New(packagePath + " is not a .deb package, which is required on Ubuntu")
+		}
+	case config.Redhat7, config.Redhat8, config.Redhat9:
+		if extension != ".rpm" {
+			return errors.New(packagePath + " is not an .rpm package, which is required on RHEL")
+		}
+	default:
+		return errors.New("operating system not supported")
+	}
+	return nil
+}
+
+// InstallLanguageFromFile installs R or Python from a local package after reading its version from the package metadata,
+// and returns the version
+func InstallLanguageFromFile(language string, packagePath string, osType config.OperatingSystem) (string, error) {
+	err := VerifyPackageFormat(packagePath, osType)
+	if err != nil {
+		return "", err
+	}
+	metadata, err := ReadPackageMetadata(packagePath)
+	if err != nil {
+		return "", err
+	}
+	languageVersion, err := LanguageVersionFromPackage(language, metadata)
+	if err != nil {
+		return "", err
+	}
+
+	// gdebi and yum need a path to treat the argument as a file rather than a package name
+	packagePath, err = filepath.Abs(packagePath)
+	if err != nil {
+		return "", fmt.Errorf("issue finding %s: %w", packagePath, err)
+	}
+	err = InstallLanguage(language, packagePath, osType, languageVersion)
+	if err != nil {
+		return "", err
+	}
+	return languageVersion, nil
+}
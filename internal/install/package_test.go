@@ -0,0 +1,142 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageVersionFromPackage(t *testing.T) {
+	tests := map[string]struct {
+		language    string
+		metadata    PackageMetadata
+		expected    string
+		expectError string
+	}{
+		"R package": {
+			language: "r",
+			metadata: PackageMetadata{Name: "r-4.3.2", Version: "1-1"},
+			expected: "4.3.2",
+		},
+		"Python package with an uppercase language": {
+			language: "Python",
+			metadata: PackageMetadata{Name: "python-3.11.6", Version: "1"},
+			expected: "3.11.6",
+		},
+		"package for the other language fails": {
+			language:    "r",
+			metadata:    PackageMetadata{Name: "python-3.11.6", Version: "1"},
+			expectError: "the package python-3.11.6 is not a Posit R build",
+		},
+		"distribution package fails": {
+			language:    "python",
+			metadata:    PackageMetadata{Name: "python3", Version: "3.10.12"},
+			expectError: "the package python3 is not a Posit Python build",
+		},
+		"package without a patch version fails": {
+			language:    "r",
+			metadata:    PackageMetadata{Name: "r-4.3", Version: "1"},
+			expectError: "the package r-4.3 is not a Posit R build",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, err := LanguageVersionFromPackage(tc.language, tc.metadata)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, version)
+		})
+	}
+}
+
+func TestVerifyPackageFormat(t *testing.T) {
+	tests := map[string]struct {
+		packagePath string
+		osType      config.OperatingSystem
+		expectError string
+	}{
+		"deb on Ubuntu": {
+			packagePath: "r-4.3.2_1_amd64.deb",
+			osType:      config.Ubuntu22,
+		},
+		"uppercase rpm on RHEL": {
+			packagePath: "R-4.3.2-1-1.x86_64.RPM",
+			osType:      config.Redhat9,
+		},
+		"rpm on Ubuntu fails": {
+			packagePath: "R-4.3.2-1-1.x86_64.rpm",
+			osType:      config.Ubuntu20,
+			expectError: "R-4.3.2-1-1.x86_64.rpm is not a .deb package, which is required on Ubuntu",
+		},
+		"deb on RHEL fails": {
+			packagePath: "r-4.3.2_1_amd64.deb",
+			osType:      config.Redhat8,
+			expectError: "r-4.3.2_1_amd64.deb is not an .rpm package, which is required on RHEL",
+		},
+		"unsupported operating system fails": {
+			packagePath: "r-4.3.2_1_amd64.deb",
+			osType:      config.Unknown,
+			expectError: "operating system not supported",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyPackageFormat(tc.packagePath, tc.osType)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRetrieveInstallCommand(t *testing.T) {
+	tests := map[string]struct {
+		filepath    string
+		osType      config.OperatingSystem
+		expected    string
+		expectError string
+	}{
+		"Ubuntu quotes the package path": {
+			filepath: "/tmp/my packages/r-4.3.2_1_amd64.deb",
+			osType:   config.Ubuntu22,
+			expected: "DEBIAN_FRONTEND=noninteractive gdebi -n '/tmp/my packages/r-4.3.2_1_amd64.deb'",
+		},
+		"RHEL quotes a path with shell syntax": {
+			filepath: "/tmp/$(reboot)'.rpm",
+			osType:   config.Redhat9,
+			expected: `yum install -y '/tmp/$(reboot)'\''.rpm'`,
+		},
+		"unsupported operating system fails": {
+			filepath:    "/tmp/r-4.3.2_1_amd64.deb",
+			osType:      config.Unknown,
+			expectError: "operating system not supported",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			command, err := RetrieveInstallCommand(tc.filepath, tc.osType)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, command)
+		})
+	}
+}
+
+func TestLanguageTitle(t *testing.T) {
+	assert.Equal(t, "R", languageTitle("r"))
+	assert.Equal(t, "Python", languageTitle("python"))
+	assert.Equal(t, "Python", languageTitle("PYTHON"))
+	assert.Equal(t, "", languageTitle(""))
+}
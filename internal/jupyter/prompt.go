@@ -3,10 +3,11 @@ package jupyter
 import (
 	"errors"
 	"fmt"
-	"regexp"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/config"
 	"github.com/sol-eng/wbi/internal/languages"
 	"github.com/sol-eng/wbi/internal/workbench"
 )
@@ -143,8 +144,7 @@ func ScanPromptInstallAndConfigJupyter() error {
 func removeNonOptPython(pythonPaths []string) []string {
 	anyOptLocations := []string{}
 	for _, value := range pythonPaths {
-		matched, err := regexp.MatchString(".*/opt.*", value)
-		if err == nil && matched {
+		if strings.HasPrefix(value, config.DefaultInstallRoot("python")+"/") {
 			anyOptLocations = append(anyOptLocations, value)
 		}
 	}
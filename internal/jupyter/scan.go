@@ -0,0 +1,119 @@
+package jupyter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// the locations ipykernel registers kernels in when run as root
+var kernelDirs = []string{
+	"/usr/local/share/jupyter/kernels",
+	"/usr/share/jupyter/kernels",
+}
+
+// Kernel is a Jupyter kernel registered on the server
+type Kernel struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Path        string `json:"path"`
+	Executable  string `json:"executable"`
+}
+
+// Inventory describes the Jupyter installation Workbench uses
+type Inventory struct {
+	JupyterExe string            `json:"jupyter_exe"`
+	Components map[string]string `json:"components"`
+	Kernels    []Kernel          `json:"kernels"`
+}
+
+type kernelSpec struct {
+	Argv        []string `json:"argv"`
+	DisplayName string   `json:"display_name"`
+}
+
+var componentVersionLine = regexp.MustCompile(`^\s*([\w-]+)\s*:\s*(\S+)\s*$`)
+
+// scanTimeout is how long jupyter --version is given to report the component versions
+const scanTimeout = 30 * time.Second
+
+// ConfiguredJupyterExe returns the jupyter-exe set in the Workbench Jupyter config, or "" if it isn't set
+func ConfiguredJupyterExe() string {
+	contents, err := os.ReadFile("/etc/rstudio/jupyter.conf")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "jupyter-exe=") {
+			return strings.TrimPrefix(line, "jupyter-exe=")
+		}
+	}
+	return ""
+}
+
+// RegisteredKernels returns the Jupyter kernels registered in the system wide kernel directories
+func RegisteredKernels() []Kernel {
+	kernels := []Kernel{}
+	for _, kernelDir := range kernelDirs {
+		entries, err := os.ReadDir(kernelDir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			kernelPath := filepath.Join(kernelDir, entry.Name())
+			contents, err := os.ReadFile(filepath.Join(kernelPath, "kernel.json"))
+			if err != nil {
+				continue
+			}
+			var spec kernelSpec
+			if json.Unmarshal(contents, &spec) != nil || len(spec.Argv) == 0 {
+				continue
+			}
+			kernels = append(kernels, Kernel{
+				Name:        entry.Name(),
+				DisplayName: spec.DisplayName,
+				Path:        kernelPath,
+				Executable:  spec.Argv[0],
+			})
+		}
+	}
+	return kernels
+}
+
+// componentVersions parses the output of jupyter --version, e.g. "jupyterlab       : 3.6.1"
+func componentVersions(versionOutput string) map[string]string {
+	components := map[string]string{}
+	for _, line := range strings.Split(versionOutput, "\n") {
+		if match := componentVersionLine.FindStringSubmatch(line); match != nil {
+			components[match[1]] = match[2]
+		}
+	}
+	return components
+}
+
+// ScanJupyter reports the jupyter-exe Workbench uses, the installed Jupyter components and the registered kernels
+func ScanJupyter() Inventory {
+	inventory := Inventory{
+		JupyterExe: ConfiguredJupyterExe(),
+		Components: map[string]string{},
+		Kernels:    RegisteredKernels(),
+	}
+	sort.Slice(inventory.Kernels, func(i, j int) bool {
+		return inventory.Kernels[i].Name < inventory.Kernels[j].Name
+	})
+
+	if inventory.JupyterExe != "" && system.VerifyFileExists(inventory.JupyterExe) {
+		versionOutput, err := system.RunCommandWithTimeout(scanTimeout, inventory.JupyterExe, "--version")
+		if err == nil {
+			inventory.Components = componentVersions(versionOutput)
+		}
+	}
+	return inventory
+}
@@ -0,0 +1,46 @@
+package jupyter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentVersions(t *testing.T) {
+	tests := map[string]struct {
+		versionOutput string
+		expected      map[string]string
+	}{
+		"jupyter --version output": {
+			versionOutput: `Selected Jupyter core packages...
+IPython          : 8.12.0
+ipykernel        : 6.22.0
+jupyter_client   : 8.1.0
+jupyterlab       : 3.6.1
+nbconvert        : not installed
+notebook         : 6.5.4
+`,
+			expected: map[string]string{
+				"IPython":        "8.12.0",
+				"ipykernel":      "6.22.0",
+				"jupyter_client": "8.1.0",
+				"jupyterlab":     "3.6.1",
+				"notebook":       "6.5.4",
+			},
+		},
+		"lines without a version are skipped": {
+			versionOutput: "Selected Jupyter core packages...\nnbconvert        : not installed\n\n",
+			expected:      map[string]string{},
+		},
+		"empty output": {
+			versionOutput: "",
+			expected:      map[string]string{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, componentVersions(tc.versionOutput))
+		})
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"time"
 
@@ -41,9 +40,9 @@ var rootRDirs = []string{
 	"/usr/local/lib/R",
 }
 
-// GetRRootDirs returns the root directories for R
+// GetRRootDirs returns the root directories for R, starting with the R directory of the install prefix
 func GetRRootDirs() []string {
-	return rootRDirs
+	return withInstallRoot("R", rootRDirs)
 }
 
 // GetRPaths returns the paths workbench will look for R
@@ -69,7 +68,7 @@ func PromptAndInstallR(osType config.OperatingSystem) ([]string, error) {
 		return []string{}, fmt.Errorf("issue selecting R installation: %w", err)
 	}
 	if installRChoice {
-		validRVersions, err := RetrieveValidRVersions()
+		validRVersions, err := RetrieveValidRVersions(osType)
 		if err != nil {
 			return []string{}, fmt.Errorf("issue retrieving R versions: %w", err)
 		}
@@ -121,13 +120,15 @@ func ScanAndHandleRVersions(osType config.OperatingSystem) error {
 	} else {
 		anyOptLocations := []string{}
 		for _, value := range rVersionsOrig {
-			matched, err := regexp.MatchString(".*/opt.*", value)
-			if err == nil && matched {
+			matched := lo.SomeBy(config.RInstallRoots(), func(rRoot string) bool {
+				return strings.HasPrefix(value, rRoot+"/")
+			})
+			if matched {
 				anyOptLocations = append(anyOptLocations, value)
 			}
 		}
 		if len(anyOptLocations) == 0 {
-			system.PrintAndLogInfo("Posit recommends installing version of R into the /opt directory to not conflict/rely on the system installed version of R.")
+			system.PrintAndLogInfo("Posit recommends installing version of R into the " + config.DefaultInstallRoot("R") + " directory to not conflict/rely on the system installed version of R.")
 		}
 		installedRVersion, err := PromptAndInstallR(osType)
 		if err != nil {
@@ -167,9 +168,8 @@ func AppendIfMissing(slice []string, s string) []string {
 func ScanForRVersions() ([]string, error) {
 	foundVersions := []string{}
 	foundOptVersions := []string{}
-	// This is somewhat naive with respect to actually checking whether
-	// this is _really_ a working version of R by launching it,
-	// vs just matches the path to R
+	// This only matches the path to R, use InspectRInstallations
+	// to launch each version and check that it really works
 	for _, rPath := range GetRPaths() {
 		if _, err := os.Stat(rPath); err == nil {
 			foundVersions = append(foundVersions, rPath)
@@ -185,7 +185,7 @@ func ScanForRVersions() ([]string, error) {
 			if entry.IsDir() {
 				rpath, isR := isRDir(filepath.Join(rootPath, entry.Name()))
 				if isR {
-					if rootPath == "/opt/R" {
+					if lo.Contains(config.RInstallRoots(), rootPath) {
 						foundOptVersions = append(foundOptVersions, rpath)
 					} else {
 						foundVersions = append(foundVersions, rpath)
@@ -196,15 +196,29 @@ func ScanForRVersions() ([]string, error) {
 
 	}
 
+	// include custom installs registered with Workbench in /etc/rstudio/r-versions
+	rVersionEntries, err := ReadRVersionsFile()
+	if err != nil {
+		return foundVersions, err
+	}
+	for _, entry := range rVersionEntries {
+		inRInstallRoot := lo.SomeBy(config.RInstallRoots(), func(rRoot string) bool {
+			return strings.HasPrefix(entry.Path, rRoot+"/")
+		})
+		if system.VerifyFileExists(entry.RBinary()) && !inRInstallRoot {
+			foundVersions = AppendIfMissing(foundVersions, entry.RBinary())
+		}
+	}
+
 	maybeR, err := exec.LookPath("R")
 	if err == nil {
 		foundVersions = AppendIfMissing(foundVersions, maybeR)
 	}
 
-	// sort /opt/R versions
+	// sort the versions in /opt/R and those built from source into the install prefix
 	foundOptVersionsSortedPaths, err := sortOptRVersionPaths(foundOptVersions)
 	if err != nil {
-		return []string{}, fmt.Errorf("issue sorting /opt/R versions: %w", err)
+		return []string{}, fmt.Errorf("issue sorting %s versions: %w", strings.Join(config.RInstallRoots(), " and "), err)
 	}
 
 	finalVersionPathSorted := append(foundOptVersionsSortedPaths, foundVersions...)
@@ -213,10 +227,15 @@ func ScanForRVersions() ([]string, error) {
 
 func sortOptRVersionPaths(versionPaths []string) ([]string, error) {
 	foundOptVersionsOnly := []string{}
+	// the same version can be in both /opt/R and the install prefix
+	versionPathsByVersion := map[string][]string{}
 	for _, optVersion := range versionPaths {
-		i := strings.Index(optVersion, "R")
-		j := strings.Index(optVersion, "bin")
-		foundOptVersionsOnly = append(foundOptVersionsOnly, optVersion[i+2:j-1])
+		// paths have the format {root}/{version}/bin/R
+		version := filepath.Base(filepath.Dir(filepath.Dir(optVersion)))
+		if _, ok := versionPathsByVersion[version]; !ok {
+			foundOptVersionsOnly = append(foundOptVersionsOnly, version)
+		}
+		versionPathsByVersion[version] = append(versionPathsByVersion[version], optVersion)
 	}
 	versions, err := ConvertStringSliceToVersionSlice(foundOptVersionsOnly)
 	if err != nil {
@@ -226,7 +245,7 @@ func sortOptRVersionPaths(versionPaths []string) ([]string, error) {
 	foundOptVersionsSorted := ConvertVersionSliceToStringSlice(sortedVersions)
 	foundOptVersionsSortedPaths := []string{}
 	for _, optVersion := range foundOptVersionsSorted {
-		foundOptVersionsSortedPaths = append(foundOptVersionsSortedPaths, "/opt/R/"+optVersion+"/bin/R")
+		foundOptVersionsSortedPaths = append(foundOptVersionsSortedPaths, versionPathsByVersion[optVersion]...)
 	}
 	return foundOptVersionsSortedPaths, nil
 }
@@ -247,7 +266,7 @@ func RInstallPrompt() (bool, error) {
 	return name, nil
 }
 
-func RetrieveValidRVersions() ([]string, error) {
+func RetrieveValidRVersions(osType config.OperatingSystem) ([]string, error) {
 	rVersionURL := "https://cdn.posit.co/r/versions.json"
 
 	client := &http.Client{
@@ -282,6 +301,11 @@ func RetrieveValidRVersions() ([]string, error) {
 		return []string{}, fmt.Errorf("issue converting string slice to version slice: %w", err)
 	}
 
+	versions, err = FilterCompatibleVersions("r", versions, osType)
+	if err != nil {
+		return []string{}, fmt.Errorf("issue removing R versions unavailable on this operating system: %w", err)
+	}
+
 	sortedVersions := SortVersionsDesc(versions)
 	if err != nil {
 		return []string{}, errors.New("failed to sort versions")
@@ -345,6 +369,23 @@ func DownloadAndInstallR(rVersion string, osType config.OperatingSystem) error {
 	return nil
 }
 
+// InstallRFromFile installs R from a local .deb or .rpm package, such as one pre-scanned by a security team, and returns
+// the version read from the package
+func InstallRFromFile(packagePath string, osType config.OperatingSystem) (string, error) {
+	system.PrintAndLogInfo("Installing R from the local package " + packagePath)
+	rVersion, err := install.InstallLanguageFromFile("r", packagePath, osType)
+	if err != nil {
+		return "", fmt.Errorf("InstallLanguageFromFile: %w", err)
+	}
+	// save to command log
+	installCommand, err := install.RetrieveInstallCommand(packagePath, osType)
+	if err != nil {
+		return "", fmt.Errorf("RetrieveInstallCommand: %w", err)
+	}
+	cmdlog.Info(installCommand)
+	return rVersion, nil
+}
+
 // PromptAndSetRSymlinks prompts user to set R symlinks
 func PromptAndSetRSymlinks(rPaths []string) error {
 	setRSymlinkChoice, err := RSymlinkPrompt()
@@ -461,13 +502,13 @@ func CheckAndSetRSymlinks(rPath string) error {
 			return fmt.Errorf("issue setting R symlinks: %w", err)
 		}
 	} else {
-		system.PrintAndLogInfo("R and Rscript symlinks already exist, skipping symlink creation")
+		system.PrintAndLogInfo("R and Rscript symlinks already exist, skipping symlink creation. Use \"wbi default r [version]\" to change the default R version.")
 	}
 	return nil
 }
 
 func CheckPromtAndSetRSymlinks(rPaths []string) error {
-	// remove any path that starts with /usr and only offer symlinks for those that don't (i.e. /opt directories)
+	// remove any path that starts with /usr and only offer symlinks for those that don't (i.e. install prefix directories)
 	rPathsFiltered := RemoveSystemRPaths(rPaths)
 	// check if R and Rscript has already been symlinked
 	rSymlinked := CheckIfRSymlinkExists()
@@ -481,8 +522,22 @@ func CheckPromtAndSetRSymlinks(rPaths []string) error {
 	return nil
 }
 
-func ValidateRVersions(rVersions []string) error {
-	availRVersions, err := RetrieveValidRVersions()
+// ResolveRVersions resolves R version values such as 4.3, latest, latest-3 or >=4.1,<4.3 to exact versions
+func ResolveRVersions(rVersions []string, osType config.OperatingSystem) ([]string, error) {
+	availRVersions, err := RetrieveValidRVersions(osType)
+	if err != nil {
+		return []string{}, fmt.Errorf("error retrieving valid R versions: %w", err)
+	}
+	resolutions, err := ResolveVersions("R", rVersions, availRVersions)
+	if err != nil {
+		return []string{}, err
+	}
+	PrintVersionResolutions("R", resolutions)
+	return ResolvedVersions(resolutions), nil
+}
+
+func ValidateRVersions(rVersions []string, osType config.OperatingSystem) error {
+	availRVersions, err := RetrieveValidRVersions(osType)
 	if err != nil {
 		return fmt.Errorf("error retrieving valid R versions: %w", err)
 	}
@@ -0,0 +1,157 @@
+package languages
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// CompatibilityOverridePath is the file that overrides the embedded compatibility matrix when present
+const CompatibilityOverridePath = "/etc/wbi/compatibility.yaml"
+
+//go:embed compatibility.yaml
+var defaultCompatibilityMatrix []byte
+
+// CompatibilityRule excludes the versions matching Constraint on the listed operating systems and architectures
+type CompatibilityRule struct {
+	OS         []string `yaml:"os"`
+	Arch       []string `yaml:"arch"`
+	Constraint string   `yaml:"constraint"`
+	Reason     string   `yaml:"reason"`
+}
+
+// CompatibilityMatrix holds the exclusion rules for each language
+type CompatibilityMatrix map[string][]CompatibilityRule
+
+// osKey converts the operating system to the name used in the compatibility matrix
+func osKey(osType config.OperatingSystem) string {
+	switch osType {
+	case config.Ubuntu20:
+		return "ubuntu20"
+	case config.Ubuntu22:
+		return "ubuntu22"
+	case config.Redhat7:
+		return "redhat7"
+	case config.Redhat8:
+		return "redhat8"
+	case config.Redhat9:
+		return "redhat9"
+	default:
+		return "unknown"
+	}
+}
+
+// compatibilityOSNames and compatibilityArchNames are the values rules can be limited to
+var compatibilityOSNames = []string{"ubuntu20", "ubuntu22", "redhat7", "redhat8", "redhat9"}
+var compatibilityArchNames = []string{"amd64", "arm64"}
+
+func parseCompatibilityMatrix(data []byte) (CompatibilityMatrix, error) {
+	var matrix CompatibilityMatrix
+	err := yaml.Unmarshal(data, &matrix)
+	if err != nil {
+		return nil, fmt.Errorf("issue parsing the compatibility matrix: %w", err)
+	}
+	// ensure every constraint can be parsed up front so a bad override file fails clearly
+	for language, rules := range matrix {
+		for _, rule := range rules {
+			if rule.Constraint == "" {
+				return nil, errors.New("a " + language + " rule in the compatibility matrix is missing a constraint")
+			}
+			_, err := version.NewConstraint(rule.Constraint)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s constraint %s in the compatibility matrix: %w", language, rule.Constraint, err)
+			}
+			// a misspelled os or arch would make the rule silently never apply
+			for _, osName := range rule.OS {
+				if !lo.Contains(compatibilityOSNames, osName) {
+					return nil, fmt.Errorf("invalid os %s in a %s rule of the compatibility matrix, valid values are %s", osName, language, strings.Join(compatibilityOSNames, ", "))
+				}
+			}
+			for _, arch := range rule.Arch {
+				if !lo.Contains(compatibilityArchNames, arch) {
+					return nil, fmt.Errorf("invalid arch %s in a %s rule of the compatibility matrix, valid values are %s", arch, language, strings.Join(compatibilityArchNames, ", "))
+				}
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// LoadCompatibilityMatrix loads the embedded compatibility matrix and applies the override file if it exists
+func LoadCompatibilityMatrix() (CompatibilityMatrix, error) {
+	matrix, err := parseCompatibilityMatrix(defaultCompatibilityMatrix)
+	if err != nil {
+		return nil, fmt.Errorf("issue loading the embedded compatibility matrix: %w", err)
+	}
+
+	overrideData, err := os.ReadFile(CompatibilityOverridePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return matrix, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("issue reading %s: %w", CompatibilityOverridePath, err)
+	}
+	override, err := parseCompatibilityMatrix(overrideData)
+	if err != nil {
+		return nil, fmt.Errorf("issue loading %s: %w", CompatibilityOverridePath, err)
+	}
+	log.Info("Using the compatibility rules in " + CompatibilityOverridePath)
+	for language, rules := range override {
+		matrix[language] = rules
+	}
+	return matrix, nil
+}
+
+// applies returns true if the rule applies to the operating system and architecture
+func (rule CompatibilityRule) applies(osName string, arch string) bool {
+	if len(rule.OS) != 0 && !lo.Contains(rule.OS, osName) {
+		return false
+	}
+	if len(rule.Arch) != 0 && !lo.Contains(rule.Arch, arch) {
+		return false
+	}
+	return true
+}
+
+// FilterVersions removes the versions excluded for a language on the operating system and architecture
+func (matrix CompatibilityMatrix) FilterVersions(language string, versions []*version.Version, osType config.OperatingSystem, arch string) ([]*version.Version, error) {
+	var constraints []version.Constraints
+	for _, rule := range matrix[language] {
+		if !rule.applies(osKey(osType), arch) {
+			continue
+		}
+		constraint, err := version.NewConstraint(rule.Constraint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s constraint %s in the compatibility matrix: %w", language, rule.Constraint, err)
+		}
+		constraints = append(constraints, constraint)
+	}
+
+	var result []*version.Version
+	for _, v := range versions {
+		excluded := lo.ContainsBy(constraints, func(constraint version.Constraints) bool {
+			return constraint.Check(v)
+		})
+		if !excluded {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// FilterCompatibleVersions removes the versions of a language that cannot be installed on this operating system and architecture
+func FilterCompatibleVersions(language string, versions []*version.Version, osType config.OperatingSystem) ([]*version.Version, error) {
+	matrix, err := LoadCompatibilityMatrix()
+	if err != nil {
+		return nil, err
+	}
+	return matrix.FilterVersions(language, versions, osType, runtime.GOARCH)
+}
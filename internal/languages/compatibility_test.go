@@ -0,0 +1,148 @@
+package languages
+
+import (
+	"testing"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCompatibilityMatrix = `
+python:
+  - constraint: "~> 3.12.0"
+    reason: not yet supported
+  - os: [redhat7]
+    constraint: ">= 3.8.16, < 3.9"
+    reason: not built for RHEL 7
+  - os: [redhat9]
+    arch: [arm64]
+    constraint: "= 3.7.3"
+    reason: not built for RHEL 9 on arm64
+quarto: []
+`
+
+func TestParseCompatibilityMatrix(t *testing.T) {
+	tests := map[string]struct {
+		data        string
+		expectRules map[string]int
+		expectError string
+	}{
+		"valid matrix": {
+			data:        testCompatibilityMatrix,
+			expectRules: map[string]int{"python": 3, "quarto": 0},
+		},
+		"malformed YAML fails": {
+			data:        "python:\n  - constraint: [\n",
+			expectError: "issue parsing the compatibility matrix",
+		},
+		"language that is not a list of rules fails": {
+			data:        "python: not-a-list\n",
+			expectError: "issue parsing the compatibility matrix",
+		},
+		"rule without a constraint fails": {
+			data:        "r:\n  - os: [ubuntu22]\n    reason: missing\n",
+			expectError: "a r rule in the compatibility matrix is missing a constraint",
+		},
+		"invalid constraint fails": {
+			data:        "r:\n  - constraint: \">= four\"\n",
+			expectError: "invalid r constraint >= four in the compatibility matrix",
+		},
+		"misspelled os fails": {
+			data:        "r:\n  - os: [ubuntu-22]\n    constraint: \"< 4.0\"\n",
+			expectError: "invalid os ubuntu-22 in a r rule of the compatibility matrix",
+		},
+		"unknown arch fails": {
+			data:        "r:\n  - arch: [x86_64]\n    constraint: \"< 4.0\"\n",
+			expectError: "invalid arch x86_64 in a r rule of the compatibility matrix",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			matrix, err := parseCompatibilityMatrix([]byte(tc.data))
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			for language, count := range tc.expectRules {
+				assert.Len(t, matrix[language], count, language)
+			}
+		})
+	}
+}
+
+func TestEmbeddedCompatibilityMatrixParses(t *testing.T) {
+	_, err := parseCompatibilityMatrix(defaultCompatibilityMatrix)
+	assert.NoError(t, err)
+}
+
+func TestFilterVersions(t *testing.T) {
+	matrix, err := parseCompatibilityMatrix([]byte(testCompatibilityMatrix))
+	if err != nil {
+		t.Fatalf("issue parsing the test matrix: %v", err)
+	}
+	available := []string{"3.7.3", "3.8.15", "3.8.16", "3.8.17", "3.9.0", "3.11.9", "3.12.0", "3.12.4", "3.13.0"}
+
+	tests := map[string]struct {
+		language string
+		osType   config.OperatingSystem
+		arch     string
+		expected []string
+	}{
+		"rules without an os apply everywhere and ~> excludes the whole minor line": {
+			language: "python",
+			osType:   config.Ubuntu22,
+			arch:     "amd64",
+			expected: []string{"3.7.3", "3.8.15", "3.8.16", "3.8.17", "3.9.0", "3.11.9", "3.13.0"},
+		},
+		"range boundaries are inclusive of the lower bound and exclusive of the upper bound": {
+			language: "python",
+			osType:   config.Redhat7,
+			arch:     "amd64",
+			expected: []string{"3.7.3", "3.8.15", "3.9.0", "3.11.9", "3.13.0"},
+		},
+		"rule limited to an arch only applies on that arch": {
+			language: "python",
+			osType:   config.Redhat9,
+			arch:     "amd64",
+			expected: []string{"3.7.3", "3.8.15", "3.8.16", "3.8.17", "3.9.0", "3.11.9", "3.13.0"},
+		},
+		"rule limited to an os and arch applies on both": {
+			language: "python",
+			osType:   config.Redhat9,
+			arch:     "arm64",
+			expected: []string{"3.8.15", "3.8.16", "3.8.17", "3.9.0", "3.11.9", "3.13.0"},
+		},
+		"unknown os only applies rules without an os": {
+			language: "python",
+			osType:   config.Unknown,
+			arch:     "amd64",
+			expected: []string{"3.7.3", "3.8.15", "3.8.16", "3.8.17", "3.9.0", "3.11.9", "3.13.0"},
+		},
+		"language without rules keeps every version": {
+			language: "quarto",
+			osType:   config.Redhat7,
+			arch:     "amd64",
+			expected: available,
+		},
+		"language missing from the matrix keeps every version": {
+			language: "julia",
+			osType:   config.Ubuntu20,
+			arch:     "arm64",
+			expected: available,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			versions, err := ConvertStringSliceToVersionSlice(available)
+			if err != nil {
+				t.Fatalf("issue converting the versions: %v", err)
+			}
+			filtered, err := matrix.FilterVersions(tc.language, versions, tc.osType, tc.arch)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ConvertVersionSliceToStringSlice(filtered))
+		})
+	}
+}
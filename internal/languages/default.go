@@ -0,0 +1,119 @@
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+const pythonProfileDPath = "/etc/profile.d/wbi_python.sh"
+
+// CurrentDefaultR returns the R install /usr/local/bin/R points to, or "" if it doesn't exist
+func CurrentDefaultR() string {
+	target, err := filepath.EvalSymlinks("/usr/local/bin/R")
+	if err != nil {
+		return ""
+	}
+	return target
+}
+
+// CurrentDefaultPython returns the directory added to PATH in /etc/profile.d/wbi_python.sh, or "" if it doesn't exist
+func CurrentDefaultPython() string {
+	contents, err := os.ReadFile(pythonProfileDPath)
+	if err != nil {
+		return ""
+	}
+	// the last entry added to PATH is the one found first
+	current := ""
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "PATH=") {
+			current = strings.TrimSuffix(strings.TrimPrefix(line, "PATH="), ":$PATH")
+		}
+	}
+	return current
+}
+
+// InstalledVersionPath returns the path to a binary of a version in an install root, listing the installed versions if it isn't installed
+func InstalledVersionPath(language string, rootDir string, version string, binary string) (string, error) {
+	binaryPath := filepath.Join(rootDir, version, "bin", binary)
+	if system.VerifyFileExists(binaryPath) {
+		return binaryPath, nil
+	}
+	installed, err := ScanOptVersions(rootDir)
+	if err != nil {
+		return "", err
+	}
+	if len(installed) == 0 {
+		return "", errors.New(language + " " + version + " is not installed and no versions were found in " + rootDir)
+	}
+	return "", errors.New(language + " " + version + " is not installed, the versions in " + rootDir + " are: " + strings.Join(installed, ", "))
+}
+
+// RPathForVersion returns the path to the R binary of a version installed in /opt/R or built from source into the install prefix
+func RPathForVersion(version string) (string, error) {
+	rRoots := config.RInstallRoots()
+	if len(rRoots) == 1 {
+		return InstalledVersionPath("R", rRoots[0], version, "R")
+	}
+	rPath := filepath.Join(config.RInstallDir(version), "bin", "R")
+	if system.VerifyFileExists(rPath) {
+		return rPath, nil
+	}
+	var installed []string
+	for _, rRoot := range rRoots {
+		rVersions, err := ScanOptVersions(rRoot)
+		if err != nil {
+			return "", err
+		}
+		for _, rVersion := range rVersions {
+			installed = append(installed, filepath.Join(rRoot, rVersion))
+		}
+	}
+	if len(installed) == 0 {
+		return "", errors.New("R " + version + " is not installed and no versions were found in " + strings.Join(rRoots, " or "))
+	}
+	return "", errors.New("R " + version + " is not installed, the installed versions are: " + strings.Join(installed, ", "))
+}
+
+// PythonPathForVersion returns the path to the Python binary of a version installed in /opt/python
+func PythonPathForVersion(version string) (string, error) {
+	return InstalledVersionPath("Python", config.DefaultInstallRoot("python"), version, "python")
+}
+
+// SetDefaultR points /usr/local/bin/R and /usr/local/bin/Rscript at an installed R version, replacing any existing symlinks
+func SetDefaultR(version string) (string, error) {
+	rPath, err := RPathForVersion(version)
+	if err != nil {
+		return "", err
+	}
+	err = system.ReplaceSymlink(rPath, "/usr/local/bin/R")
+	if err != nil {
+		return "", fmt.Errorf("issue setting the R symlink: %w", err)
+	}
+	err = system.ReplaceSymlink(rPath+"script", "/usr/local/bin/Rscript")
+	if err != nil {
+		return "", fmt.Errorf("issue setting the Rscript symlink: %w", err)
+	}
+	system.PrintAndLogInfo("\n/usr/local/bin/R and /usr/local/bin/Rscript now point to R " + version)
+	return rPath, nil
+}
+
+// SetDefaultPython rewrites /etc/profile.d/wbi_python.sh so an installed Python version is first on PATH
+func SetDefaultPython(version string) (string, error) {
+	pythonPath, err := PythonPathForVersion(version)
+	if err != nil {
+		return "", err
+	}
+	err = system.ReplacePATH(filepath.Dir(pythonPath), "python")
+	if err != nil {
+		return "", fmt.Errorf("issue setting the Python PATH: %w", err)
+	}
+	system.PrintAndLogInfo("\n" + pythonProfileDPath + " now adds Python " + version + " to PATH. Users must log in again to pick up the change.")
+	return pythonPath, nil
+}
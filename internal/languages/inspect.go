@@ -0,0 +1,237 @@
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// inspectTimeout is how long a candidate binary is given to report its version
+const inspectTimeout = 15 * time.Second
+
+// Installation describes an R or Python binary found while scanning
+type Installation struct {
+	Path    string `json:"path"`
+	Target  string `json:"target"`
+	Version string `json:"version"`
+	Arch    string `json:"arch"`
+	Origin  string `json:"origin"`
+	Problem string `json:"problem,omitempty"`
+}
+
+// Broken returns true if the installation could not be run successfully
+func (i Installation) Broken() bool {
+	return i.Problem != ""
+}
+
+// Status returns a short description of whether the installation works
+func (i Installation) Status() string {
+	if i.Broken() {
+		return "broken: " + i.Problem
+	}
+	return "ok"
+}
+
+var missingSharedLibrary = regexp.MustCompile(`error while loading shared libraries: ([^:]+)`)
+var rVersionLine = regexp.MustCompile(`R version (\d+\.\d+\.\d+)`)
+var rPlatformLine = regexp.MustCompile(`Platform: ([^-\s]+)`)
+
+// runWithTimeout runs a binary and returns its combined output, killing it if it does not finish in time
+func runWithTimeout(name string, args ...string) (string, error) {
+	return system.RunCommandWithTimeout(inspectTimeout, name, args...)
+}
+
+// describeFailure turns the output of a failed run into a short reason
+func describeFailure(output string, err error) string {
+	if match := missingSharedLibrary.FindStringSubmatch(output); match != nil {
+		return "missing shared library " + strings.TrimSpace(match[1])
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if lastLine := strings.TrimSpace(lines[len(lines)-1]); lastLine != "" {
+		return lastLine
+	}
+	return err.Error()
+}
+
+// ownedBySystemPackage returns true if dpkg or rpm reports that a package owns the path
+func ownedBySystemPackage(path string) bool {
+	if _, err := exec.LookPath("dpkg"); err == nil {
+		_, err = runWithTimeout("dpkg", "-S", path)
+		return err == nil
+	}
+	if _, err := exec.LookPath("rpm"); err == nil {
+		_, err = runWithTimeout("rpm", "-qf", path)
+		return err == nil
+	}
+	return false
+}
+
+// installationOrigin determines whether an install lives in the install prefix or /opt, is owned by a system package or is a custom install
+func installationOrigin(path string, target string) string {
+	if strings.HasPrefix(target, config.InstallPrefix()+"/") {
+		return config.InstallPrefix()
+	}
+	if strings.HasPrefix(target, "/opt/") {
+		return "/opt"
+	}
+	// package databases may record either the symlink or the real path, for example /bin vs /usr/bin
+	if ownedBySystemPackage(target) || (path != target && ownedBySystemPackage(path)) {
+		return "system package"
+	}
+	return "custom"
+}
+
+// newInstallation resolves the symlinks of a path and determines its origin
+func newInstallation(path string) Installation {
+	installation := Installation{Path: path, Target: path}
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		installation.Problem = "unable to resolve path: " + err.Error()
+		return installation
+	}
+	installation.Target = target
+	installation.Origin = installationOrigin(path, target)
+	return installation
+}
+
+// parseROutput reads the version and platform architecture from the output of R --version
+func parseROutput(output string) (string, string, error) {
+	match := rVersionLine.FindStringSubmatch(output)
+	if match == nil {
+		return "", "", errors.New("unable to determine the version from R --version")
+	}
+	arch := ""
+	if platform := rPlatformLine.FindStringSubmatch(output); platform != nil {
+		arch = platform[1]
+	}
+	return match[1], arch, nil
+}
+
+// parsePythonOutput reads the version and machine architecture printed on separate lines by the Python inspection script
+func parsePythonOutput(output string) (string, string, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	version := strings.TrimSpace(lines[0])
+	if version == "" {
+		return "", "", errors.New("unable to determine the version from python")
+	}
+	arch := ""
+	if len(lines) > 1 {
+		arch = strings.TrimSpace(lines[1])
+	}
+	return version, arch, nil
+}
+
+// parseJuliaOutput reads the version and architecture printed by the Julia inspection script
+func parseJuliaOutput(output string) (string, string, error) {
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return "", "", errors.New("unable to determine the version from julia")
+	}
+	return fields[0], fields[1], nil
+}
+
+// InspectR runs an R binary to determine its real version and architecture
+func InspectR(path string) Installation {
+	installation := newInstallation(path)
+	if installation.Broken() {
+		return installation
+	}
+
+	output, err := runWithTimeout(installation.Target, "--version")
+	if err != nil {
+		installation.Problem = describeFailure(output, err)
+		return installation
+	}
+	installation.Version, installation.Arch, err = parseROutput(output)
+	if err != nil {
+		installation.Problem = err.Error()
+	}
+	return installation
+}
+
+// InspectPython runs a Python binary to determine its real version and architecture, and ensures the ssl module can be imported
+func InspectPython(path string) Installation {
+	installation := newInstallation(path)
+	if installation.Broken() {
+		return installation
+	}
+
+	output, err := runWithTimeout(installation.Target, "-c", "import sys, ssl, platform; print(platform.python_version()); print(platform.machine())")
+	if err != nil {
+		installation.Problem = describeFailure(output, err)
+		return installation
+	}
+	installation.Version, installation.Arch, err = parsePythonOutput(output)
+	if err != nil {
+		installation.Problem = err.Error()
+	}
+	return installation
+}
+
+// InspectJulia runs a Julia binary to determine its real version and architecture
+func InspectJulia(path string) Installation {
+	installation := newInstallation(path)
+	if installation.Broken() {
+		return installation
+	}
+
+	output, err := runWithTimeout(installation.Target, "--startup-file=no", "-e", `print(VERSION, " ", Sys.ARCH)`)
+	if err != nil {
+		installation.Problem = describeFailure(output, err)
+		return installation
+	}
+	installation.Version, installation.Arch, err = parseJuliaOutput(output)
+	if err != nil {
+		installation.Problem = err.Error()
+	}
+	return installation
+}
+
+// inspectInstallations inspects each path and removes the paths that resolve to an install that was already found
+func inspectInstallations(paths []string, inspect func(string) Installation) []Installation {
+	installations := []Installation{}
+	seenTargets := map[string]bool{}
+	for _, path := range paths {
+		installation := inspect(path)
+		if seenTargets[installation.Target] {
+			continue
+		}
+		seenTargets[installation.Target] = true
+		installations = append(installations, installation)
+	}
+	return installations
+}
+
+// InspectRInstallations scans for R versions and runs each one to report its real version, architecture and origin
+func InspectRInstallations() ([]Installation, error) {
+	rPaths, err := ScanForRVersions()
+	if err != nil {
+		return nil, fmt.Errorf("issue occured in scanning for R versions: %w", err)
+	}
+	return inspectInstallations(rPaths, InspectR), nil
+}
+
+// InspectPythonInstallations scans for Python versions and runs each one to report its real version, architecture and origin
+func InspectPythonInstallations() ([]Installation, error) {
+	pythonPaths, err := ScanForPythonVersions()
+	if err != nil {
+		return nil, fmt.Errorf("issue occured in scanning for Python versions: %w", err)
+	}
+	return inspectInstallations(pythonPaths, InspectPython), nil
+}
+
+// InspectJuliaInstallations scans for Julia versions and runs each one to report its real version, architecture and origin
+func InspectJuliaInstallations() ([]Installation, error) {
+	juliaPaths, err := ScanForJuliaVersions()
+	if err != nil {
+		return nil, fmt.Errorf("issue occured in scanning for Julia versions: %w", err)
+	}
+	return inspectInstallations(juliaPaths, InspectJulia), nil
+}
@@ -0,0 +1,95 @@
+package languages
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseROutput(t *testing.T) {
+	tests := map[string]struct {
+		output          string
+		expectedVersion string
+		expectedArch    string
+		expectError     string
+	}{
+		"R with a platform line": {
+			output: `R version 4.3.2 (2023-10-31) -- "Eye Holes"
+Copyright (C) 2023 The R Foundation for Statistical Computing
+Platform: x86_64-pc-linux-gnu (64-bit)`,
+			expectedVersion: "4.3.2",
+			expectedArch:    "x86_64",
+		},
+		"R on arm without a platform line": {
+			output:          `R version 4.4.0 (2024-04-24) -- "Puppy Cup"`,
+			expectedVersion: "4.4.0",
+		},
+		"output without a version fails": {
+			output:      "Segmentation fault",
+			expectError: "unable to determine the version from R --version",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, arch, err := parseROutput(tc.output)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, version)
+			assert.Equal(t, tc.expectedArch, arch)
+		})
+	}
+}
+
+func TestParsePythonOutput(t *testing.T) {
+	version, arch, err := parsePythonOutput("3.11.6\nx86_64\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.11.6", version)
+	assert.Equal(t, "x86_64", arch)
+
+	_, _, err = parsePythonOutput("\n")
+	assert.ErrorContains(t, err, "unable to determine the version from python")
+}
+
+func TestParseJuliaOutput(t *testing.T) {
+	version, arch, err := parseJuliaOutput("1.10.2 x86_64")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.10.2", version)
+	assert.Equal(t, "x86_64", arch)
+
+	_, _, err = parseJuliaOutput("ERROR: could not load library")
+	assert.ErrorContains(t, err, "unable to determine the version from julia")
+}
+
+func TestDescribeFailure(t *testing.T) {
+	tests := map[string]struct {
+		output   string
+		err      error
+		expected string
+	}{
+		"missing shared library": {
+			output:   "/opt/R/4.3.2/lib/R/bin/exec/R: error while loading shared libraries: libRblas.so: cannot open shared object file",
+			err:      errors.New("exit status 127"),
+			expected: "missing shared library libRblas.so",
+		},
+		"last line of the output": {
+			output:   "Traceback (most recent call last):\nModuleNotFoundError: No module named '_ssl'\n",
+			err:      errors.New("exit status 1"),
+			expected: "ModuleNotFoundError: No module named '_ssl'",
+		},
+		"no output uses the error": {
+			err:      errors.New("timed out after 15s"),
+			expected: "timed out after 15s",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, describeFailure(tc.output, tc.err))
+		})
+	}
+}
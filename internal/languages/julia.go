@@ -0,0 +1,481 @@
+package languages
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/install"
+	cmdlog "github.com/sol-eng/wbi/internal/logging"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// juliaVersionsURL is the manifest of every Julia release and its downloads
+var juliaVersionsURL = "https://julialang-s3.julialang.org/bin/versions.json"
+
+// JuliaSharedDepot returns the Julia depot IJulia is installed into so every user can start the Jupyter kernel, such as /opt/julia/depot
+func JuliaSharedDepot() string {
+	return filepath.Join(config.InstallRoot("julia"), "depot")
+}
+
+// JuliaBinary returns the path to the julia binary of a version installed in the install prefix
+func JuliaBinary(juliaVersion string) string {
+	return filepath.Join(config.InstallDir("julia", juliaVersion), "bin", "julia")
+}
+
+// juliaSymlinkPath is where the default Julia is symlinked so it is available on PATH
+const juliaSymlinkPath = "/usr/local/bin/julia"
+
+type juliaFile struct {
+	URL       string `json:"url"`
+	Kind      string `json:"kind"`
+	Arch      string `json:"arch"`
+	OS        string `json:"os"`
+	Extension string `json:"extension"`
+	SHA256    string `json:"sha256"`
+}
+
+type juliaRelease struct {
+	Stable bool        `json:"stable"`
+	Files  []juliaFile `json:"files"`
+}
+
+// juliaArch converts the Go architecture to the one used in the Julia manifest
+func juliaArch() string {
+	switch runtime.GOARCH {
+	case "arm64":
+		return "aarch64"
+	default:
+		return "x86_64"
+	}
+}
+
+// linuxArchive returns the glibc Linux tarball of the release for this architecture
+func (release juliaRelease) linuxArchive() (juliaFile, bool) {
+	return lo.Find(release.Files, func(file juliaFile) bool {
+		// musl builds share the os and arch but are named linux-musl in the URL
+		return file.OS == "linux" && file.Arch == juliaArch() && file.Kind == "archive" && file.Extension == "tar.gz" && !strings.Contains(file.URL, "musl")
+	})
+}
+
+func retrieveJuliaManifest() (map[string]juliaRelease, error) {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	req, err := http.NewRequestWithContext(context.Background(),
+		http.MethodGet, juliaVersionsURL, nil)
+	if err != nil {
+		return nil, errors.New("error creating request")
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.New("error retrieving JSON data")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New("error in HTTP status code")
+	}
+
+	var manifest map[string]juliaRelease
+	err = json.NewDecoder(res.Body).Decode(&manifest)
+	if err != nil {
+		return nil, errors.New("error unmarshalling JSON data")
+	}
+	return manifest, nil
+}
+
+// RetrieveValidJuliaVersions returns the stable Julia releases with a Linux tarball for this architecture
+func RetrieveValidJuliaVersions(osType config.OperatingSystem) ([]string, error) {
+	manifest, err := retrieveJuliaManifest()
+	if err != nil {
+		return []string{}, fmt.Errorf("issue retrieving the Julia versions manifest: %w", err)
+	}
+
+	var stableVersions []string
+	for juliaVersion, release := range manifest {
+		if _, found := release.linuxArchive(); release.Stable && found {
+			stableVersions = append(stableVersions, juliaVersion)
+		}
+	}
+	versions, err := ConvertStringSliceToVersionSlice(stableVersions)
+	if err != nil {
+		return []string{}, fmt.Errorf("issue converting string slice to version slice: %w", err)
+	}
+
+	versions, err = FilterCompatibleVersions("julia", versions, osType)
+	if err != nil {
+		return []string{}, fmt.Errorf("issue removing Julia versions unavailable on this operating system: %w", err)
+	}
+	return ConvertVersionSliceToStringSlice(SortVersionsDesc(versions)), nil
+}
+
+// ResolveJuliaVersions resolves Julia version values such as 1.9, latest, latest-3 or >=1.8,<1.10 to exact versions
+func ResolveJuliaVersions(juliaVersions []string, osType config.OperatingSystem) ([]string, error) {
+	availJuliaVersions, err := RetrieveValidJuliaVersions(osType)
+	if err != nil {
+		return []string{}, fmt.Errorf("error retrieving valid Julia versions: %w", err)
+	}
+	resolutions, err := ResolveVersions("Julia", juliaVersions, availJuliaVersions)
+	if err != nil {
+		return []string{}, err
+	}
+	PrintVersionResolutions("Julia", resolutions)
+	return ResolvedVersions(resolutions), nil
+}
+
+// verifySHA256 ensures a downloaded file matches the checksum in the manifest
+func verifySHA256(path string, expected string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("issue opening %s: %w", path, err)
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("issue reading %s: %w", path, err)
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != expected {
+		return errors.New("the checksum of " + path + " does not match the Julia manifest")
+	}
+	return nil
+}
+
+// DownloadAndInstallJulia downloads the Julia tarball for a version and extracts it into the install prefix, such as /opt/julia/<version>
+func DownloadAndInstallJulia(juliaVersion string) error {
+	manifest, err := retrieveJuliaManifest()
+	if err != nil {
+		return fmt.Errorf("issue retrieving the Julia versions manifest: %w", err)
+	}
+	archive, found := manifest[juliaVersion].linuxArchive()
+	if !found {
+		return errors.New("no Linux " + juliaArch() + " download is available for Julia " + juliaVersion)
+	}
+
+	installerPath, err := install.DownloadFile("Julia", archive.URL, filepath.Base(archive.URL))
+	if err != nil {
+		return fmt.Errorf("DownloadJulia: %w", err)
+	}
+	if archive.SHA256 != "" {
+		err = verifySHA256(installerPath, archive.SHA256)
+		if err != nil {
+			return err
+		}
+	}
+
+	juliaPath := config.InstallDir("julia", juliaVersion)
+	err = os.MkdirAll(juliaPath, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+	installCommand := "tar -zxf " + system.ShellQuote(installerPath) + " -C " + system.ShellQuote(juliaPath) + " --strip-components=1"
+	err = system.RunCommand(installCommand, false, 0, false)
+	if err != nil {
+		return fmt.Errorf("the command '%s' failed to run: %w", installCommand, err)
+	}
+
+	// save to command log
+	cmdlog.Info("curl -o julia.tar.gz -L " + archive.URL)
+	cmdlog.Info("mkdir -p " + system.ShellQuote(juliaPath))
+	cmdlog.Info("tar -zxf julia.tar.gz -C " + system.ShellQuote(juliaPath) + " --strip-components=1")
+	cmdlog.Info("rm julia.tar.gz")
+
+	err = config.SaveInstallPrefix()
+	if err != nil {
+		return fmt.Errorf("issue saving the install prefix: %w", err)
+	}
+
+	system.PrintAndLogInfo("\nJulia version " + juliaVersion + " successfully installed!\n")
+	return nil
+}
+
+// ScanForJuliaVersions scans for Julia versions in the install prefix and on PATH
+func ScanForJuliaVersions() ([]string, error) {
+	foundVersions := []string{}
+	optVersions, err := ScanOptVersions(config.InstallRoot("julia"))
+	if err != nil {
+		return foundVersions, err
+	}
+	versions, err := ConvertStringSliceToVersionSlice(optVersions)
+	if err != nil {
+		return foundVersions, fmt.Errorf("issue converting string slice to version slice: %w", err)
+	}
+	for _, optVersion := range ConvertVersionSliceToStringSlice(SortVersionsDesc(versions)) {
+		juliaPath := JuliaBinary(optVersion)
+		if system.VerifyFileExists(juliaPath) {
+			foundVersions = append(foundVersions, juliaPath)
+		}
+	}
+
+	maybeJulia, err := exec.LookPath("julia")
+	if err == nil {
+		foundVersions = AppendIfMissing(foundVersions, maybeJulia)
+	}
+	return foundVersions, nil
+}
+
+// CheckIfJuliaSymlinkExists checks if the Julia symlink exists
+func CheckIfJuliaSymlinkExists() bool {
+	_, err := os.Stat(juliaSymlinkPath)
+	if err != nil {
+		return false
+	}
+
+	system.PrintAndLogInfo("\nAn existing Julia symlink has been detected (" + juliaSymlinkPath + ")")
+	return true
+}
+
+// SetJuliaSymlink symlinks a Julia binary to /usr/local/bin/julia, replacing any existing symlink
+func SetJuliaSymlink(juliaPath string) error {
+	err := system.ReplaceSymlink(juliaPath, juliaSymlinkPath)
+	if err != nil {
+		return fmt.Errorf("error setting the Julia symlink: %w", err)
+	}
+	return nil
+}
+
+func CheckAndSetJuliaSymlink(juliaPath string) error {
+	if CheckIfJuliaSymlinkExists() {
+		system.PrintAndLogInfo("The Julia symlink already exists, skipping symlink creation.")
+		return nil
+	}
+	return SetJuliaSymlink(juliaPath)
+}
+
+// RegisterIJuliaKernel installs IJulia into the shared depot and registers a system wide Jupyter kernel for a Julia binary.
+// The kernel searches the user's own depot first and then the shared depot, so users can still add their own packages
+func RegisterIJuliaKernel(juliaPath string) error {
+	err := os.MkdirAll(JuliaSharedDepot(), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	kernelScript := `using Pkg; Pkg.add("IJulia"); using IJulia; installkernel("Julia", env=Dict("JULIA_DEPOT_PATH" => ":` + JuliaSharedDepot() + `"))`
+	kernelCommand := "JULIA_DEPOT_PATH=" + system.ShellQuote(JuliaSharedDepot()) + " JUPYTER_DATA_DIR=/usr/local/share/jupyter " + system.ShellQuote(juliaPath) + " -e " + system.ShellQuote(kernelScript)
+	err = system.RunCommand(kernelCommand, true, 1, true)
+	if err != nil {
+		return fmt.Errorf("issue installing IJulia and registering the Julia kernel with the command '%s': %w", kernelCommand, err)
+	}
+
+	// make the shared depot readable by every user
+	permissionsCommand := "chmod -R a+rX " + system.ShellQuote(JuliaSharedDepot())
+	err = system.RunCommand(permissionsCommand, false, 0, true)
+	if err != nil {
+		return fmt.Errorf("issue setting permissions on %s with the command '%s': %w", JuliaSharedDepot(), permissionsCommand, err)
+	}
+	system.PrintAndLogInfo("\nThe Jupyter kernel for " + juliaPath + " has been registered with IJulia in " + JuliaSharedDepot())
+	return nil
+}
+
+// JuliaInstallPrompt asks users if they would like to install Julia versions
+func JuliaInstallPrompt() (bool, error) {
+	name := true
+	messageText := "Would you like to install version(s) of Julia?"
+	prompt := &survey.Confirm{
+		Message: messageText,
+	}
+	err := survey.AskOne(prompt, &name)
+	if err != nil {
+		return false, errors.New("there was an issue with the Julia install prompt")
+	}
+	log.Info(messageText)
+	log.Info(fmt.Sprintf("%v", name))
+	return name, nil
+}
+
+// JuliaSelectVersionsPrompt asks users which Julia version(s) they would like to install
+func JuliaSelectVersionsPrompt(availableJuliaVersions []string) ([]string, error) {
+	messageText := "Which version(s) of Julia would you like to install?"
+	var qs = []*survey.Question{
+		{
+			Name: "juliaVersions",
+			Prompt: &survey.MultiSelect{
+				Message: messageText,
+				Options: availableJuliaVersions,
+				Default: availableJuliaVersions[0],
+			},
+		},
+	}
+	juliaVersionsAnswers := struct {
+		JuliaVersions []string `survey:"juliaVersions"`
+	}{}
+	err := survey.Ask(qs, &juliaVersionsAnswers, survey.WithRemoveSelectAll(), survey.WithRemoveSelectNone())
+	if err != nil {
+		return []string{}, errors.New("there was an issue with the Julia versions selection prompt")
+	}
+	log.Info(messageText)
+	log.Info(strings.Join(juliaVersionsAnswers.JuliaVersions, ", "))
+	return juliaVersionsAnswers.JuliaVersions, nil
+}
+
+// juliaSymlinkPrompt asks users if they would like to set the Julia symlink
+func juliaSymlinkPrompt() (bool, error) {
+	name := true
+	messageText := `Would you like to symlink a Julia version to make it available on PATH? This is recommended so users can type "julia" in the terminal.`
+	prompt := &survey.Confirm{
+		Message: messageText,
+	}
+	err := survey.AskOne(prompt, &name)
+	if err != nil {
+		return false, errors.New("there was an issue with the symlink Julia prompt")
+	}
+	log.Info(messageText)
+	log.Info(fmt.Sprintf("%v", name))
+	return name, nil
+}
+
+// juliaLocationPrompt asks users to select one of the Julia binaries
+func juliaLocationPrompt(messageText string, juliaPaths []string) (string, error) {
+	target := ""
+	prompt := &survey.Select{
+		Message: messageText,
+		Options: juliaPaths,
+	}
+	err := survey.AskOne(prompt, &target)
+	if err != nil {
+		return "", errors.New("there was an issue with the Julia selection prompt")
+	}
+	if target == "" {
+		return target, errors.New("no Julia binary selected")
+	}
+	log.Info(messageText)
+	log.Info(target)
+	return target, nil
+}
+
+// juliaKernelPrompt asks users which Julia versions they would like to register as Jupyter kernels
+func juliaKernelPrompt(juliaPaths []string) ([]string, error) {
+	messageText := "Which Julia version(s) would you like to register as Jupyter kernels with IJulia?"
+	var qs = []*survey.Question{
+		{
+			Name: "juliaPaths",
+			Prompt: &survey.MultiSelect{
+				Message: messageText,
+				Options: juliaPaths,
+				Default: juliaPaths[0],
+			},
+		},
+	}
+	juliaKernelAnswers := struct {
+		JuliaPaths []string `survey:"juliaPaths"`
+	}{}
+	err := survey.Ask(qs, &juliaKernelAnswers, survey.WithRemoveSelectAll())
+	if err != nil {
+		return []string{}, errors.New("there was an issue with the Julia kernel selection prompt")
+	}
+	log.Info(messageText)
+	log.Info(strings.Join(juliaKernelAnswers.JuliaPaths, ", "))
+	return juliaKernelAnswers.JuliaPaths, nil
+}
+
+// PromptAndInstallJulia prompts users if they want to install Julia and does the installation
+func PromptAndInstallJulia(osType config.OperatingSystem) ([]string, error) {
+	installJuliaChoice, err := JuliaInstallPrompt()
+	if err != nil {
+		return []string{}, fmt.Errorf("issue selecting Julia installation: %w", err)
+	}
+	if !installJuliaChoice {
+		return []string{}, nil
+	}
+	validJuliaVersions, err := RetrieveValidJuliaVersions(osType)
+	if err != nil {
+		return []string{}, fmt.Errorf("issue retrieving Julia versions: %w", err)
+	}
+
+	var installJuliaVersions []string
+	for {
+		installJuliaVersions, err = JuliaSelectVersionsPrompt(validJuliaVersions)
+		if err != nil {
+			return []string{}, fmt.Errorf("issue selecting Julia versions: %w", err)
+		}
+		if len(installJuliaVersions) == 0 {
+			system.PrintAndLogInfo(`No Julia versions selected. Please select at least one version to install.`)
+		} else {
+			break
+		}
+	}
+
+	for _, juliaVersion := range installJuliaVersions {
+		err = DownloadAndInstallJulia(juliaVersion)
+		if err != nil {
+			return []string{}, fmt.Errorf("issue installing Julia version: %w", err)
+		}
+	}
+	return installJuliaVersions, nil
+}
+
+// ScanAndHandleJuliaVersions scans for Julia versions, offers to install more, and offers the symlink and Jupyter kernels
+func ScanAndHandleJuliaVersions(osType config.OperatingSystem) error {
+	juliaVersionsOrig, err := ScanForJuliaVersions()
+	if err != nil {
+		return fmt.Errorf("issue occured in scanning for Julia versions: %w", err)
+	}
+	if len(juliaVersionsOrig) == 0 {
+		system.PrintAndLogInfo("\nno Julia versions found at locations: \n" + config.InstallRoot("julia"))
+	} else {
+		system.PrintAndLogInfo("\nFound Julia versions:")
+		system.PrintAndLogInfo(strings.Join(juliaVersionsOrig, "\n"))
+	}
+
+	_, err = PromptAndInstallJulia(osType)
+	if err != nil {
+		return fmt.Errorf("issue installing Julia: %w", err)
+	}
+
+	juliaVersions, err := ScanForJuliaVersions()
+	if err != nil {
+		return fmt.Errorf("issue occured in scanning for Julia versions: %w", err)
+	}
+	optJuliaVersions := lo.Filter(juliaVersions, func(juliaPath string, _ int) bool {
+		return strings.HasPrefix(juliaPath, config.InstallRoot("julia")+"/")
+	})
+	if len(optJuliaVersions) == 0 {
+		return nil
+	}
+
+	if !CheckIfJuliaSymlinkExists() {
+		setSymlinkChoice, err := juliaSymlinkPrompt()
+		if err != nil {
+			return fmt.Errorf("an issue occured during the selection of Julia symlink choice: %w", err)
+		}
+		if setSymlinkChoice {
+			juliaPathChoice, err := juliaLocationPrompt("Select a Julia binary to symlink:", optJuliaVersions)
+			if err != nil {
+				return fmt.Errorf("issue selecting Julia binary to symlink: %w", err)
+			}
+			err = SetJuliaSymlink(juliaPathChoice)
+			if err != nil {
+				return fmt.Errorf("issue setting Julia symlink: %w", err)
+			}
+		}
+	}
+
+	kernelJuliaPaths, err := juliaKernelPrompt(optJuliaVersions)
+	if err != nil {
+		return err
+	}
+	for _, juliaPath := range kernelJuliaPaths {
+		err = RegisterIJuliaKernel(juliaPath)
+		if err != nil {
+			return fmt.Errorf("issue registering a Jupyter kernel for Julia: %w", err)
+		}
+	}
+
+	system.PrintAndLogInfo("\nFound Julia versions:")
+	system.PrintAndLogInfo(strings.Join(juliaVersions, "\n"))
+	return nil
+}
@@ -0,0 +1,114 @@
+package languages
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// otherJuliaArch returns an architecture in the Julia manifest that isn't this machine's
+func otherJuliaArch() string {
+	if juliaArch() == "aarch64" {
+		return "x86_64"
+	}
+	return "aarch64"
+}
+
+func TestJuliaReleaseLinuxArchive(t *testing.T) {
+	glibc := juliaFile{
+		URL:       "https://julialang-s3.julialang.org/bin/linux/x64/1.10/julia-1.10.2-linux-" + juliaArch() + ".tar.gz",
+		Kind:      "archive",
+		Arch:      juliaArch(),
+		OS:        "linux",
+		Extension: "tar.gz",
+	}
+	musl := glibc
+	musl.URL = "https://julialang-s3.julialang.org/bin/musl/x64/1.10/julia-1.10.2-musl-" + juliaArch() + ".tar.gz"
+	otherArch := glibc
+	otherArch.Arch = otherJuliaArch()
+	installer := glibc
+	installer.Kind = "installer"
+	mac := glibc
+	mac.OS = "mac"
+
+	tests := map[string]struct {
+		files    []juliaFile
+		expected juliaFile
+		found    bool
+	}{
+		"the glibc tarball is picked over the musl tarball": {
+			files:    []juliaFile{musl, otherArch, glibc},
+			expected: glibc,
+			found:    true,
+		},
+		"musl, other architectures, installers and other operating systems are skipped": {
+			files: []juliaFile{musl, otherArch, installer, mac},
+		},
+		"no files": {
+			files: []juliaFile{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			archive, found := juliaRelease{Stable: true, Files: tc.files}.linuxArchive()
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.expected, archive)
+		})
+	}
+}
+
+func TestVerifySHA256(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "julia.tar.gz")
+	if err := os.WriteFile(path, []byte("julia"), 0644); err != nil {
+		t.Fatalf("issue writing the file: %v", err)
+	}
+
+	assert.NoError(t, verifySHA256(path, "d277670919a94ba361be1887d39852c3f31d7eed817343cbb70fcd8910841f86"))
+	// the checksum of "julia 1.10"
+	err := verifySHA256(path, "530cb5c0a7af0da0f8f470208921e8264bcbe40502dd88d66db440d1499831a3")
+	assert.ErrorContains(t, err, "does not match the Julia manifest")
+	err = verifySHA256(filepath.Join(t.TempDir(), "missing.tar.gz"), "d277670919a94ba361be1887d39852c3f31d7eed817343cbb70fcd8910841f86")
+	assert.ErrorContains(t, err, "issue opening")
+}
+
+func TestRetrieveValidJuliaVersions(t *testing.T) {
+	linuxArchive := fmt.Sprintf(`{"url": "https://example.com/julia-linux-%[1]s.tar.gz", "kind": "archive", "arch": "%[1]s", "os": "linux", "extension": "tar.gz"}`, juliaArch())
+	muslArchive := fmt.Sprintf(`{"url": "https://example.com/julia-musl-%[1]s.tar.gz", "kind": "archive", "arch": "%[1]s", "os": "linux", "extension": "tar.gz"}`, juliaArch())
+	otherArchive := fmt.Sprintf(`{"url": "https://example.com/julia-linux-%[1]s.tar.gz", "kind": "archive", "arch": "%[1]s", "os": "linux", "extension": "tar.gz"}`, otherJuliaArch())
+	manifest := `{
+  "1.9.4": {"stable": true, "files": [` + linuxArchive + `]},
+  "1.10.2": {"stable": true, "files": [` + muslArchive + `, ` + linuxArchive + `]},
+  "1.11.0-rc1": {"stable": false, "files": [` + linuxArchive + `]},
+  "1.8.5": {"stable": true, "files": [` + muslArchive + `, ` + otherArchive + `]}
+}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, manifest)
+	}))
+	defer server.Close()
+	juliaVersionsURL = server.URL
+	defer func() { juliaVersionsURL = "https://julialang-s3.julialang.org/bin/versions.json" }()
+
+	versions, err := RetrieveValidJuliaVersions(config.Ubuntu22)
+	assert.NoError(t, err)
+	// unstable releases and releases without a glibc tarball for this architecture are left out, newest first
+	assert.Equal(t, []string{"1.10.2", "1.9.4"}, versions)
+}
+
+func TestRetrieveValidJuliaVersionsHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	juliaVersionsURL = server.URL
+	defer func() { juliaVersionsURL = "https://julialang-s3.julialang.org/bin/versions.json" }()
+
+	_, err := RetrieveValidJuliaVersions(config.Ubuntu22)
+	assert.ErrorContains(t, err, "error in HTTP status code")
+}
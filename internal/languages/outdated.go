@@ -0,0 +1,137 @@
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// OutdatedVersion is an installed version with a newer patch release available in the same minor line
+type OutdatedVersion struct {
+	Installed string
+	Latest    string
+}
+
+// withInstallRoot returns the root directories to scan for a program, starting with the program's directory in the install prefix
+func withInstallRoot(program string, rootDirs []string) []string {
+	dirs := []string{config.InstallRoot(program)}
+	for _, rootDir := range rootDirs {
+		dirs = AppendIfMissing(dirs, rootDir)
+	}
+	return dirs
+}
+
+// ScanOptVersions returns the versions installed as directories in an install root such as /opt/R
+func ScanOptVersions(rootDir string) ([]string, error) {
+	entries, err := os.ReadDir(rootDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{}, nil
+		}
+		return []string{}, fmt.Errorf("issue reading %s: %w", rootDir, err)
+	}
+	var installed []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		// skip anything that isn't a version such as a custom build directory
+		if _, err := version.NewVersion(entry.Name()); err == nil {
+			installed = append(installed, entry.Name())
+		}
+	}
+	return installed, nil
+}
+
+func minorLine(v *version.Version) string {
+	segments := v.Segments()
+	return fmt.Sprintf("%d.%d", segments[0], segments[1])
+}
+
+// FindNewerPatchVersions compares installed versions against the available versions and returns those with a newer patch release in the same minor line
+func FindNewerPatchVersions(installed []string, available []string) ([]OutdatedVersion, error) {
+	installedVersions, err := ConvertStringSliceToVersionSlice(installed)
+	if err != nil {
+		return nil, fmt.Errorf("issue converting string slice to version slice: %w", err)
+	}
+	availableVersions, err := ConvertStringSliceToVersionSlice(available)
+	if err != nil {
+		return nil, fmt.Errorf("issue converting string slice to version slice: %w", err)
+	}
+
+	// find the newest version available and installed for each minor line, never offering a pre-release as an upgrade
+	latestAvailable := map[string]*version.Version{}
+	for _, v := range availableVersions {
+		if v.Prerelease() != "" {
+			continue
+		}
+		line := minorLine(v)
+		if current, ok := latestAvailable[line]; !ok || v.GreaterThan(current) {
+			latestAvailable[line] = v
+		}
+	}
+	latestInstalled := map[string]*version.Version{}
+	for _, v := range installedVersions {
+		line := minorLine(v)
+		if current, ok := latestInstalled[line]; !ok || v.GreaterThan(current) {
+			latestInstalled[line] = v
+		}
+	}
+
+	var outdated []OutdatedVersion
+	for _, v := range SortVersionsDesc(installedVersions) {
+		line := minorLine(v)
+		latest, ok := latestAvailable[line]
+		if !ok || !latest.GreaterThan(latestInstalled[line]) {
+			continue
+		}
+		outdated = append(outdated, OutdatedVersion{Installed: v.Original(), Latest: latest.Original()})
+	}
+	return outdated, nil
+}
+
+// UniqueLatestVersions returns each newer patch version once, even if several installed versions share a minor line
+func UniqueLatestVersions(outdated []OutdatedVersion) []string {
+	return lo.Uniq(lo.Map(outdated, func(o OutdatedVersion, _ int) string {
+		return o.Latest
+	}))
+}
+
+// MoveRSymlinks moves the R and Rscript symlinks from one installed R version to another if they currently point at the old version
+func MoveRSymlinks(oldVersion string, newVersion string) (bool, error) {
+	target, err := filepath.EvalSymlinks("/usr/local/bin/R")
+	if err != nil || !strings.HasPrefix(target, config.RInstallDir(oldVersion)+"/") {
+		return false, nil
+	}
+	// the symlinks are replaced in place so R never drops off PATH
+	_, err = SetDefaultR(newVersion)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// MovePythonPATH moves the Python PATH entry from one installed Python version to another if it currently points at the old version
+func MovePythonPATH(oldVersion string, newVersion string) (bool, error) {
+	profileFile := "/etc/profile.d/wbi_python.sh"
+	oldPath := filepath.Join(config.DefaultInstallDir("python", oldVersion), "bin")
+	matched, err := system.CheckStringExists(oldPath+":", profileFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if line exists: %w", err)
+	}
+	if !matched {
+		return false, nil
+	}
+	_, err = SetDefaultPython(newVersion)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
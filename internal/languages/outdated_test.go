@@ -0,0 +1,86 @@
+package languages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNewerPatchVersions(t *testing.T) {
+	tests := map[string]struct {
+		installed   []string
+		available   []string
+		expected    []OutdatedVersion
+		expectError string
+	}{
+		"newer patch in the same minor line is reported": {
+			installed: []string{"4.3.1"},
+			available: []string{"4.3.0", "4.3.1", "4.3.2"},
+			expected:  []OutdatedVersion{{Installed: "4.3.1", Latest: "4.3.2"}},
+		},
+		"latest patch installed is not reported": {
+			installed: []string{"4.3.2"},
+			available: []string{"4.3.1", "4.3.2"},
+		},
+		"newer minor line is not an upgrade": {
+			installed: []string{"4.2.3"},
+			available: []string{"4.2.3", "4.3.2", "4.4.0"},
+		},
+		"patch numbers are compared numerically": {
+			installed: []string{"1.3.9"},
+			available: []string{"1.3.9", "1.3.10", "1.3.340"},
+			expected:  []OutdatedVersion{{Installed: "1.3.9", Latest: "1.3.340"}},
+		},
+		"each minor line is compared separately and newest installed first": {
+			installed: []string{"3.10.11", "3.11.4", "3.12.0"},
+			available: []string{"3.10.13", "3.11.4", "3.11.6", "3.12.0"},
+			expected: []OutdatedVersion{
+				{Installed: "3.11.4", Latest: "3.11.6"},
+				{Installed: "3.10.11", Latest: "3.10.13"},
+			},
+		},
+		"a minor line with the latest patch installed alongside an older one is not reported": {
+			installed: []string{"4.3.1", "4.3.2"},
+			available: []string{"4.3.1", "4.3.2"},
+		},
+		"pre-release of a newer patch is not offered": {
+			installed: []string{"4.3.1"},
+			available: []string{"4.3.1", "4.3.2-rc1"},
+		},
+		"installed pre-release is older than its release": {
+			installed: []string{"4.4.0-rc1"},
+			available: []string{"4.4.0"},
+			expected:  []OutdatedVersion{{Installed: "4.4.0-rc1", Latest: "4.4.0"}},
+		},
+		"minor line not available is not reported": {
+			installed: []string{"3.6.3"},
+			available: []string{"4.3.2"},
+		},
+		"invalid available version fails": {
+			installed:   []string{"4.3.1"},
+			available:   []string{"not-a-version"},
+			expectError: "failed to parse version not-a-version",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			outdated, err := FindNewerPatchVersions(tc.installed, tc.available)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, outdated)
+		})
+	}
+}
+
+func TestUniqueLatestVersions(t *testing.T) {
+	outdated := []OutdatedVersion{
+		{Installed: "4.3.1", Latest: "4.3.2"},
+		{Installed: "4.3.0", Latest: "4.3.2"},
+		{Installed: "4.2.1", Latest: "4.2.3"},
+	}
+	assert.Equal(t, []string{"4.3.2", "4.2.3"}, UniqueLatestVersions(outdated))
+}
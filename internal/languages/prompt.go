@@ -17,7 +17,7 @@ func PromptAndRespond() ([]string, error) {
 			Name: "languages",
 			Prompt: &survey.MultiSelect{
 				Message: messageText,
-				Options: []string{"R", "python"},
+				Options: []string{"R", "python", "julia"},
 				Default: []string{"R", "python"},
 			},
 		},
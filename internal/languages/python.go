@@ -26,12 +26,6 @@ type availablePythonVersions struct {
 	PythonVersions []string `json:"python_versions"`
 }
 
-type unavailablePythonVersions struct {
-	NewestPythonVersions   []string
-	OldestPythonVersions   []string
-	SpecificPythonVersions []string
-}
-
 var globalPythonPaths = []string{
 	"/usr/bin/python",
 	"/usr/bin/Python",
@@ -47,7 +41,8 @@ var rootPythonDirs = []string{
 	"/usr/local/lib/Python",
 }
 
-// GetPythonRootDirs returns the root directories for Python
+// GetPythonRootDirs returns the root directories for Python. Posit's Python packages always install into /opt/python,
+// so the install prefix is not included
 func GetPythonRootDirs() []string {
 	return rootPythonDirs
 }
@@ -178,13 +173,12 @@ func ScanAndHandlePythonVersions(osType config.OperatingSystem) error {
 	} else {
 		anyOptLocations := []string{}
 		for _, value := range pythonVersionsOrig {
-			matched, err := regexp.MatchString(".*/opt.*", value)
-			if err == nil && matched {
+			if strings.HasPrefix(value, config.DefaultInstallRoot("python")+"/") {
 				anyOptLocations = append(anyOptLocations, value)
 			}
 		}
 		if len(anyOptLocations) == 0 {
-			system.PrintAndLogInfo("Posit recommends installing version of Python into the /opt directory to not conflict/rely on the system installed version of Python.")
+			system.PrintAndLogInfo("Posit recommends installing version of Python into the " + config.DefaultInstallRoot("python") + " directory to not conflict/rely on the system installed version of Python.")
 		}
 		_, err := PromptAndInstallPython(osType)
 		if err != nil {
@@ -211,9 +205,8 @@ func ScanAndHandlePythonVersions(osType config.OperatingSystem) error {
 func ScanForPythonVersions() ([]string, error) {
 	foundVersions := []string{}
 	foundOptVersions := []string{}
-	// This is somewhat naive with respect to actually checking whether
-	// this is _really_ a working version of Python by launching it,
-	// vs just matches the path to Python
+	// This only matches the path to Python, use InspectPythonInstallations
+	// to launch each version and check that it really works
 	for _, pyPath := range GetPythonPaths() {
 		if _, err := os.Stat(pyPath); err == nil {
 			foundVersions = append(foundVersions, pyPath)
@@ -229,7 +222,7 @@ func ScanForPythonVersions() ([]string, error) {
 			if entry.IsDir() {
 				pythonPath, isPython := isPythonDir(filepath.Join(rootPath, entry.Name()))
 				if isPython {
-					if rootPath == "/opt/python" {
+					if rootPath == config.DefaultInstallRoot("python") {
 						foundOptVersions = append(foundOptVersions, pythonPath)
 					} else {
 						foundVersions = append(foundVersions, pythonPath)
@@ -244,10 +237,10 @@ func ScanForPythonVersions() ([]string, error) {
 		foundVersions = AppendIfMissing(foundVersions, maybePython)
 	}
 
-	// sort /opt/R versions
+	// sort the versions in the install prefix
 	foundOptVersionsSortedPaths, err := sortOptPythonVersionPaths(foundOptVersions)
 	if err != nil {
-		return []string{}, fmt.Errorf("issue sorting /opt/python versions: %w", err)
+		return []string{}, fmt.Errorf("issue sorting %s versions: %w", config.DefaultInstallRoot("python"), err)
 	}
 
 	finalVersionPathSorted := append(foundOptVersionsSortedPaths, foundVersions...)
@@ -258,9 +251,8 @@ func ScanForPythonVersions() ([]string, error) {
 func sortOptPythonVersionPaths(versionPaths []string) ([]string, error) {
 	foundOptVersionsOnly := []string{}
 	for _, optVersion := range versionPaths {
-		i := strings.Index(optVersion, "python")
-		j := strings.Index(optVersion, "bin")
-		foundOptVersionsOnly = append(foundOptVersionsOnly, optVersion[i+7:j-1])
+		// paths have the format {root}/{version}/bin/python
+		foundOptVersionsOnly = append(foundOptVersionsOnly, filepath.Base(filepath.Dir(filepath.Dir(optVersion))))
 	}
 	versions, err := ConvertStringSliceToVersionSlice(foundOptVersionsOnly)
 	if err != nil {
@@ -271,7 +263,7 @@ func sortOptPythonVersionPaths(versionPaths []string) ([]string, error) {
 	foundOptVersionsSorted := ConvertVersionSliceToStringSlice(sortedVersions)
 	foundOptVersionsSortedPaths := []string{}
 	for _, optVersion := range foundOptVersionsSorted {
-		foundOptVersionsSortedPaths = append(foundOptVersionsSortedPaths, "/opt/python/"+optVersion+"/bin/python")
+		foundOptVersionsSortedPaths = append(foundOptVersionsSortedPaths, filepath.Join(config.DefaultInstallDir("python", optVersion), "bin", "python"))
 	}
 	return foundOptVersionsSortedPaths, nil
 }
@@ -339,31 +331,9 @@ func RetrieveValidPythonVersions(osType config.OperatingSystem) ([]string, error
 		return []string{}, fmt.Errorf("issue converting string slice to version slice: %w", err)
 	}
 
-	unavailPythonVersions := unavailablePythonVersionsByOS(osType)
-
-	if len(unavailPythonVersions.NewestPythonVersions) != 0 {
-		for _, v := range unavailPythonVersions.NewestPythonVersions {
-			versions, err = removeNewerVersions(versions, v)
-			if err != nil {
-				return []string{}, errors.New("failed removing newer unsupported versions of Python")
-			}
-		}
-	}
-	if len(unavailPythonVersions.OldestPythonVersions) != 0 {
-		for _, v := range unavailPythonVersions.OldestPythonVersions {
-			versions, err = removeOlderVersions(versions, v)
-			if err != nil {
-				return []string{}, errors.New("failed removing older unsupported versions of Python")
-			}
-		}
-	}
-	if len(unavailPythonVersions.SpecificPythonVersions) != 0 {
-		for _, v := range unavailPythonVersions.SpecificPythonVersions {
-			versions, err = removeSpecificVersions(versions, v)
-			if err != nil {
-				return []string{}, errors.New("failed removing specific unsupported versions of Python")
-			}
-		}
+	versions, err = FilterCompatibleVersions("python", versions, osType)
+	if err != nil {
+		return []string{}, fmt.Errorf("issue removing Python versions unavailable on this operating system: %w", err)
 	}
 
 	sortedVersions := SortVersionsDesc(versions)
@@ -433,8 +403,30 @@ func DownloadAndInstallPython(pythonVersion string, osType config.OperatingSyste
 	return nil
 }
 
+// InstallPythonFromFile installs Python from a local .deb or .rpm package, such as one pre-scanned by a security team, and
+// returns the version read from the package
+func InstallPythonFromFile(packagePath string, osType config.OperatingSystem) (string, error) {
+	system.PrintAndLogInfo("Installing Python from the local package " + packagePath)
+	pythonVersion, err := install.InstallLanguageFromFile("python", packagePath, osType)
+	if err != nil {
+		return "", fmt.Errorf("InstallLanguageFromFile: %w", err)
+	}
+	// save to command log
+	installCommand, err := install.RetrieveInstallCommand(packagePath, osType)
+	if err != nil {
+		return "", fmt.Errorf("RetrieveInstallCommand: %w", err)
+	}
+	cmdlog.Info(installCommand)
+	// Upgrade pip, setuptools, and wheel
+	err = UpgradePythonTools(pythonVersion)
+	if err != nil {
+		return "", fmt.Errorf("UpgradePythonTools: %w", err)
+	}
+	return pythonVersion, nil
+}
+
 func UpgradePythonTools(pythonVersion string) error {
-	upgradeCommand := "PIP_ROOT_USER_ACTION=ignore /opt/python/" + pythonVersion + "/bin/pip install --upgrade --no-warn-script-location --disable-pip-version-check pip setuptools wheel"
+	upgradeCommand := "PIP_ROOT_USER_ACTION=ignore " + filepath.Join(config.DefaultInstallDir("python", pythonVersion), "bin", "pip") + " install --upgrade --no-warn-script-location --disable-pip-version-check pip setuptools wheel"
 	err := system.RunCommand(upgradeCommand, true, 2, true)
 	if err != nil {
 		return fmt.Errorf("issue upgrading pip, setuptools and wheel for Python with the command '%s': %w", upgradeCommand, err)
@@ -474,6 +466,20 @@ func RemovePythonFromPathSlice(pythonPaths []string) ([]string, error) {
 	return newPythonPaths, nil
 }
 
+// ResolvePythonVersions resolves Python version values such as 3.11, latest, latest-3 or >=3.9,<3.12 to exact versions
+func ResolvePythonVersions(pythonVersions []string, osType config.OperatingSystem) ([]string, error) {
+	availablePythonVersions, err := RetrieveValidPythonVersions(osType)
+	if err != nil {
+		return []string{}, fmt.Errorf("error retrieving valid Python versions: %w", err)
+	}
+	resolutions, err := ResolveVersions("Python", pythonVersions, availablePythonVersions)
+	if err != nil {
+		return []string{}, err
+	}
+	PrintVersionResolutions("Python", resolutions)
+	return ResolvedVersions(resolutions), nil
+}
+
 func ValidatePythonVersions(pythonVersions []string, osType config.OperatingSystem) error {
 	availablePythonVersions, err := RetrieveValidPythonVersions(osType)
 	if err != nil {
@@ -493,20 +499,6 @@ func CheckIfPythonProfileDExists() bool {
 		return false
 	}
 
-	system.PrintAndLogInfo("\nAn existing /etc/profile.d/wbi_python.sh file was found, skipping setting Python path.")
+	system.PrintAndLogInfo("\nAn existing /etc/profile.d/wbi_python.sh file was found, skipping setting Python path. Use \"wbi default python [version]\" to change the default Python version.")
 	return true
 }
-func unavailablePythonVersionsByOS(osType config.OperatingSystem) unavailablePythonVersions {
-
-	var pythonVersions unavailablePythonVersions
-	switch osType {
-	case config.Redhat7:
-		pythonVersions.NewestPythonVersions = []string{"3.10.0", "3.11.0", "3.8.16", "3.9.15"}
-	case config.Redhat9:
-		pythonVersions.SpecificPythonVersions = []string{"3.7.3", "3.7.4", "3.7.5"}
-	}
-	// global
-	pythonVersions.NewestPythonVersions = []string{"3.12.0"}
-
-	return pythonVersions
-}
@@ -0,0 +1,250 @@
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// PipConfPath is the global pip configuration Workbench's PyPI repo is written to
+const PipConfPath = "/etc/pip.conf"
+
+// PythonPackageReport is the outcome of installing a requirements file into one Python version
+type PythonPackageReport struct {
+	PythonVersion string
+	Problem       string
+	Conflicts     []string
+}
+
+// PipIndexURL returns the index-url configured in /etc/pip.conf, or "" if there isn't one
+func PipIndexURL() string {
+	contents, err := os.ReadFile(PipConfPath)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(contents), "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if found && strings.TrimSpace(key) == "index-url" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// PythonPackageTargets returns the Python versions to install packages into. "all" selects every version in /opt/python,
+// any other value must be a version installed in /opt/python
+func PythonPackageTargets(pythonVersions []string) ([]string, error) {
+	var targets []string
+	for _, pythonVersion := range pythonVersions {
+		if pythonVersion == "all" {
+			optVersions, err := ScanOptVersions(config.DefaultInstallRoot("python"))
+			if err != nil {
+				return nil, fmt.Errorf("issue finding installed Python versions: %w", err)
+			}
+			for _, optVersion := range optVersions {
+				targets = AppendIfMissing(targets, optVersion)
+			}
+			continue
+		}
+		if !system.VerifyFileExists(pythonBinary(pythonVersion)) {
+			return nil, errors.New("Python " + pythonVersion + " is not installed in " + config.DefaultInstallRoot("python"))
+		}
+		targets = AppendIfMissing(targets, pythonVersion)
+	}
+	return targets, nil
+}
+
+// pythonBinary returns the path to the python binary of a version installed in /opt/python
+func pythonBinary(pythonVersion string) string {
+	return filepath.Join(config.DefaultInstallDir("python", pythonVersion), "bin", "python")
+}
+
+// pipCheck runs pip check and returns each reported conflict
+func pipCheck(pythonVersion string) ([]string, error) {
+	output, err := exec.Command(pythonBinary(pythonVersion), "-m", "pip", "check", "--disable-pip-version-check").CombinedOutput()
+	if err == nil {
+		return nil, nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("issue running pip check: %w", err)
+	}
+	// pip check exits with 1 and prints one line per conflict
+	var conflicts []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			conflicts = append(conflicts, line)
+		}
+	}
+	return conflicts, nil
+}
+
+// installPythonPackagesInto installs a requirements file into one Python version and checks the result for conflicts
+func installPythonPackagesInto(pythonVersion string, requirementsFile string, constraintsFile string, indexURL string) PythonPackageReport {
+	report := PythonPackageReport{PythonVersion: pythonVersion}
+
+	installCommand := "PIP_ROOT_USER_ACTION=ignore " + pythonBinary(pythonVersion) + " -m pip install --no-warn-script-location --disable-pip-version-check -r " + system.ShellQuote(requirementsFile)
+	if constraintsFile != "" {
+		installCommand = installCommand + " -c " + system.ShellQuote(constraintsFile)
+	}
+	if indexURL != "" {
+		installCommand = installCommand + " --index-url " + system.ShellQuote(indexURL)
+	}
+	err := system.RunCommand(installCommand, true, 1, true)
+	if err != nil {
+		report.Problem = "pip install failed, see the output above"
+		log.Error(err.Error())
+		return report
+	}
+
+	report.Conflicts, err = pipCheck(pythonVersion)
+	if err != nil {
+		report.Problem = err.Error()
+	}
+	return report
+}
+
+// InstallPythonPackages installs a requirements file, optionally with a constraints file, into each Python version through the
+// index in /etc/pip.conf and prints a summary. An error is returned if any install failed or left conflicting requirements
+func InstallPythonPackages(pythonVersions []string, requirementsFile string, constraintsFile string) ([]PythonPackageReport, error) {
+	if len(pythonVersions) == 0 {
+		system.PrintAndLogInfo("\nNo Python versions were found in " + config.DefaultInstallRoot("python") + ", skipping Python package installation.")
+		return nil, nil
+	}
+
+	// pip resolves relative paths against its working directory, so pass absolute paths
+	requirementsFile, err := filepath.Abs(requirementsFile)
+	if err != nil {
+		return nil, fmt.Errorf("issue finding %s: %w", requirementsFile, err)
+	}
+	if constraintsFile != "" {
+		constraintsFile, err = filepath.Abs(constraintsFile)
+		if err != nil {
+			return nil, fmt.Errorf("issue finding %s: %w", constraintsFile, err)
+		}
+	}
+
+	indexURL := PipIndexURL()
+	if indexURL != "" {
+		system.PrintAndLogInfo("\nInstalling Python packages from " + indexURL + " configured in " + PipConfPath)
+	} else {
+		system.PrintAndLogInfo("\nNo index-url is configured in " + PipConfPath + ", installing Python packages from the default index")
+	}
+
+	var reports []PythonPackageReport
+	for _, pythonVersion := range pythonVersions {
+		reports = append(reports, installPythonPackagesInto(pythonVersion, requirementsFile, constraintsFile, indexURL))
+	}
+
+	PrintPythonPackageReports(reports)
+
+	var failedVersions []string
+	for _, report := range reports {
+		if report.Problem != "" || len(report.Conflicts) > 0 {
+			failedVersions = append(failedVersions, report.PythonVersion)
+		}
+	}
+	if len(failedVersions) > 0 {
+		return reports, errors.New("the requirements could not be installed cleanly into Python " + strings.Join(failedVersions, ", "))
+	}
+	return reports, nil
+}
+
+// PrintPythonPackageReports prints a summary of the reports as a table followed by any conflicts
+func PrintPythonPackageReports(reports []PythonPackageReport) {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "PYTHON\tSTATUS\tCONFLICTS")
+	for _, report := range reports {
+		status := "ok"
+		if report.Problem != "" {
+			status = report.Problem
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%d\n", report.PythonVersion, status, len(report.Conflicts))
+	}
+	writer.Flush()
+	system.PrintAndLogInfo("\n" + strings.TrimSuffix(builder.String(), "\n"))
+
+	for _, report := range reports {
+		if len(report.Conflicts) > 0 {
+			system.PrintAndLogInfo("\nConflicts reported by pip check for Python " + report.PythonVersion + ":\n" + strings.Join(report.Conflicts, "\n"))
+		}
+	}
+}
+
+// PythonPackagesPrompt asks users if they would like to install a requirements file into every Python version
+func PythonPackagesPrompt() (bool, error) {
+	name := false
+	messageText := "Would you like to install a requirements file into every Python version?"
+	prompt := &survey.Confirm{
+		Message: messageText,
+	}
+	err := survey.AskOne(prompt, &name)
+	if err != nil {
+		return false, errors.New("there was an issue with the Python packages prompt")
+	}
+	log.Info(messageText)
+	log.Info(fmt.Sprintf("%v", name))
+	return name, nil
+}
+
+// PythonRequirementsFilesPrompt asks users for the requirements file and an optional constraints file
+func PythonRequirementsFilesPrompt() (string, string, error) {
+	requirementsFile := ""
+	requirementsMessage := "Path to the requirements file:"
+	err := survey.AskOne(&survey.Input{Message: requirementsMessage}, &requirementsFile, survey.WithValidator(survey.Required))
+	if err != nil {
+		return "", "", errors.New("there was an issue with the requirements file prompt")
+	}
+	log.Info(requirementsMessage)
+	log.Info(requirementsFile)
+
+	constraintsFile := ""
+	constraintsMessage := "Path to a constraints file (leave blank for none):"
+	err = survey.AskOne(&survey.Input{Message: constraintsMessage}, &constraintsFile)
+	if err != nil {
+		return "", "", errors.New("there was an issue with the constraints file prompt")
+	}
+	log.Info(constraintsMessage)
+	log.Info(constraintsFile)
+	return requirementsFile, constraintsFile, nil
+}
+
+// PromptAndInstallPythonPackages prompts users for a requirements file and installs it into every Python version
+func PromptAndInstallPythonPackages() error {
+	installChoice, err := PythonPackagesPrompt()
+	if err != nil {
+		return err
+	}
+	if !installChoice {
+		return nil
+	}
+
+	requirementsFile, constraintsFile, err := PythonRequirementsFilesPrompt()
+	if err != nil {
+		return err
+	}
+	for _, file := range []string{requirementsFile, constraintsFile} {
+		if file != "" && !system.VerifyFileExists(file) {
+			return errors.New(file + " does not exist")
+		}
+	}
+	pythonVersions, err := PythonPackageTargets([]string{"all"})
+	if err != nil {
+		return err
+	}
+	_, err = InstallPythonPackages(pythonVersions, requirementsFile, constraintsFile)
+	if err != nil {
+		return fmt.Errorf("issue installing Python packages: %w", err)
+	}
+	return nil
+}
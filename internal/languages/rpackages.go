@@ -0,0 +1,360 @@
+package languages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// defaultCRANURL is used when neither R nor Workbench has a CRAN repo configured
+const defaultCRANURL = "https://cloud.r-project.org"
+
+var rPackageName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9.]*[A-Za-z0-9]$`)
+
+// basePackages ship with R and are never installed from a repo
+var basePackages = []string{"R", "base", "compiler", "datasets", "graphics", "grDevices", "grid", "methods", "parallel", "splines", "stats", "stats4", "tcltk", "tools", "utils"}
+
+// installRPackagesScript installs the packages that are missing from the site library, or the default library if R has
+// no site library, and prints a result line for every package. The first argument is the repo to use when R has none configured
+const installRPackagesScript = `
+args <- commandArgs(trailingOnly = TRUE)
+fallback <- args[1]
+pkgs <- args[-1]
+lib <- if (length(.Library.site) > 0) .Library.site[1] else .Library
+repos <- getOption("repos")
+if (is.null(repos) || is.na(repos["CRAN"]) || repos["CRAN"] == "@CRAN@") repos <- c(CRAN = fallback)
+cat("wbi-library:", lib, "\n", sep = "")
+existing <- rownames(installed.packages(lib.loc = lib))
+missing <- setdiff(pkgs, existing)
+if (length(missing) > 0) install.packages(missing, lib = lib, repos = repos)
+installed <- installed.packages(lib.loc = lib)
+for (pkg in pkgs) {
+  status <- if (pkg %in% existing) "already installed" else if (pkg %in% rownames(installed)) "installed" else "failed"
+  version <- if (pkg %in% rownames(installed)) installed[pkg, "Version"] else ""
+  cat("wbi-result:", pkg, "\t", status, "\t", version, "\n", sep = "")
+}
+`
+
+// RPackageResult is the outcome of installing one package into one R version
+type RPackageResult struct {
+	Package string
+	Status  string
+	Version string
+}
+
+// RPackageReport is the outcome of installing the packages into one R version
+type RPackageReport struct {
+	RHome      string
+	Library    string
+	Results    []RPackageResult
+	Problem    string
+	ReportPath string
+}
+
+// Failed returns the packages that could not be installed
+func (r RPackageReport) Failed() []string {
+	var failed []string
+	for _, result := range r.Results {
+		if result.Status == "failed" {
+			failed = append(failed, result.Package)
+		}
+	}
+	return failed
+}
+
+// descriptionDependencies returns the packages in the Depends, Imports and LinkingTo fields of a DESCRIPTION file
+func descriptionDependencies(contents string) []string {
+	fields := map[string]string{}
+	var currentField string
+	for _, line := range strings.Split(contents, "\n") {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			// continuation of the previous field
+			if currentField != "" {
+				fields[currentField] += " " + strings.TrimSpace(line)
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			currentField = ""
+			continue
+		}
+		currentField = strings.TrimSpace(key)
+		fields[currentField] = strings.TrimSpace(value)
+	}
+
+	var packages []string
+	for _, field := range []string{"Depends", "Imports", "LinkingTo"} {
+		for _, dependency := range strings.Split(fields[field], ",") {
+			// drop version requirements such as (>= 1.0.0)
+			name, _, _ := strings.Cut(dependency, "(")
+			name = strings.TrimSpace(name)
+			if name != "" {
+				packages = AppendIfMissing(packages, name)
+			}
+		}
+	}
+	return packages
+}
+
+// ReadRPackagesFile reads the package names from an renv.lock file, the dependencies of a DESCRIPTION file, or a text file
+// with one package per line. Versions in an renv.lock are not pinned, the current version in the configured repo is installed
+func ReadRPackagesFile(path string) ([]string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("issue reading %s: %w", path, err)
+	}
+
+	var packages []string
+	if filepath.Base(path) == "DESCRIPTION" {
+		packages = descriptionDependencies(string(contents))
+	} else if strings.HasSuffix(path, ".lock") {
+		var lockfile struct {
+			Packages map[string]json.RawMessage `json:"Packages"`
+		}
+		err = json.Unmarshal(contents, &lockfile)
+		if err != nil {
+			return nil, fmt.Errorf("issue parsing %s as an renv.lock file: %w", path, err)
+		}
+		for name := range lockfile.Packages {
+			packages = append(packages, name)
+		}
+		sort.Strings(packages)
+	} else {
+		for _, line := range strings.Split(string(contents), "\n") {
+			line, _, _ = strings.Cut(line, "#")
+			for _, name := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\r' }) {
+				packages = AppendIfMissing(packages, name)
+			}
+		}
+	}
+
+	packages = lo.Without(packages, basePackages...)
+	if len(packages) == 0 {
+		return nil, errors.New("no packages were found in " + path)
+	}
+	err = ValidateRPackageNames(packages)
+	if err != nil {
+		return nil, fmt.Errorf("issue reading %s: %w", path, err)
+	}
+	return packages, nil
+}
+
+// ValidateRPackageNames ensures each name is a valid R package name
+func ValidateRPackageNames(packages []string) error {
+	for _, name := range packages {
+		if !rPackageName.MatchString(name) {
+			return errors.New(name + " is not a valid R package name")
+		}
+	}
+	return nil
+}
+
+// RPackageTargets returns the R homes to install packages into. "all" selects every installed R version including those in
+// /etc/rstudio/r-versions, any other value must be a version installed in /opt/R or built into the install prefix
+func RPackageTargets(rVersions []string) ([]string, error) {
+	var rHomes []string
+	for _, rVersion := range rVersions {
+		if rVersion == "all" {
+			allRHomes, err := installedRHomes()
+			if err != nil {
+				return nil, fmt.Errorf("issue finding installed R versions: %w", err)
+			}
+			for _, rHome := range allRHomes {
+				rHomes = AppendIfMissing(rHomes, rHome)
+			}
+			continue
+		}
+		rHome := config.RInstallDir(rVersion)
+		if !system.VerifyFileExists(filepath.Join(rHome, "bin", "Rscript")) {
+			return nil, errors.New("R " + rVersion + " is not installed in " + strings.Join(config.RInstallRoots(), " or "))
+		}
+		rHomes = AppendIfMissing(rHomes, rHome)
+	}
+	return rHomes, nil
+}
+
+// reportName returns the file name of the report for an R home, using the version for installs in /opt/R or the install prefix
+func reportName(rHome string, timestamp string) string {
+	label := rHome
+	for _, root := range config.RInstallRoots() {
+		label = strings.TrimPrefix(label, root+"/")
+	}
+	label = strings.Trim(strings.ReplaceAll(label, "/", "-"), "-")
+	return "wbi-r-packages-" + label + "-" + timestamp + ".log"
+}
+
+// rPackagesInstallTimeout is how long the packages are given to install into one R version, which includes compiling any
+// packages without binaries
+const rPackagesInstallTimeout = 2 * time.Hour
+
+// installRPackagesInto installs the packages into one R version and writes the full output and the results to a report file
+func installRPackagesInto(rHome string, packages []string, fallbackRepo string, reportPath string) RPackageReport {
+	report := RPackageReport{RHome: rHome, ReportPath: reportPath}
+
+	// Rscript passes the arguments after the expression to commandArgs(TRUE) itself
+	args := append([]string{"-e", installRPackagesScript, fallbackRepo}, packages...)
+	output, err := system.RunCommandWithTimeout(rPackagesInstallTimeout, filepath.Join(rHome, "bin", "Rscript"), args...)
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "wbi-library:") {
+			report.Library = strings.TrimSpace(strings.TrimPrefix(line, "wbi-library:"))
+		} else if strings.HasPrefix(line, "wbi-result:") {
+			fields := strings.Split(strings.TrimRight(strings.TrimPrefix(line, "wbi-result:"), "\r"), "\t")
+			if len(fields) == 3 {
+				report.Results = append(report.Results, RPackageResult{Package: fields[0], Status: fields[1], Version: fields[2]})
+			}
+		}
+	}
+	if errors.Is(err, system.ErrTimeout) {
+		report.Problem = "the installation " + err.Error()
+	} else if err != nil && len(report.Results) == 0 {
+		report.Problem = describeFailure(output, err)
+	}
+
+	reportLines := []string{"R: " + rHome, "Library: " + report.Library, ""}
+	for _, result := range report.Results {
+		reportLines = append(reportLines, result.Package+"\t"+result.Status+"\t"+result.Version)
+	}
+	if report.Problem != "" {
+		reportLines = append(reportLines, "Problem: "+report.Problem)
+	}
+	reportLines = append(reportLines, "", "Output:", output)
+	writeErr := os.WriteFile(reportPath, []byte(strings.Join(reportLines, "\n")), 0644)
+	if writeErr != nil {
+		log.Error("issue writing " + reportPath + ": " + writeErr.Error())
+		report.ReportPath = ""
+	}
+	return report
+}
+
+// InstallRPackages installs the packages into the site library of each R home in parallel and prints a summary.
+// An error is returned if any package could not be installed into any version
+func InstallRPackages(rHomes []string, packages []string) ([]RPackageReport, error) {
+	if len(rHomes) == 0 {
+		system.PrintAndLogInfo("\nNo R versions were found in " + strings.Join(config.RInstallRoots(), ", ") + " or /etc/rstudio/r-versions, skipping R package installation.")
+		return nil, nil
+	}
+
+	fallbackRepo := RepoURLFromReposConf()
+	if fallbackRepo == "" {
+		fallbackRepo = defaultCRANURL
+	}
+
+	system.PrintAndLogInfo(fmt.Sprintf("\nInstalling %d R packages into %d R versions: %s", len(packages), len(rHomes), strings.Join(packages, ", ")))
+	timestamp := time.Now().Format("20060102T150405")
+	reports := make([]RPackageReport, len(rHomes))
+	var wg sync.WaitGroup
+	for i, rHome := range rHomes {
+		wg.Add(1)
+		go func(i int, rHome string) {
+			defer wg.Done()
+			reports[i] = installRPackagesInto(rHome, packages, fallbackRepo, reportName(rHome, timestamp))
+		}(i, rHome)
+	}
+	wg.Wait()
+
+	PrintRPackageReports(reports)
+
+	var failedHomes []string
+	for _, report := range reports {
+		if report.Problem != "" || len(report.Failed()) > 0 {
+			failedHomes = append(failedHomes, report.RHome)
+		}
+	}
+	if len(failedHomes) > 0 {
+		return reports, errors.New("not every package could be installed into " + strings.Join(failedHomes, ", ") + ", see the reports for details")
+	}
+	return reports, nil
+}
+
+// PrintRPackageReports prints a summary of the reports as a table
+func PrintRPackageReports(reports []RPackageReport) {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "R\tLIBRARY\tSUCCEEDED\tFAILED\tREPORT")
+	for _, report := range reports {
+		failed := strings.Join(report.Failed(), ", ")
+		if report.Problem != "" {
+			failed = report.Problem
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\n", report.RHome, report.Library, len(report.Results)-len(report.Failed()), failed, report.ReportPath)
+	}
+	writer.Flush()
+	system.PrintAndLogInfo("\n" + strings.TrimSuffix(builder.String(), "\n"))
+}
+
+// RPackagesPrompt asks users if they would like to install a baseline set of R packages
+func RPackagesPrompt() (bool, error) {
+	name := false
+	messageText := "Would you like to install a baseline set of R packages into every R version from a package list or renv.lock file?"
+	prompt := &survey.Confirm{
+		Message: messageText,
+	}
+	err := survey.AskOne(prompt, &name)
+	if err != nil {
+		return false, errors.New("there was an issue with the R packages prompt")
+	}
+	log.Info(messageText)
+	log.Info(fmt.Sprintf("%v", name))
+	return name, nil
+}
+
+// RPackagesFilePrompt asks users for the path to the package list or renv.lock file
+func RPackagesFilePrompt() (string, error) {
+	target := ""
+	messageText := "Path to a file with one R package per line or an renv.lock file:"
+	prompt := &survey.Input{
+		Message: messageText,
+	}
+	err := survey.AskOne(prompt, &target, survey.WithValidator(survey.Required))
+	if err != nil {
+		return "", errors.New("there was an issue with the R packages file prompt")
+	}
+	log.Info(messageText)
+	log.Info(target)
+	return target, nil
+}
+
+// PromptAndInstallRPackages prompts users for a package list and installs it into every R version
+func PromptAndInstallRPackages() error {
+	installChoice, err := RPackagesPrompt()
+	if err != nil {
+		return err
+	}
+	if !installChoice {
+		return nil
+	}
+
+	packagesFile, err := RPackagesFilePrompt()
+	if err != nil {
+		return err
+	}
+	packages, err := ReadRPackagesFile(packagesFile)
+	if err != nil {
+		return err
+	}
+	rHomes, err := RPackageTargets([]string{"all"})
+	if err != nil {
+		return err
+	}
+	_, err = InstallRPackages(rHomes, packages)
+	if err != nil {
+		return fmt.Errorf("issue installing R packages: %w", err)
+	}
+	return nil
+}
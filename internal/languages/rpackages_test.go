@@ -0,0 +1,90 @@
+package languages
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadRPackagesFile(t *testing.T) {
+	tests := map[string]struct {
+		path     string
+		expected []string
+	}{
+		"text file with comments, separators and duplicates": {
+			path:     filepath.Join("testdata", "packages.txt"),
+			expected: []string{"tidyverse", "shiny", "rmarkdown", "data.table", "DBI"},
+		},
+		"renv.lock without base packages": {
+			path:     filepath.Join("testdata", "renv.lock"),
+			expected: []string{"cli", "rlang"},
+		},
+		"DESCRIPTION dependencies without R, base packages or suggests": {
+			path:     filepath.Join("testdata", "description", "DESCRIPTION"),
+			expected: []string{"dplyr", "httr2", "jsonlite", "Rcpp"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			packages, err := ReadRPackagesFile(tc.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, packages)
+		})
+	}
+}
+
+func TestReadRPackagesFileErrors(t *testing.T) {
+	tests := map[string]struct {
+		filename    string
+		contents    string
+		expectError string
+	}{
+		"file with only comments and base packages fails": {
+			filename:    "packages.txt",
+			contents:    "# nothing to install\nstats\nutils\n",
+			expectError: "no packages were found in",
+		},
+		"invalid package name fails": {
+			filename:    "packages.txt",
+			contents:    "shiny\nrm -rf\n",
+			expectError: "-rf is not a valid R package name",
+		},
+		"invalid renv.lock fails": {
+			filename:    "renv.lock",
+			contents:    "{\"Packages\": [",
+			expectError: "as an renv.lock file",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.filename)
+			if err := os.WriteFile(path, []byte(tc.contents), 0644); err != nil {
+				t.Fatalf("issue writing %s: %v", path, err)
+			}
+			_, err := ReadRPackagesFile(path)
+			assert.ErrorContains(t, err, tc.expectError)
+		})
+	}
+
+	_, err := ReadRPackagesFile(filepath.Join("testdata", "does-not-exist.txt"))
+	assert.ErrorContains(t, err, "issue reading")
+}
+
+func TestDescriptionDependencies(t *testing.T) {
+	contents := `Package: example
+Depends: R (>= 4.1.0)
+Imports:
+    dplyr (>= 1.1.0), httr2,
+	jsonlite
+LinkingTo: Rcpp, cpp11 (>= 0.4.0)
+Suggests: testthat
+Enhances: data.table`
+
+	// R itself is left for ReadRPackagesFile to drop with the base packages
+	assert.Equal(t, []string{"R", "dplyr", "httr2", "jsonlite", "Rcpp", "cpp11"}, descriptionDependencies(contents))
+	assert.Empty(t, descriptionDependencies("Package: example\nVersion: 0.1.0"))
+}
@@ -0,0 +1,226 @@
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+const (
+	managedBlockStart = "# BEGIN wbi managed block, changes between these lines are overwritten by wbi"
+	managedBlockEnd   = "# END wbi managed block"
+)
+
+// RSiteConfig is the site-wide configuration written to each R version's Rprofile.site and Renviron.site
+type RSiteConfig struct {
+	RepoURL    string
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	CABundle   string
+}
+
+// RepoURLFromReposConf returns the CRAN URL configured for Workbench in /etc/rstudio/repos.conf, or "" if there isn't one
+func RepoURLFromReposConf() string {
+	contents, err := os.ReadFile("/etc/rstudio/repos.conf")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "CRAN=") {
+			return strings.TrimPrefix(line, "CRAN=")
+		}
+	}
+	return ""
+}
+
+// rprofileSiteLines returns the managed lines of Rprofile.site
+func (c RSiteConfig) rprofileSiteLines() []string {
+	lines := []string{}
+	if c.RepoURL != "" {
+		lines = append(lines, "options(repos = c(CRAN = "+strconv.Quote(c.RepoURL)+"))")
+	}
+	// Posit Package Manager only serves Linux binaries to clients that identify their R version and platform
+	lines = append(lines,
+		`options(HTTPUserAgent = sprintf("R/%s R (%s)", getRversion(), paste(getRversion(), R.version["platform"], R.version["arch"], R.version["os"])))`,
+		`options(download.file.method = "libcurl")`,
+	)
+	return lines
+}
+
+// renvironSiteLines returns the managed lines of Renviron.site
+func (c RSiteConfig) renvironSiteLines() []string {
+	lines := []string{}
+	if c.HTTPProxy != "" {
+		lines = append(lines, "http_proxy="+c.HTTPProxy, "HTTP_PROXY="+c.HTTPProxy)
+	}
+	if c.HTTPSProxy != "" {
+		lines = append(lines, "https_proxy="+c.HTTPSProxy, "HTTPS_PROXY="+c.HTTPSProxy)
+	}
+	if c.NoProxy != "" {
+		lines = append(lines, "no_proxy="+c.NoProxy, "NO_PROXY="+c.NoProxy)
+	}
+	if c.CABundle != "" {
+		lines = append(lines, "CURL_CA_BUNDLE="+c.CABundle, "SSL_CERT_FILE="+c.CABundle)
+	}
+	return lines
+}
+
+// replaceManagedBlock removes any existing wbi managed block from the contents and appends a new one with the lines.
+// No block is added when there are no lines, so an existing block is removed. A block without its end marker is an
+// error rather than a reason to drop the rest of the file
+func replaceManagedBlock(contents string, lines []string) (string, error) {
+	var kept []string
+	inBlock := false
+	for _, line := range strings.Split(contents, "\n") {
+		switch {
+		case strings.TrimSpace(line) == managedBlockStart:
+			if inBlock {
+				return "", errors.New("found the start of a wbi managed block inside another one")
+			}
+			inBlock = true
+		case strings.TrimSpace(line) == managedBlockEnd:
+			inBlock = false
+		case !inBlock:
+			kept = append(kept, line)
+		}
+	}
+	if inBlock {
+		return "", errors.New("found the start of the wbi managed block without the line " + strconv.Quote(managedBlockEnd) + ", please fix or remove the block")
+	}
+	result := strings.TrimRight(strings.Join(kept, "\n"), "\n")
+
+	if len(lines) > 0 {
+		block := managedBlockStart + "\n" + strings.Join(lines, "\n") + "\n" + managedBlockEnd
+		if result != "" {
+			result = result + "\n\n"
+		}
+		result = result + block
+	}
+	if result == "" {
+		return "", nil
+	}
+	return result + "\n", nil
+}
+
+// writeManagedBlock updates the wbi managed block in a file, leaving the rest of the file untouched.
+// It returns false if the file was already up to date
+func writeManagedBlock(path string, lines []string) (bool, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("issue reading %s: %w", path, err)
+	}
+	updated, err := replaceManagedBlock(string(contents), lines)
+	if err != nil {
+		return false, fmt.Errorf("issue updating %s: %w", path, err)
+	}
+	if updated == string(contents) {
+		return false, nil
+	}
+
+	// write next to the file and rename so R never reads a partially written file
+	tmpPath := path + ".wbi-tmp"
+	err = os.WriteFile(tmpPath, []byte(updated), 0644)
+	if err != nil {
+		return false, fmt.Errorf("issue writing %s: %w", tmpPath, err)
+	}
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return false, fmt.Errorf("issue replacing %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// installedRHomes returns the directory of every R version installed in /opt/R, built from source into the install prefix
+// or registered in /etc/rstudio/r-versions
+func installedRHomes() ([]string, error) {
+	var rHomes []string
+	for _, root := range config.RInstallRoots() {
+		optVersions, err := ScanOptVersions(root)
+		if err != nil {
+			return nil, err
+		}
+		for _, optVersion := range optVersions {
+			rHomes = append(rHomes, filepath.Join(root, optVersion))
+		}
+	}
+	rVersionEntries, err := ReadRVersionsFile()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range rVersionEntries {
+		rHomes = AppendIfMissing(rHomes, strings.TrimSuffix(entry.Path, "/"))
+	}
+	return rHomes, nil
+}
+
+// RSiteConfigDirs returns the etc directory of every installed R version, including those registered in /etc/rstudio/r-versions
+func RSiteConfigDirs() ([]string, error) {
+	rHomes, err := installedRHomes()
+	if err != nil {
+		return nil, err
+	}
+
+	var etcDirs []string
+	for _, rHome := range rHomes {
+		etcDir := filepath.Join(rHome, "lib", "R", "etc")
+		if info, err := os.Stat(etcDir); err == nil && info.IsDir() {
+			etcDirs = append(etcDirs, etcDir)
+		}
+	}
+	return etcDirs, nil
+}
+
+// ConfigureRSiteFiles writes the wbi managed block into Rprofile.site and Renviron.site for every installed R version.
+// The Renviron.site block is removed when no proxy or CA bundle is given; settings outside the block are kept
+func ConfigureRSiteFiles(siteConfig RSiteConfig) error {
+	return configureRSiteFiles(siteConfig, true)
+}
+
+// ConfigureRprofileSite writes the wbi managed block into Rprofile.site for every installed R version, leaving Renviron.site untouched
+func ConfigureRprofileSite(siteConfig RSiteConfig) error {
+	return configureRSiteFiles(siteConfig, false)
+}
+
+// configureRSiteFiles writes the wbi managed block into Rprofile.site, and into Renviron.site if renviron is true, for every installed R version
+func configureRSiteFiles(siteConfig RSiteConfig, renviron bool) error {
+	etcDirs, err := RSiteConfigDirs()
+	if err != nil {
+		return fmt.Errorf("issue finding installed R versions: %w", err)
+	}
+	if len(etcDirs) == 0 {
+		system.PrintAndLogInfo("\nNo R versions were found in " + strings.Join(config.RInstallRoots(), ", ") + " or /etc/rstudio/r-versions, skipping Rprofile.site and Renviron.site.")
+		return nil
+	}
+
+	type siteFile struct {
+		name  string
+		lines []string
+	}
+	for _, etcDir := range etcDirs {
+		files := []siteFile{{"Rprofile.site", siteConfig.rprofileSiteLines()}}
+		if renviron {
+			files = append(files, siteFile{"Renviron.site", siteConfig.renvironSiteLines()})
+		}
+		for _, file := range files {
+			path := filepath.Join(etcDir, file.name)
+			changed, err := writeManagedBlock(path, file.lines)
+			if err != nil {
+				return err
+			}
+			if changed {
+				system.PrintAndLogInfo("Updated " + path)
+			} else if system.VerifyFileExists(path) {
+				system.PrintAndLogInfo(path + " is already up to date")
+			}
+		}
+	}
+	return nil
+}
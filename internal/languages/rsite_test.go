@@ -0,0 +1,90 @@
+package languages
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceManagedBlock(t *testing.T) {
+	block := managedBlockStart + "\nhttp_proxy=http://proxy:3128\n" + managedBlockEnd + "\n"
+	tests := map[string]struct {
+		contents    string
+		lines       []string
+		expected    string
+		expectError string
+	}{
+		"empty file gets a block": {
+			lines:    []string{"http_proxy=http://proxy:3128"},
+			expected: block,
+		},
+		"block is added after the existing settings": {
+			contents: "R_LIBS_SITE=/opt/site-library\n",
+			lines:    []string{"http_proxy=http://proxy:3128"},
+			expected: "R_LIBS_SITE=/opt/site-library\n\n" + block,
+		},
+		"existing block is replaced and the settings around it are kept": {
+			contents: "R_LIBS_SITE=/opt/site-library\n\n" + managedBlockStart + "\nhttp_proxy=http://old:8080\n" + managedBlockEnd + "\nTZ=UTC\n",
+			lines:    []string{"http_proxy=http://proxy:3128"},
+			expected: "R_LIBS_SITE=/opt/site-library\n\nTZ=UTC\n\n" + block,
+		},
+		"no lines remove an existing block": {
+			contents: "R_LIBS_SITE=/opt/site-library\n\n" + block,
+			expected: "R_LIBS_SITE=/opt/site-library\n",
+		},
+		"no lines and no block leave the file empty": {
+			expected: "",
+		},
+		"start without an end fails": {
+			contents:    "TZ=UTC\n" + managedBlockStart + "\nhttp_proxy=http://old:8080\nR_LIBS_SITE=/opt/site-library\n",
+			lines:       []string{"http_proxy=http://proxy:3128"},
+			expectError: "without the line",
+		},
+		"nested start fails": {
+			contents:    managedBlockStart + "\n" + block,
+			expectError: "inside another one",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			updated, err := replaceManagedBlock(tc.contents, tc.lines)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, updated)
+
+			// replacing the block again must not change anything
+			again, err := replaceManagedBlock(updated, tc.lines)
+			assert.NoError(t, err)
+			assert.Equal(t, updated, again)
+		})
+	}
+}
+
+func TestWriteManagedBlockLeavesBrokenFileUntouched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Renviron.site")
+	contents := managedBlockStart + "\nhttp_proxy=http://old:8080\nR_LIBS_SITE=/opt/site-library\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("issue writing %s: %v", path, err)
+	}
+
+	_, err := writeManagedBlock(path, nil)
+	assert.ErrorContains(t, err, "issue updating "+path)
+
+	written, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, contents, string(written))
+}
+
+func TestRenvironSiteLines(t *testing.T) {
+	assert.Empty(t, RSiteConfig{RepoURL: "https://packagemanager.posit.co/cran/latest"}.renvironSiteLines())
+	assert.Equal(t, []string{
+		"https_proxy=http://proxy:3128", "HTTPS_PROXY=http://proxy:3128",
+		"CURL_CA_BUNDLE=/etc/ssl/ca.pem", "SSL_CERT_FILE=/etc/ssl/ca.pem",
+	}, RSiteConfig{HTTPSProxy: "http://proxy:3128", CABundle: "/etc/ssl/ca.pem"}.renvironSiteLines())
+}
@@ -0,0 +1,203 @@
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/install"
+	cmdlog "github.com/sol-eng/wbi/internal/logging"
+	"github.com/sol-eng/wbi/internal/operatingsystem"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// rBuildDependencies are the packages needed to build R with its recommended packages and capabilities for each distribution family
+var rBuildDependencies = map[string][]string{
+	operatingsystem.DistroFamilyDebian: {
+		"build-essential", "gfortran", "libreadline-dev", "libx11-dev", "libxt-dev", "libpng-dev", "libjpeg-dev", "libtiff-dev",
+		"libcairo2-dev", "libpango1.0-dev", "libbz2-dev", "liblzma-dev", "libcurl4-openssl-dev", "libpcre2-dev", "libicu-dev",
+		"zlib1g-dev", "libblas-dev", "liblapack-dev", "tcl-dev", "tk-dev", "texinfo", "curl",
+	},
+	operatingsystem.DistroFamilyRHEL: {
+		"gcc", "gcc-c++", "gcc-gfortran", "make", "readline-devel", "libX11-devel", "libXt-devel", "libpng-devel", "libjpeg-turbo-devel",
+		"libtiff-devel", "cairo-devel", "pango-devel", "bzip2-devel", "xz-devel", "libcurl-devel", "pcre2-devel", "libicu-devel",
+		"zlib-devel", "blas-devel", "lapack-devel", "tcl-devel", "tk-devel", "texinfo", "curl",
+	},
+	operatingsystem.DistroFamilySUSE: {
+		"gcc", "gcc-c++", "gcc-fortran", "make", "readline-devel", "libX11-devel", "libXt-devel", "libpng16-devel", "libjpeg8-devel",
+		"libtiff-devel", "cairo-devel", "pango-devel", "libbz2-devel", "xz-devel", "libcurl-devel", "pcre2-devel", "libicu-devel",
+		"zlib-devel", "blas-devel", "lapack-devel", "tcl-devel", "tk-devel", "texinfo", "curl",
+	},
+}
+
+// RBuildDependenciesCommand returns the command that installs the packages needed to build R for a distribution family
+func RBuildDependenciesCommand(family string) (string, error) {
+	dependencies, ok := rBuildDependencies[family]
+	if !ok {
+		return "", errors.New("building R from source is not supported for the " + family + " distribution family")
+	}
+	switch family {
+	case operatingsystem.DistroFamilyDebian:
+		return "DEBIAN_FRONTEND=noninteractive apt-get update && DEBIAN_FRONTEND=noninteractive apt-get install -y " + strings.Join(dependencies, " "), nil
+	case operatingsystem.DistroFamilySUSE:
+		return "zypper --non-interactive install " + strings.Join(dependencies, " "), nil
+	default:
+		return "yum install -y " + strings.Join(dependencies, " "), nil
+	}
+}
+
+// RConfigureFlags returns the configure flags R is built with, which match Posit's builds: a shared library for Workbench and
+// RStudio, the system BLAS/LAPACK and the install prefix
+func RConfigureFlags(rHome string) []string {
+	return []string{
+		"--prefix=" + rHome,
+		"--enable-R-shlib",
+		"--enable-memory-profiling",
+		"--with-blas",
+		"--with-lapack",
+		"--with-tcltk",
+		"--with-x",
+	}
+}
+
+// rConfigureCommand returns the configure step of an R build, quoting the flags since the install prefix comes from the user
+func rConfigureCommand(rHome string) string {
+	return "./configure " + strings.Join(lo.Map(RConfigureFlags(rHome), func(flag string, _ int) string {
+		return system.ShellQuote(flag)
+	}), " ")
+}
+
+// RSourceURL returns the URL of the source tarball of an R version on a CRAN mirror, such as
+// https://cloud.r-project.org/src/base/R-4/R-4.3.2.tar.gz
+func RSourceURL(mirror string, rVersion string) string {
+	major, _, _ := strings.Cut(rVersion, ".")
+	return strings.TrimSuffix(mirror, "/") + "/src/base/R-" + major + "/R-" + rVersion + ".tar.gz"
+}
+
+// RSourceTarball returns the path to the source tarball of an R version. The source may be empty to download it from CRAN, the
+// base URL of a CRAN mirror, the URL of a tarball or the path to a local tarball
+func RSourceTarball(rVersion string, source string) (string, error) {
+	isURL := strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+	if source != "" && !isURL {
+		if !system.VerifyFileExists(source) {
+			return "", errors.New("the R source tarball " + source + " does not exist")
+		}
+		return filepath.Abs(source)
+	}
+
+	sourceURL := source
+	if source == "" {
+		sourceURL = RSourceURL(defaultCRANURL, rVersion)
+	} else if !strings.HasSuffix(source, ".tar.gz") {
+		sourceURL = RSourceURL(source, rVersion)
+	}
+	tarballPath, err := install.DownloadFile("R "+rVersion+" source", sourceURL, "R-"+rVersion+".tar.gz")
+	if err != nil {
+		return "", fmt.Errorf("issue downloading the R source tarball from %s: %w", sourceURL, err)
+	}
+	cmdlog.Info("curl -O " + sourceURL)
+	return tarballPath, nil
+}
+
+// runRBuildStep runs one step of an R source build in the build directory and appends its output to the build log
+func runRBuildStep(buildLog *os.File, buildDir string, step string, command string) error {
+	system.PrintAndLogInfo("Running " + step + ": " + command)
+	fmt.Fprintf(buildLog, "\n==> %s: %s\n", step, command)
+
+	stepCommand := exec.Command("/bin/sh", "-c", command)
+	stepCommand.Dir = buildDir
+	stepCommand.Stdout = buildLog
+	stepCommand.Stderr = buildLog
+	cmdlog.Info("cd " + buildDir + " && " + command)
+	err := stepCommand.Run()
+	if err != nil {
+		return fmt.Errorf("the %s step failed with the command '%s': %w", step, command, err)
+	}
+	return nil
+}
+
+// BuildRFromSource installs the build dependencies of the distribution family, enabling the builder repository on RHEL, then
+// configures, builds and installs an R version into the install prefix from its source tarball, optionally running
+// make check-devel first. The output of every step is captured in a log per version, and the build directory is kept when
+// a step fails
+func BuildRFromSource(rVersion string, source string, family string, check bool) error {
+	rHome := config.InstallDir("R", rVersion)
+	if system.VerifyFileExists(filepath.Join(rHome, "bin", "R")) {
+		system.PrintAndLogInfo("\nR " + rVersion + " is already installed in " + rHome + ", skipping the build.")
+		return nil
+	}
+
+	dependenciesCommand, err := RBuildDependenciesCommand(family)
+	if err != nil {
+		return err
+	}
+	// blas-devel, lapack-devel and texinfo come from the CodeReady Linux Builder, CRB or PowerTools repository
+	if family == operatingsystem.DistroFamilyRHEL {
+		err = operatingsystem.EnableBuilderRepo()
+		if err != nil {
+			return fmt.Errorf("issue enabling the repository with the R build dependencies: %w", err)
+		}
+	}
+	err = system.RunCommand(dependenciesCommand, true, 1, true)
+	if err != nil {
+		return fmt.Errorf("issue installing the R build dependencies with the command '%s': %w", dependenciesCommand, err)
+	}
+
+	tarballPath, err := RSourceTarball(rVersion, source)
+	if err != nil {
+		return err
+	}
+
+	buildDir, err := os.MkdirTemp("", "wbi-r-build-"+rVersion+"-")
+	if err != nil {
+		return fmt.Errorf("issue creating a build directory: %w", err)
+	}
+	logPath, err := filepath.Abs("wbi-r-build-" + rVersion + "-" + time.Now().Format("20060102-150405") + ".log")
+	if err != nil {
+		return fmt.Errorf("issue finding the build log path: %w", err)
+	}
+	buildLog, err := os.Create(logPath)
+	if err != nil {
+		return fmt.Errorf("issue creating the build log %s: %w", logPath, err)
+	}
+	defer buildLog.Close()
+
+	system.PrintAndLogInfo("\nBuilding R " + rVersion + " from source in " + buildDir + ", this can take a while. The output is written to " + logPath)
+	steps := [][2]string{
+		{"extract", "tar -xzf " + system.ShellQuote(tarballPath) + " --strip-components=1"},
+		{"configure", rConfigureCommand(rHome)},
+		{"build", fmt.Sprintf("make -j%d", runtime.NumCPU())},
+	}
+	if check {
+		steps = append(steps, [2]string{"check", "make check-devel"})
+	}
+	steps = append(steps, [2]string{"install", "make install"})
+	for _, step := range steps {
+		err = runRBuildStep(buildLog, buildDir, step[0], step[1])
+		if err != nil {
+			return fmt.Errorf("%w, see %s for the output and %s for the build directory", err, logPath, buildDir)
+		}
+	}
+	os.RemoveAll(buildDir)
+	err = config.SaveInstallPrefix()
+	if err != nil {
+		return fmt.Errorf("issue saving the install prefix: %w", err)
+	}
+	system.PrintAndLogInfo("\nR " + rVersion + " has been built from source and installed into " + rHome + "!")
+
+	// Workbench only finds R in /opt/R by default, so register versions installed under another prefix
+	if !config.IsDefaultInstallPrefix() && system.VerifyFileExists("/etc/rstudio") {
+		err = AddRVersionEntry(RVersionEntry{Path: rHome, Label: "R " + rVersion})
+		if err != nil {
+			return fmt.Errorf("issue registering R %s in /etc/rstudio/r-versions: %w", rVersion, err)
+		}
+	}
+	return nil
+}
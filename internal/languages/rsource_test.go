@@ -0,0 +1,162 @@
+package languages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sol-eng/wbi/internal/operatingsystem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRSourceURL(t *testing.T) {
+	tests := map[string]struct {
+		mirror   string
+		rVersion string
+		expected string
+	}{
+		"mirror without a trailing slash": {
+			mirror:   "https://cloud.r-project.org",
+			rVersion: "4.3.2",
+			expected: "https://cloud.r-project.org/src/base/R-4/R-4.3.2.tar.gz",
+		},
+		"mirror with a trailing slash": {
+			mirror:   "https://cran.rstudio.com/",
+			rVersion: "3.6.3",
+			expected: "https://cran.rstudio.com/src/base/R-3/R-3.6.3.tar.gz",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, RSourceURL(tc.mirror, tc.rVersion))
+		})
+	}
+}
+
+func TestRBuildDependenciesCommand(t *testing.T) {
+	tests := map[string]struct {
+		family         string
+		expectedPrefix string
+		expectError    string
+	}{
+		"debian family": {
+			family:         operatingsystem.DistroFamilyDebian,
+			expectedPrefix: "DEBIAN_FRONTEND=noninteractive apt-get update && DEBIAN_FRONTEND=noninteractive apt-get install -y build-essential gfortran",
+		},
+		"rhel family": {
+			family:         operatingsystem.DistroFamilyRHEL,
+			expectedPrefix: "yum install -y gcc gcc-c++ gcc-gfortran",
+		},
+		"suse family": {
+			family:         operatingsystem.DistroFamilySUSE,
+			expectedPrefix: "zypper --non-interactive install gcc gcc-c++ gcc-fortran",
+		},
+		"unknown family fails": {
+			family:      "arch",
+			expectError: "building R from source is not supported for the arch distribution family",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			command, err := RBuildDependenciesCommand(tc.family)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, command, tc.expectedPrefix)
+		})
+	}
+}
+
+func TestRConfigureCommand(t *testing.T) {
+	otherFlags := " '--enable-R-shlib' '--enable-memory-profiling' '--with-blas' '--with-lapack' '--with-tcltk' '--with-x'"
+	tests := map[string]struct {
+		rHome    string
+		expected string
+	}{
+		"prefix with a space": {
+			rHome:    "/opt/my apps/R/4.3.2",
+			expected: "./configure '--prefix=/opt/my apps/R/4.3.2'" + otherFlags,
+		},
+		"prefix with shell syntax": {
+			rHome:    "/opt/$(reboot)'/R/4.3.2",
+			expected: `./configure '--prefix=/opt/$(reboot)'\''/R/4.3.2'` + otherFlags,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, rConfigureCommand(tc.rHome))
+		})
+	}
+}
+
+func TestRSourceTarball(t *testing.T) {
+	localTarball := filepath.Join(t.TempDir(), "R-4.3.2.tar.gz")
+	assert.NoError(t, os.WriteFile(localTarball, []byte("local source"), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/src/base/R-4/R-4.3.2.tar.gz":
+			w.Write([]byte("mirror source"))
+		case "/builds/R-4.3.2.tar.gz":
+			w.Write([]byte("tarball source"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		source           string
+		expectedPath     string
+		expectedContents string
+		expectError      string
+	}{
+		"local tarball": {
+			source:           localTarball,
+			expectedPath:     localTarball,
+			expectedContents: "local source",
+		},
+		"missing local tarball fails": {
+			source:      filepath.Join(t.TempDir(), "R-4.3.2.tar.gz"),
+			expectError: "R-4.3.2.tar.gz does not exist",
+		},
+		"mirror URL": {
+			source:           server.URL + "/",
+			expectedContents: "mirror source",
+		},
+		"tarball URL": {
+			source:           server.URL + "/builds/R-4.3.2.tar.gz",
+			expectedContents: "tarball source",
+		},
+		"tarball URL that is not found fails": {
+			source:      server.URL + "/builds/R-9.9.9.tar.gz",
+			expectError: "issue downloading the R source tarball from " + server.URL + "/builds/R-9.9.9.tar.gz",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tarballPath, err := RSourceTarball("4.3.2", tc.source)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			if tc.expectedPath != "" {
+				assert.Equal(t, tc.expectedPath, tarballPath)
+			} else {
+				defer os.Remove(tarballPath)
+			}
+			contents, err := os.ReadFile(tarballPath)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedContents, string(contents))
+		})
+	}
+}
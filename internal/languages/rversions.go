@@ -0,0 +1,317 @@
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// RVersionsFilePath is the file Workbench reads additional R installs from
+const RVersionsFilePath = "/etc/rstudio/r-versions"
+
+// RVersionEntry is an R install registered in /etc/rstudio/r-versions
+type RVersionEntry struct {
+	Path    string
+	Label   string
+	Module  string
+	Script  string
+	Repo    string
+	Library string
+
+	// leading holds the blank lines and comment blocks between the previous entry and this one, and source the lines of the
+	// entry as they were read, including comments and keys wbi does not know, so rewriting the file keeps them
+	leading []string
+	source  []string
+}
+
+// RBinary returns the path to the R binary of the entry
+func (e RVersionEntry) RBinary() string {
+	return filepath.Join(e.Path, "bin", "R")
+}
+
+// fields returns the keys of the entry in the order they are written, with their values
+func (e RVersionEntry) fields() []struct{ key, value string } {
+	return []struct{ key, value string }{
+		{"Path", e.Path},
+		{"Label", e.Label},
+		{"Module", e.Module},
+		{"Script", e.Script},
+		{"Repo", e.Repo},
+		{"Library", e.Library},
+	}
+}
+
+// set updates the value of a key, returning false if the key is not one wbi knows
+func (e *RVersionEntry) set(key string, value string) bool {
+	switch strings.ToLower(key) {
+	case "path":
+		e.Path = value
+	case "label":
+		e.Label = value
+	case "module":
+		e.Module = value
+	case "script":
+		e.Script = value
+	case "repo":
+		e.Repo = value
+	case "library":
+		e.Library = value
+	default:
+		return false
+	}
+	return true
+}
+
+// isRVersionsPathLine returns true for a line that only contains the path of an R install
+func isRVersionsPathLine(line string) bool {
+	return !strings.Contains(line, ":") || strings.HasPrefix(line, "/")
+}
+
+// lines returns the entry in the r-versions file format. The lines the entry was read from are kept with only the changed
+// values updated, and values that were not in the file are added after them
+func (e RVersionEntry) lines() []string {
+	written := map[string]bool{}
+	values := map[string]string{}
+	for _, field := range e.fields() {
+		values[strings.ToLower(field.key)] = field.value
+	}
+
+	var lines []string
+	for _, line := range e.source {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			lines = append(lines, line)
+			continue
+		}
+		if isRVersionsPathLine(trimmed) {
+			// a bare path can only stand alone, so an entry that gained other keys is written with a Path key
+			onlyPath := e.Label == "" && e.Module == "" && e.Script == "" && e.Repo == "" && e.Library == ""
+			switch {
+			case onlyPath && trimmed == e.Path:
+				lines = append(lines, line)
+			case onlyPath:
+				lines = append(lines, e.Path)
+			default:
+				lines = append(lines, "Path: "+e.Path)
+			}
+			written["path"] = true
+			continue
+		}
+		key, value, _ := strings.Cut(trimmed, ":")
+		name := strings.ToLower(strings.TrimSpace(key))
+		current, known := values[name]
+		switch {
+		case !known || strings.TrimSpace(value) == current:
+			lines = append(lines, line)
+		case current != "" && !written[name]:
+			lines = append(lines, strings.TrimSpace(key)+": "+current)
+		}
+		written[name] = true
+	}
+
+	for _, field := range e.fields() {
+		if field.value != "" && !written[strings.ToLower(field.key)] {
+			lines = append(lines, field.key+": "+field.value)
+		}
+	}
+	return lines
+}
+
+// parseRVersions parses the r-versions format. Entries are blocks of "Key: value" lines separated by blank lines,
+// and a line with only a path is treated as an entry with just a Path. The lines after the last entry are returned
+// separately so they can be written back
+func parseRVersions(contents string) ([]RVersionEntry, []string) {
+	entries := []RVersionEntry{}
+	var pending []string
+	var current *RVersionEntry
+	if contents == "" {
+		return entries, nil
+	}
+
+	// startEntry begins an entry; the comments directly above it belong to the entry, everything before them is leading
+	startEntry := func() *RVersionEntry {
+		split := len(pending)
+		for split > 0 && strings.TrimSpace(pending[split-1]) != "" {
+			split--
+		}
+		entries = append(entries, RVersionEntry{leading: pending[:split], source: pending[split:]})
+		pending = nil
+		return &entries[len(entries)-1]
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(contents, "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			current = nil
+			pending = append(pending, line)
+		case strings.HasPrefix(trimmed, "#"):
+			if current != nil {
+				current.source = append(current.source, line)
+			} else {
+				pending = append(pending, line)
+			}
+		case isRVersionsPathLine(trimmed):
+			entry := startEntry()
+			entry.Path = trimmed
+			entry.source = append(entry.source, line)
+			current = nil
+		default:
+			if current == nil {
+				current = startEntry()
+			}
+			key, value, _ := strings.Cut(trimmed, ":")
+			current.set(strings.TrimSpace(key), strings.TrimSpace(value))
+			current.source = append(current.source, line)
+		}
+	}
+	return entries, pending
+}
+
+// formatRVersions returns the lines of the r-versions file, separating entries that were not read from the file with a blank line
+func formatRVersions(entries []RVersionEntry, trailing []string) []string {
+	var lines []string
+	for _, entry := range entries {
+		if entry.leading == nil && entry.source == nil && len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, entry.leading...)
+		lines = append(lines, entry.lines()...)
+	}
+	return append(lines, trailing...)
+}
+
+// readRVersions reads the entries in /etc/rstudio/r-versions and the lines after them, returning nothing if the file doesn't exist
+func readRVersions() ([]RVersionEntry, []string, error) {
+	contents, err := os.ReadFile(RVersionsFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []RVersionEntry{}, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("issue reading %s: %w", RVersionsFilePath, err)
+	}
+	entries, trailing := parseRVersions(string(contents))
+	return entries, trailing, nil
+}
+
+// ReadRVersionsFile reads the entries in /etc/rstudio/r-versions, returning no entries if the file doesn't exist
+func ReadRVersionsFile() ([]RVersionEntry, error) {
+	entries, _, err := readRVersions()
+	return entries, err
+}
+
+// writeRVersionsFile replaces /etc/rstudio/r-versions with the entries and trailing lines, backing up the original file first
+func writeRVersionsFile(entries []RVersionEntry, trailing []string) error {
+	err := system.MoveToBackup(RVersionsFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = system.WriteStrings(formatRVersions(entries, trailing), RVersionsFilePath, 0644, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// removeRVersionEntry removes the entry at index i, keeping the comments that were above it but not attached to it
+func removeRVersionEntry(entries []RVersionEntry, trailing []string, i int) ([]RVersionEntry, []string) {
+	leading := entries[i].leading
+	remaining := append(append([]RVersionEntry{}, entries[:i]...), entries[i+1:]...)
+	if i < len(remaining) {
+		remaining[i].leading = mergeLeadingLines(leading, remaining[i].leading)
+		return remaining, trailing
+	}
+	return remaining, mergeLeadingLines(leading, trailing)
+}
+
+// mergeLeadingLines joins two runs of lines that used to be separated by an entry, without doubling the blank line between them
+func mergeLeadingLines(first []string, second []string) []string {
+	if len(first) > 0 && len(second) > 0 && strings.TrimSpace(first[len(first)-1]) == "" && strings.TrimSpace(second[0]) == "" {
+		second = second[1:]
+	}
+	return append(append([]string{}, first...), second...)
+}
+
+// AddRVersionEntry registers an R install in /etc/rstudio/r-versions, replacing any existing entry with the same path
+func AddRVersionEntry(newEntry RVersionEntry) error {
+	newEntry.Path = strings.TrimSuffix(newEntry.Path, "/")
+	if !system.VerifyFileExists(newEntry.RBinary()) {
+		// an environment module may be what makes the install available, so only warn when one is given
+		if newEntry.Module == "" {
+			return errors.New(newEntry.RBinary() + " does not exist, please provide the directory R is installed in")
+		}
+		system.PrintAndLogInfo("Warning: " + newEntry.RBinary() + " does not exist, it must be available once the module " + newEntry.Module + " is loaded")
+	}
+
+	entries, trailing, err := readRVersions()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i, entry := range entries {
+		if strings.TrimSuffix(entry.Path, "/") == newEntry.Path {
+			// keep the comments and unknown keys of the entry being replaced
+			newEntry.leading, newEntry.source = entry.leading, entry.source
+			entries[i] = newEntry
+			replaced = true
+		}
+	}
+	if !replaced {
+		entries = append(entries, newEntry)
+	}
+
+	err = writeRVersionsFile(entries, trailing)
+	if err != nil {
+		return fmt.Errorf("issue writing %s: %w", RVersionsFilePath, err)
+	}
+	system.PrintAndLogInfo("\n" + newEntry.Path + " has been registered in " + RVersionsFilePath + ". Restart Workbench for the change to take effect.")
+	return nil
+}
+
+// RemoveRVersionEntry removes the entry with the given path from /etc/rstudio/r-versions
+func RemoveRVersionEntry(path string) error {
+	path = strings.TrimSuffix(path, "/")
+	entries, trailing, err := readRVersions()
+	if err != nil {
+		return err
+	}
+	removed := false
+	for i := len(entries) - 1; i >= 0; i-- {
+		if strings.TrimSuffix(entries[i].Path, "/") == path {
+			entries, trailing = removeRVersionEntry(entries, trailing, i)
+			removed = true
+		}
+	}
+	if !removed {
+		return errors.New(path + " is not registered in " + RVersionsFilePath)
+	}
+
+	err = writeRVersionsFile(entries, trailing)
+	if err != nil {
+		return fmt.Errorf("issue writing %s: %w", RVersionsFilePath, err)
+	}
+	system.PrintAndLogInfo("\n" + path + " has been removed from " + RVersionsFilePath + ". Restart Workbench for the change to take effect.")
+	return nil
+}
+
+// PrintRVersionEntries prints the entries in /etc/rstudio/r-versions as a table
+func PrintRVersionEntries(entries []RVersionEntry) {
+	if len(entries) == 0 {
+		system.PrintAndLogInfo("No R versions are registered in " + RVersionsFilePath + ".")
+		return
+	}
+
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "PATH\tLABEL\tMODULE\tR EXISTS")
+	for _, entry := range entries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%t\n", entry.Path, entry.Label, entry.Module, system.VerifyFileExists(entry.RBinary()))
+	}
+	writer.Flush()
+	system.PrintAndLogInfo(strings.TrimSuffix(builder.String(), "\n"))
+}
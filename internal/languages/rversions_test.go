@@ -0,0 +1,110 @@
+package languages
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRVersionsRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		contents string
+	}{
+		"entries with comments and unknown keys": {
+			contents: `# R versions available to Workbench
+# managed by the platform team
+
+# the default for new projects
+Path: /opt/R/4.3.2
+Label: R 4.3.2
+# RStudio specific setting wbi does not manage
+Environment: R_LIBS_SITE=/opt/site-library
+
+Path: /opt/R/4.2.3
+Module: r/4.2.3
+`,
+		},
+		"bare paths and a trailing comment": {
+			contents: `/opt/R/4.3.2
+/opt/R/4.2.3
+
+# end of file`,
+		},
+		"keys in a different order and case": {
+			contents: `label: Old R
+path: /usr/lib/R
+`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			entries, trailing := parseRVersions(tc.contents)
+			formatted := strings.Join(formatRVersions(entries, trailing), "\n") + "\n"
+			assert.Equal(t, strings.TrimSuffix(tc.contents, "\n")+"\n", formatted)
+		})
+	}
+}
+
+func TestParseRVersions(t *testing.T) {
+	entries, _ := parseRVersions(`# header
+/opt/R/4.1.3
+
+Path: /opt/R/4.3.2
+Label: R 4.3.2
+Environment: R_LIBS_SITE=/opt/site-library
+Repo: /etc/rstudio/repos-4.3.conf
+`)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "/opt/R/4.1.3", entries[0].Path)
+		assert.Equal(t, "/opt/R/4.3.2", entries[1].Path)
+		assert.Equal(t, "R 4.3.2", entries[1].Label)
+		assert.Equal(t, "/etc/rstudio/repos-4.3.conf", entries[1].Repo)
+	}
+}
+
+func TestRVersionEntryLinesUpdatesValuesInPlace(t *testing.T) {
+	entries, trailing := parseRVersions(`Path: /opt/R/4.3.2
+# shown in the version picker
+Label: R 4.3.2
+Environment: R_LIBS_SITE=/opt/site-library
+Module: r/4.3.2
+
+/opt/R/4.2.3
+`)
+	entries[0].Label = "R 4.3.2 (default)"
+	entries[0].Module = ""
+	entries[0].Library = "/opt/R/library"
+	entries[1].Label = "R 4.2.3"
+
+	expected := `Path: /opt/R/4.3.2
+# shown in the version picker
+Label: R 4.3.2 (default)
+Environment: R_LIBS_SITE=/opt/site-library
+Library: /opt/R/library
+
+Path: /opt/R/4.2.3
+Label: R 4.2.3`
+	assert.Equal(t, expected, strings.Join(formatRVersions(entries, trailing), "\n"))
+}
+
+func TestFormatRVersionsSeparatesNewEntries(t *testing.T) {
+	entries, trailing := parseRVersions("# header\nPath: /opt/R/4.3.2\n")
+	entries = append(entries, RVersionEntry{Path: "/opt/R/4.4.0", Label: "R 4.4.0"})
+
+	assert.Equal(t, "# header\nPath: /opt/R/4.3.2\n\nPath: /opt/R/4.4.0\nLabel: R 4.4.0", strings.Join(formatRVersions(entries, trailing), "\n"))
+}
+
+func TestRemoveRVersionEntryKeepsUnattachedComments(t *testing.T) {
+	entries, trailing := parseRVersions(`# R versions available to Workbench
+
+# retired at the end of the year
+Path: /opt/R/4.1.3
+
+Path: /opt/R/4.3.2
+`)
+	entries, trailing = removeRVersionEntry(entries, trailing, 0)
+
+	assert.Equal(t, "# R versions available to Workbench\n\nPath: /opt/R/4.3.2", strings.Join(formatRVersions(entries, trailing), "\n"))
+}
@@ -0,0 +1,276 @@
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// verifyTimeout is how long a language installation is given to finish its checks, which download packages
+const verifyTimeout = 5 * time.Minute
+
+// verifyRPackage is installed into a temporary library to check R can reach its repo. It is small and has no dependencies
+const verifyRPackage = "R6"
+
+// verifyPythonPackage is downloaded to check pip can reach its index. It is small and has no dependencies
+const verifyPythonPackage = "six"
+
+// verifyRScript checks R's graphics, networking and ICU capabilities, installs a package into a temporary library and checks
+// BLAS/LAPACK with a small calculation, printing a check line for each. The first argument is the repo to use when R has none
+// configured and the second is the package to install
+const verifyRScript = `
+args <- commandArgs(trailingOnly = TRUE)
+fallback <- args[1]
+pkg <- args[2]
+check <- function(name, ok, detail = "") {
+  cat("wbi-check:", name, "\t", if (isTRUE(ok)) "ok" else "failed", "\t", detail, "\n", sep = "")
+}
+caps <- capabilities()
+for (cap in c("png", "cairo", "libcurl", "ICU")) check(cap, cap %in% names(caps) && caps[[cap]])
+repos <- getOption("repos")
+if (is.null(repos) || is.na(repos["CRAN"]) || repos["CRAN"] == "@CRAN@") repos <- c(CRAN = fallback)
+lib <- tempfile("wbi-verify-")
+dir.create(lib)
+installed <- tryCatch({
+  install.packages(pkg, lib = lib, repos = repos, quiet = TRUE)
+  requireNamespace(pkg, lib.loc = lib, quietly = TRUE)
+}, error = function(e) FALSE)
+check("package install", installed, paste(pkg, "from", repos[["CRAN"]]))
+unlink(lib, recursive = TRUE)
+m <- matrix(c(2, 1, 1, 3), 2)
+solved <- tryCatch(isTRUE(all.equal(solve(m) %*% m, diag(2))), error = function(e) FALSE)
+libraries <- tryCatch(c(extSoftVersion()[["BLAS"]], La_library()), error = function(e) character())
+check("blas/lapack", solved, paste(unique(libraries[nzchar(libraries)]), collapse = " "))
+`
+
+// verifyPythonImports imports the standard library modules that are missing when Python is built without their OS libraries
+const verifyPythonImports = "import ssl, sqlite3, venv; print(ssl.OPENSSL_VERSION + ', SQLite ' + sqlite3.sqlite_version)"
+
+// VerificationCheck is the outcome of one functional check of a language installation
+type VerificationCheck struct {
+	Name   string
+	Status string
+	Detail string
+}
+
+// VerificationReport is the outcome of the functional checks of one R or Python installation
+type VerificationReport struct {
+	Language string
+	Path     string
+	Checks   []VerificationCheck
+	Problem  string
+}
+
+// Failed returns the checks that did not pass
+func (r VerificationReport) Failed() []string {
+	var failed []string
+	for _, check := range r.Checks {
+		if check.Status != "ok" {
+			failed = append(failed, check.Name)
+		}
+	}
+	return failed
+}
+
+// parseRChecks returns the checks reported by the wbi-check: lines verifyRScript prints, in the format name\tstatus\tdetail
+func parseRChecks(output string) []VerificationCheck {
+	var checks []VerificationCheck
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, "wbi-check:") {
+			continue
+		}
+		fields := strings.Split(strings.TrimRight(strings.TrimPrefix(line, "wbi-check:"), "\r"), "\t")
+		if len(fields) == 3 {
+			checks = append(checks, VerificationCheck{Name: fields[0], Status: fields[1], Detail: fields[2]})
+		}
+	}
+	return checks
+}
+
+// verifyR runs the functional checks against the R installed in rHome
+func verifyR(rHome string, fallbackRepo string) VerificationReport {
+	report := VerificationReport{Language: "R", Path: rHome}
+
+	// Rscript passes the arguments after the expression to commandArgs(TRUE) itself
+	output, err := system.RunCommandWithTimeout(verifyTimeout, filepath.Join(rHome, "bin", "Rscript"), "-e", verifyRScript, fallbackRepo, verifyRPackage)
+	report.Checks = parseRChecks(output)
+	if err == nil && len(report.Checks) == 0 {
+		err = errors.New("Rscript did not report any checks")
+	}
+	if err != nil {
+		report.Problem = describeFailure(output, err)
+		log.Error("R verification output for " + rHome + ":\n" + output)
+	}
+	return report
+}
+
+// verifyPython runs the functional checks against a Python version installed in /opt/python
+func verifyPython(pythonVersion string, indexURL string) VerificationReport {
+	pythonPath := pythonBinary(pythonVersion)
+	report := VerificationReport{Language: "Python", Path: config.DefaultInstallDir("python", pythonVersion)}
+
+	output, err := runWithTimeout(pythonPath, "-c", verifyPythonImports)
+	if err != nil {
+		report.Checks = append(report.Checks, VerificationCheck{Name: "ssl, sqlite3, venv", Status: "failed", Detail: describeFailure(output, err)})
+	} else {
+		report.Checks = append(report.Checks, VerificationCheck{Name: "ssl, sqlite3, venv", Status: "ok", Detail: strings.TrimSpace(output)})
+	}
+
+	tempDir, err := os.MkdirTemp("", "wbi-verify-")
+	if err != nil {
+		report.Problem = fmt.Sprintf("issue creating a temporary directory: %s", err)
+		return report
+	}
+	defer os.RemoveAll(tempDir)
+
+	// download with the venv's pip so a working venv is also a usable one, falling back to the base install's pip
+	pipPython := pythonPath
+	venvPath := filepath.Join(tempDir, "venv")
+	output, err = system.RunCommandWithTimeout(verifyTimeout, pythonPath, "-m", "venv", venvPath)
+	if err != nil {
+		report.Checks = append(report.Checks, VerificationCheck{Name: "venv", Status: "failed", Detail: describeFailure(output, err)})
+	} else {
+		report.Checks = append(report.Checks, VerificationCheck{Name: "venv", Status: "ok"})
+		pipPython = filepath.Join(venvPath, "bin", "python")
+	}
+
+	downloadArgs := []string{"-m", "pip", "download", "--no-deps", "--disable-pip-version-check", "-d", filepath.Join(tempDir, "downloads")}
+	indexDetail := verifyPythonPackage + " from the default index"
+	if indexURL != "" {
+		downloadArgs = append(downloadArgs, "--index-url", indexURL)
+		indexDetail = verifyPythonPackage + " from " + indexURL
+	}
+	output, err = system.RunCommandWithTimeout(verifyTimeout, pipPython, append(downloadArgs, verifyPythonPackage)...)
+	if err != nil {
+		report.Checks = append(report.Checks, VerificationCheck{Name: "pip download", Status: "failed", Detail: describeFailure(output, err)})
+	} else {
+		report.Checks = append(report.Checks, VerificationCheck{Name: "pip download", Status: "ok", Detail: indexDetail})
+	}
+	return report
+}
+
+// VerifyRInstallations runs the functional checks against each R home and prints the results. An error is returned if any check failed
+func VerifyRInstallations(rHomes []string) ([]VerificationReport, error) {
+	if len(rHomes) == 0 {
+		system.PrintAndLogInfo("\nNo R versions were found in " + strings.Join(config.RInstallRoots(), ", ") + " or /etc/rstudio/r-versions, skipping R verification.")
+		return nil, nil
+	}
+
+	fallbackRepo := RepoURLFromReposConf()
+	if fallbackRepo == "" {
+		fallbackRepo = defaultCRANURL
+	}
+
+	system.PrintAndLogInfo(fmt.Sprintf("\nVerifying %d R versions, this installs %s into a temporary library...", len(rHomes), verifyRPackage))
+	var reports []VerificationReport
+	for _, rHome := range rHomes {
+		reports = append(reports, verifyR(rHome, fallbackRepo))
+	}
+	PrintVerificationReports(reports)
+	return reports, verificationError(reports)
+}
+
+// VerifyPythonInstallations runs the functional checks against each Python version in /opt/python through the index in
+// /etc/pip.conf and prints the results. An error is returned if any check failed
+func VerifyPythonInstallations(pythonVersions []string) ([]VerificationReport, error) {
+	if len(pythonVersions) == 0 {
+		system.PrintAndLogInfo("\nNo Python versions were found in " + config.DefaultInstallRoot("python") + ", skipping Python verification.")
+		return nil, nil
+	}
+
+	system.PrintAndLogInfo(fmt.Sprintf("\nVerifying %d Python versions...", len(pythonVersions)))
+	indexURL := PipIndexURL()
+	var reports []VerificationReport
+	for _, pythonVersion := range pythonVersions {
+		reports = append(reports, verifyPython(pythonVersion, indexURL))
+	}
+	PrintVerificationReports(reports)
+	return reports, verificationError(reports)
+}
+
+// verificationError returns an error naming the installations that failed a check, or nil if every check passed
+func verificationError(reports []VerificationReport) error {
+	var failedPaths []string
+	for _, report := range reports {
+		if report.Problem != "" || len(report.Failed()) > 0 {
+			failedPaths = append(failedPaths, report.Path)
+		}
+	}
+	if len(failedPaths) > 0 {
+		return errors.New("verification failed for " + strings.Join(failedPaths, ", "))
+	}
+	return nil
+}
+
+// PrintVerificationReports prints every check of the reports as a table
+func PrintVerificationReports(reports []VerificationReport) {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "LANGUAGE\tPATH\tCHECK\tSTATUS\tDETAIL")
+	for _, report := range reports {
+		for _, check := range report.Checks {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", report.Language, report.Path, check.Name, check.Status, check.Detail)
+		}
+		if report.Problem != "" {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", report.Language, report.Path, "-", "failed", report.Problem)
+		}
+	}
+	writer.Flush()
+	system.PrintAndLogInfo("\n" + strings.TrimSuffix(builder.String(), "\n"))
+}
+
+// VerifyLanguagesPrompt asks users if they would like to run functional checks against the installed R and Python versions
+func VerifyLanguagesPrompt() (bool, error) {
+	name := false
+	messageText := "Would you like to verify the installed R and Python versions work (capabilities, package installs and libraries)?"
+	prompt := &survey.Confirm{
+		Message: messageText,
+	}
+	err := survey.AskOne(prompt, &name)
+	if err != nil {
+		return false, errors.New("there was an issue with the verify languages prompt")
+	}
+	log.Info(messageText)
+	log.Info(fmt.Sprintf("%v", name))
+	return name, nil
+}
+
+// PromptAndVerifyLanguages prompts users and runs the functional checks against every installed R and Python version
+func PromptAndVerifyLanguages() error {
+	verifyChoice, err := VerifyLanguagesPrompt()
+	if err != nil {
+		return err
+	}
+	if !verifyChoice {
+		return nil
+	}
+
+	rHomes, err := RPackageTargets([]string{"all"})
+	if err != nil {
+		return err
+	}
+	_, rErr := VerifyRInstallations(rHomes)
+
+	pythonVersions, err := PythonPackageTargets([]string{"all"})
+	if err != nil {
+		return err
+	}
+	_, pythonErr := VerifyPythonInstallations(pythonVersions)
+
+	if rErr != nil {
+		return fmt.Errorf("issue verifying R: %w", rErr)
+	}
+	if pythonErr != nil {
+		return fmt.Errorf("issue verifying Python: %w", pythonErr)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package languages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRChecks(t *testing.T) {
+	tests := map[string]struct {
+		output   string
+		expected []VerificationCheck
+	}{
+		"every check with the install output in between": {
+			output: "wbi-check:png\tok\t\n" +
+				"wbi-check:cairo\tfailed\t\n" +
+				"trying URL 'https://packagemanager.posit.co/cran/__linux__/jammy/latest/src/contrib/R6_2.5.1.tar.gz'\n" +
+				"wbi-check:package install\tok\tR6 from https://packagemanager.posit.co/cran/__linux__/jammy/latest\r\n" +
+				"wbi-check:blas/lapack\tok\t/usr/lib/x86_64-linux-gnu/openblas-pthread/libblas.so.3\n",
+			expected: []VerificationCheck{
+				{Name: "png", Status: "ok"},
+				{Name: "cairo", Status: "failed"},
+				{Name: "package install", Status: "ok", Detail: "R6 from https://packagemanager.posit.co/cran/__linux__/jammy/latest"},
+				{Name: "blas/lapack", Status: "ok", Detail: "/usr/lib/x86_64-linux-gnu/openblas-pthread/libblas.so.3"},
+			},
+		},
+		"malformed check lines are skipped": {
+			output:   "wbi-check:png ok\nwbi-check:ICU\tok\t\textra\n  wbi-check:cairo\tok\t\n",
+			expected: nil,
+		},
+		"no output": {
+			output:   "",
+			expected: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseRChecks(tc.output))
+		})
+	}
+}
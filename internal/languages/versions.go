@@ -3,9 +3,14 @@ package languages
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/go-version"
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/system"
 )
 
 func SortVersionsDesc(versions []*version.Version) []*version.Version {
@@ -34,76 +39,195 @@ func removeElements(originalElements []string, elementsToRemove []string) ([]str
 	return originalElements, nil
 }
 
-func removeNewerVersions(versions []*version.Version, maxVersion string) ([]*version.Version, error) {
-	maxV, err := version.NewVersion(maxVersion)
-	if err != nil {
-		return nil, err
+func ConvertStringSliceToVersionSlice(strings []string) ([]*version.Version, error) {
+
+	versions := make([]*version.Version, len(strings))
+	for i, raw := range strings {
+		v, err := version.NewVersion(raw)
+		if err != nil {
+			return []*version.Version{}, fmt.Errorf("failed to parse version %s: %w", raw, err)
+		}
+		versions[i] = v
 	}
-	var result []*version.Version
+
+	return versions, nil
+}
+
+func ConvertVersionSliceToStringSlice(versions []*version.Version) []string {
+
+	var versionStrings []string
 	for _, v := range versions {
-		if v.Segments()[0] == maxV.Segments()[0] && v.Segments()[1] == maxV.Segments()[1] && v.Segments()[2] < maxV.Segments()[2] {
-			// Version is newer across Major.Minor versions
-			result = append(result, v)
-		} else if v.Segments()[0] != maxV.Segments()[0] || v.Segments()[1] != maxV.Segments()[1] {
-			// Version is newer only within the same Major.Minor version line
-			result = append(result, v)
-		}
+		versionStrings = append(versionStrings, v.String())
 	}
-	return result, nil
+	return versionStrings
 }
 
-func removeOlderVersions(versions []*version.Version, minVersion string) ([]*version.Version, error) {
-	minV, err := version.NewVersion(minVersion)
-	if err != nil {
-		return nil, err
+// VersionResolution records which exact versions a --version value resolved to
+type VersionResolution struct {
+	Spec     string
+	Versions []string
+}
+
+var latestMinorLines = regexp.MustCompile(`^latest-(\d+)$`)
+var partialVersion = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+func isConstraint(spec string) bool {
+	return strings.ContainsAny(spec[:1], "<>=!~")
+}
+
+// groupVersionSpecs joins constraint ranges such as ">=4.1,<4.3" back together after the flag values are split on commas.
+// Consecutive constraints form one range, and a semicolon starts a new one, such as ">=4.1,<4.3;>=3.6,<3.7"
+func groupVersionSpecs(specs []string) []string {
+	var grouped []string
+	startRange := true
+	for _, spec := range specs {
+		for i, piece := range strings.Split(spec, ";") {
+			if i > 0 {
+				startRange = true
+			}
+			piece = strings.TrimSpace(piece)
+			if piece == "" {
+				continue
+			}
+			if !startRange && isConstraint(piece) && isConstraint(grouped[len(grouped)-1]) {
+				grouped[len(grouped)-1] = grouped[len(grouped)-1] + "," + piece
+			} else {
+				grouped = append(grouped, piece)
+			}
+			startRange = false
+		}
 	}
-	var result []*version.Version
-	for _, v := range versions {
-		if v.Segments()[0] == minV.Segments()[0] && v.Segments()[1] == minV.Segments()[1] && v.Segments()[2] > minV.Segments()[2] {
-			// Version is newer across Major.Minor versions
-			result = append(result, v)
-		} else if v.Segments()[0] != minV.Segments()[0] || v.Segments()[1] != minV.Segments()[1] {
-			// Version is newer only within the same Major.Minor version line
-			result = append(result, v)
+	return grouped
+}
+
+// verifyRangeBounds returns an error if a range has more than one lower or upper bound, which happens when two ranges are
+// written without the semicolon between them and would otherwise match nothing
+func verifyRangeBounds(spec string) error {
+	var lower, upper int
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, ">") {
+			lower++
+		} else if strings.HasPrefix(part, "<") {
+			upper++
 		}
 	}
-	return result, nil
+	if lower > 1 || upper > 1 {
+		return errors.New("the range " + spec + " has more than one lower or upper bound, separate ranges with a semicolon such as >=4.1,<4.3;>=3.6,<3.7")
+	}
+	return nil
 }
 
-func removeSpecificVersions(versions []*version.Version, specificVersion string) ([]*version.Version, error) {
-	specificV, err := version.NewVersion(specificVersion)
-	if err != nil {
-		return nil, err
+// newestPerMinorLine returns the newest patch version of each minor line, newest first
+func newestPerMinorLine(versions []*version.Version) []*version.Version {
+	var newest []*version.Version
+	seen := map[string]bool{}
+	for _, v := range SortVersionsDesc(versions) {
+		line := minorLine(v)
+		if !seen[line] {
+			seen[line] = true
+			newest = append(newest, v)
+		}
 	}
-	var result []*version.Version
-	for _, v := range versions {
-		if v.Segments()[0] != specificV.Segments()[0] || v.Segments()[1] != specificV.Segments()[1] || v.Segments()[2] != specificV.Segments()[2] {
-			// Version is newer across Major.Minor versions
-			result = append(result, v)
+	return newest
+}
+
+// resolveVersionSpec resolves a single --version value against the available versions
+func resolveVersionSpec(language string, spec string, available []*version.Version) ([]*version.Version, error) {
+	lowerSpec := strings.ToLower(spec)
+	switch {
+	case lowerSpec == "latest":
+		return available[:1], nil
+	case latestMinorLines.MatchString(lowerSpec):
+		count, err := strconv.Atoi(latestMinorLines.FindStringSubmatch(lowerSpec)[1])
+		if err != nil || count < 1 {
+			return nil, errors.New("invalid version " + spec + ", latest-N requires N to be 1 or more")
+		}
+		newest := newestPerMinorLine(available)
+		if count > len(newest) {
+			count = len(newest)
 		}
+		return newest[:count], nil
+	case isConstraint(spec):
+		if err := verifyRangeBounds(spec); err != nil {
+			return nil, err
+		}
+		constraint, err := version.NewConstraint(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %s: %w", spec, err)
+		}
+		var matching []*version.Version
+		for _, v := range available {
+			if constraint.Check(v) {
+				matching = append(matching, v)
+			}
+		}
+		if len(matching) == 0 {
+			return nil, errors.New("no valid " + language + " versions match the range " + spec)
+		}
+		// a range installs the newest patch of each minor line it covers
+		return newestPerMinorLine(matching), nil
+	case partialVersion.MatchString(spec):
+		// a major or major.minor version resolves to the newest release in that line
+		for _, v := range available {
+			if v.Original() == spec || strings.HasPrefix(v.Original(), spec+".") {
+				return []*version.Version{v}, nil
+			}
+		}
+		return nil, errors.New("version " + spec + " does not match any valid " + language + " version")
+	default:
+		for _, v := range available {
+			if v.Original() == spec {
+				return []*version.Version{v}, nil
+			}
+		}
+		return nil, errors.New("version " + spec + " is not a valid " + language + " version")
 	}
-	return result, nil
 }
 
-func ConvertStringSliceToVersionSlice(strings []string) ([]*version.Version, error) {
+// ResolveVersions resolves exact versions, major.minor versions, latest, latest-N and constraint ranges against the available versions
+func ResolveVersions(language string, specs []string, available []string) ([]VersionResolution, error) {
+	availableVersions, err := ConvertStringSliceToVersionSlice(available)
+	if err != nil {
+		return nil, fmt.Errorf("issue converting string slice to version slice: %w", err)
+	}
+	if len(availableVersions) == 0 {
+		return nil, errors.New("no valid " + language + " versions are available")
+	}
+	availableVersions = SortVersionsDesc(availableVersions)
 
-	versions := make([]*version.Version, len(strings))
-	for i, raw := range strings {
-		v, err := version.NewVersion(raw)
+	var resolutions []VersionResolution
+	for _, spec := range groupVersionSpecs(specs) {
+		resolved, err := resolveVersionSpec(language, spec, availableVersions)
 		if err != nil {
-			return []*version.Version{}, fmt.Errorf("failed to parse version %s: %w", raw, err)
+			return nil, err
 		}
-		versions[i] = v
+		resolutions = append(resolutions, VersionResolution{
+			Spec: spec,
+			Versions: lo.Map(resolved, func(v *version.Version, _ int) string {
+				return v.Original()
+			}),
+		})
 	}
-
-	return versions, nil
+	return resolutions, nil
 }
 
-func ConvertVersionSliceToStringSlice(versions []*version.Version) []string {
+// ResolvedVersions flattens the resolutions into a list of unique versions in the order they were requested
+func ResolvedVersions(resolutions []VersionResolution) []string {
+	var versions []string
+	for _, resolution := range resolutions {
+		versions = append(versions, resolution.Versions...)
+	}
+	return lo.Uniq(versions)
+}
 
-	var versionStrings []string
-	for _, v := range versions {
-		versionStrings = append(versionStrings, v.String())
+// PrintVersionResolutions prints what each --version value resolved to so the exact versions can be pinned
+func PrintVersionResolutions(language string, resolutions []VersionResolution) {
+	for _, resolution := range resolutions {
+		resolvedVersions := strings.Join(resolution.Versions, ", ")
+		if resolution.Spec == resolvedVersions {
+			continue
+		}
+		system.PrintAndLogInfo(language + " version " + resolution.Spec + " resolved to " + resolvedVersions)
 	}
-	return versionStrings
 }
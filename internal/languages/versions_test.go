@@ -0,0 +1,145 @@
+package languages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupVersionSpecs(t *testing.T) {
+	tests := map[string]struct {
+		specs    []string
+		expected []string
+	}{
+		"exact versions stay separate": {
+			specs:    []string{"4.3.2", "4.2.3"},
+			expected: []string{"4.3.2", "4.2.3"},
+		},
+		"consecutive constraints form one range": {
+			specs:    []string{">=4.1", "<4.3"},
+			expected: []string{">=4.1,<4.3"},
+		},
+		"a semicolon starts a new range": {
+			specs:    []string{">=4.1", "<4.3;>=3.6", "<3.7"},
+			expected: []string{">=4.1,<4.3", ">=3.6,<3.7"},
+		},
+		"a trailing semicolon starts a new range": {
+			specs:    []string{">=4.1", "<4.3;", ">=3.6", "<3.7"},
+			expected: []string{">=4.1,<4.3", ">=3.6,<3.7"},
+		},
+		"a version between constraints ends the range": {
+			specs:    []string{">=4.1", "4.0", "<3.7"},
+			expected: []string{">=4.1", "4.0", "<3.7"},
+		},
+		"empty values are skipped": {
+			specs:    []string{" latest ", "", ";"},
+			expected: []string{"latest"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, groupVersionSpecs(tc.specs))
+		})
+	}
+}
+
+func TestResolveVersions(t *testing.T) {
+	available := []string{"3.6.3", "4.0.5", "4.1.2", "4.1.3", "4.2.2", "4.2.3", "4.3.0", "4.3.1", "4.3.2"}
+
+	tests := map[string]struct {
+		specs       []string
+		available   []string
+		expected    []VersionResolution
+		expectError string
+	}{
+		"exact version": {
+			specs:    []string{"4.2.2"},
+			expected: []VersionResolution{{Spec: "4.2.2", Versions: []string{"4.2.2"}}},
+		},
+		"major.minor resolves to the newest patch": {
+			specs:    []string{"4.1"},
+			expected: []VersionResolution{{Spec: "4.1", Versions: []string{"4.1.3"}}},
+		},
+		"major resolves to the newest release": {
+			specs:    []string{"3"},
+			expected: []VersionResolution{{Spec: "3", Versions: []string{"3.6.3"}}},
+		},
+		"latest resolves to the newest release": {
+			specs:    []string{"LATEST"},
+			expected: []VersionResolution{{Spec: "LATEST", Versions: []string{"4.3.2"}}},
+		},
+		"latest-N resolves to the newest patch of the last N minor lines": {
+			specs:    []string{"latest-3"},
+			expected: []VersionResolution{{Spec: "latest-3", Versions: []string{"4.3.2", "4.2.3", "4.1.3"}}},
+		},
+		"latest-N is capped at the available minor lines": {
+			specs:    []string{"latest-50"},
+			expected: []VersionResolution{{Spec: "latest-50", Versions: []string{"4.3.2", "4.2.3", "4.1.3", "4.0.5", "3.6.3"}}},
+		},
+		"range resolves to the newest patch of each minor line it covers": {
+			specs:    []string{">=4.1", "<4.3"},
+			expected: []VersionResolution{{Spec: ">=4.1,<4.3", Versions: []string{"4.2.3", "4.1.3"}}},
+		},
+		"ranges separated by a semicolon resolve separately": {
+			specs: []string{">=4.1", "<4.3;>=3.6", "<3.7"},
+			expected: []VersionResolution{
+				{Spec: ">=4.1,<4.3", Versions: []string{"4.2.3", "4.1.3"}},
+				{Spec: ">=3.6,<3.7", Versions: []string{"3.6.3"}},
+			},
+		},
+		"ranges without a semicolon fail": {
+			specs:       []string{">=4.1", "<4.3", ">=3.6", "<3.7"},
+			expectError: "has more than one lower or upper bound, separate ranges with a semicolon",
+		},
+		"range matching nothing fails": {
+			specs:       []string{">=5.0"},
+			expectError: "no valid R versions match the range >=5.0",
+		},
+		"invalid range fails": {
+			specs:       []string{">=four"},
+			expectError: "invalid version range >=four",
+		},
+		"latest-0 fails": {
+			specs:       []string{"latest-0"},
+			expectError: "latest-N requires N to be 1 or more",
+		},
+		"unknown major.minor fails": {
+			specs:       []string{"4.4"},
+			expectError: "version 4.4 does not match any valid R version",
+		},
+		"unknown exact version fails": {
+			specs:       []string{"4.3.9"},
+			expectError: "version 4.3.9 is not a valid R version",
+		},
+		"no available versions fails": {
+			specs:       []string{"latest"},
+			available:   []string{},
+			expectError: "no valid R versions are available",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			availableVersions := available
+			if tc.available != nil {
+				availableVersions = tc.available
+			}
+			resolutions, err := ResolveVersions("R", tc.specs, availableVersions)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, resolutions)
+		})
+	}
+}
+
+func TestResolvedVersions(t *testing.T) {
+	resolutions := []VersionResolution{
+		{Spec: "4.3", Versions: []string{"4.3.2"}},
+		{Spec: "latest-2", Versions: []string{"4.3.2", "4.2.3"}},
+	}
+	assert.Equal(t, []string{"4.3.2", "4.2.3"}, ResolvedVersions(resolutions))
+}
@@ -0,0 +1,34 @@
+package launcher
+
+import (
+	"fmt"
+
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// WriteLauncherClusterConfig adds a cluster entry to the Job Launcher config file
+func WriteLauncherClusterConfig(clusterName string, clusterType string) error {
+	// check to ensure the cluster doesn't already exist
+	filepath := "/etc/rstudio/launcher.conf"
+	lineExists, err := system.CheckStringExists("type="+clusterType, filepath)
+	if err != nil {
+		return fmt.Errorf("failed to check if line exists: %w", err)
+	}
+
+	if !lineExists {
+		writeLines := []string{
+			"",
+			"[cluster]",
+			"name=" + clusterName,
+			"type=" + clusterType,
+		}
+
+		err = system.WriteStrings(writeLines, filepath, 0644, true, true)
+		if err != nil {
+			return fmt.Errorf("failed to write config: %w", err)
+		}
+	} else {
+		system.PrintAndLogInfo("A " + clusterType + " cluster already exists in " + filepath + ". Skipping writing to the file.")
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package launcher
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sol-eng/wbi/internal/system"
+	"github.com/sol-eng/wbi/internal/workbench"
+)
+
+// kubernetesConfigPath is the Kubernetes plugin config of the Job Launcher, which holds the cluster token
+var kubernetesConfigPath = "/etc/rstudio/launcher.kubernetes.conf"
+
+// KubernetesConfig contains the information needed to configure the Kubernetes Job Launcher plugin
+type KubernetesConfig struct {
+	APIURL     string
+	Namespace  string
+	TokenPath  string
+	CACertPath string
+	Image      string
+}
+
+type kubernetesVersion struct {
+	GitVersion string `json:"gitVersion"`
+	Platform   string `json:"platform"`
+}
+
+func cleanKubernetesAPIURL(apiURL string) string {
+	// remove trailing slash if present
+	return strings.TrimSuffix(apiURL, "/")
+}
+
+// readKubernetesToken reads the service account token from a file
+func readKubernetesToken(tokenPath string) (string, error) {
+	token, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the service account token from %s: %w", tokenPath, err)
+	}
+	if strings.TrimSpace(string(token)) == "" {
+		return "", errors.New("the service account token file " + tokenPath + " is empty")
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// newKubernetesClient creates an HTTP client that trusts the cluster CA if one is provided
+func newKubernetesClient(caCertPath string) (*http.Client, error) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	if caCertPath != "" {
+		caCert, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the cluster CA certificate from %s: %w", caCertPath, err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("no certificates found in " + caCertPath)
+		}
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs},
+		}
+	}
+	return client, nil
+}
+
+func kubernetesGet(client *http.Client, url string, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.Background(),
+		http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.New("error creating request")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the Kubernetes API: %w", err)
+	}
+	return res, nil
+}
+
+// VerifyKubernetesConnectivity checks the Kubernetes API is reachable and the namespace exists
+func VerifyKubernetesConnectivity(kubernetesConfig KubernetesConfig) error {
+	apiURL := cleanKubernetesAPIURL(kubernetesConfig.APIURL)
+	token, err := readKubernetesToken(kubernetesConfig.TokenPath)
+	if err != nil {
+		return err
+	}
+	client, err := newKubernetesClient(kubernetesConfig.CACertPath)
+	if err != nil {
+		return err
+	}
+
+	res, err := kubernetesGet(client, apiURL+"/version", token)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error in HTTP status code from %s/version: %d", apiURL, res.StatusCode)
+	}
+	var version kubernetesVersion
+	err = json.NewDecoder(res.Body).Decode(&version)
+	if err != nil {
+		return errors.New("error unmarshalling JSON data")
+	}
+	system.PrintAndLogInfo("\nKubernetes API reached, server version: " + version.GitVersion)
+
+	namespaceURL := apiURL + "/api/v1/namespaces/" + kubernetesConfig.Namespace
+	namespaceRes, err := kubernetesGet(client, namespaceURL, token)
+	if err != nil {
+		return err
+	}
+	defer namespaceRes.Body.Close()
+	switch namespaceRes.StatusCode {
+	case http.StatusOK:
+		system.PrintAndLogInfo("Kubernetes namespace " + kubernetesConfig.Namespace + " has been successfully validated.")
+	case http.StatusNotFound:
+		return errors.New("the namespace " + kubernetesConfig.Namespace + " does not exist in the Kubernetes cluster")
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errors.New("the service account token is not authorized to read the namespace " + kubernetesConfig.Namespace)
+	default:
+		return fmt.Errorf("error in HTTP status code from %s: %d", namespaceURL, namespaceRes.StatusCode)
+	}
+	return nil
+}
+
+// WriteKubernetesConfig writes the Kubernetes plugin config for the Job Launcher, replacing any earlier connection lines so
+// it can be run again
+func WriteKubernetesConfig(kubernetesConfig KubernetesConfig) error {
+	filepath := kubernetesConfigPath
+	token, err := readKubernetesToken(kubernetesConfig.TokenPath)
+	if err != nil {
+		return err
+	}
+	keys := []string{"api-url=", "auth-token=", "kubernetes-namespace=", "certificate-authority="}
+	writeLines := []string{
+		"api-url=" + cleanKubernetesAPIURL(kubernetesConfig.APIURL),
+		"auth-token=" + token,
+		"kubernetes-namespace=" + kubernetesConfig.Namespace,
+	}
+	if kubernetesConfig.CACertPath != "" {
+		caCert, err := os.ReadFile(kubernetesConfig.CACertPath)
+		if err != nil {
+			return fmt.Errorf("failed to read the cluster CA certificate from %s: %w", kubernetesConfig.CACertPath, err)
+		}
+		writeLines = append(writeLines, "certificate-authority="+base64.StdEncoding.EncodeToString(caCert))
+	}
+
+	// the auth token is a secret so the file is only readable by root and it is not saved to the command log
+	err = system.ReplaceStrings(keys, writeLines, filepath, 0600, false)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	// the mode is only applied when the file is created, so restrict an existing file too
+	err = os.Chmod(filepath, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to restrict the permissions of %s: %w", filepath, err)
+	}
+	return nil
+}
+
+// WriteKubernetesProfilesConfig sets the default session image in the [*] section of the Kubernetes profiles config, keeping
+// every other section and setting
+func WriteKubernetesProfilesConfig(image string) error {
+	filepath, err := ProfilesConfigPath("kubernetes")
+	if err != nil {
+		return err
+	}
+	sections, err := ReadProfiles(filepath)
+	if err != nil {
+		return fmt.Errorf("issue reading %s: %w", filepath, err)
+	}
+	sections, err = SetProfileLimits(sections, "*", ProfileLimits{Allowed: []string{image}}, "kubernetes")
+	if err != nil {
+		return err
+	}
+	for i := range sections {
+		if sections[i].Name == "*" {
+			sections[i].set("default-container-image", image)
+			sections[i].set("allow-unknown-images", "0")
+		}
+	}
+
+	err = WriteProfiles(filepath, sections)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// ConfigureKubernetes verifies connectivity and writes every config file needed for the Kubernetes Job Launcher plugin
+func ConfigureKubernetes(kubernetesConfig KubernetesConfig) error {
+	err := VerifyKubernetesConnectivity(kubernetesConfig)
+	if err != nil {
+		return fmt.Errorf("issue verifying Kubernetes connectivity: %w", err)
+	}
+	// the profiles config is parsed before it is written, so it goes first to fail before any other file is changed
+	err = WriteKubernetesProfilesConfig(kubernetesConfig.Image)
+	if err != nil {
+		return fmt.Errorf("issue writing Kubernetes launcher profiles config: %w", err)
+	}
+	err = WriteKubernetesConfig(kubernetesConfig)
+	if err != nil {
+		return fmt.Errorf("issue writing Kubernetes launcher config: %w", err)
+	}
+	err = WriteLauncherClusterConfig("Kubernetes", "Kubernetes")
+	if err != nil {
+		return fmt.Errorf("issue writing launcher cluster config: %w", err)
+	}
+	err = workbench.WriteLauncherSessionsConfig("Kubernetes", kubernetesConfig.Image)
+	if err != nil {
+		return fmt.Errorf("issue writing launcher sessions config: %w", err)
+	}
+
+	system.PrintAndLogInfo("\nThe Job Launcher has been configured to launch sessions in Kubernetes. Restart Workbench and the Job Launcher to apply the changes.")
+	return nil
+}
@@ -0,0 +1,148 @@
+package launcher
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newKubernetesAPIServer starts a TLS server that answers the version and namespace requests the connectivity check makes,
+// and writes its CA certificate and a service account token to files
+func newKubernetesAPIServer(t *testing.T, versionStatus int, namespaceStatus int) (*httptest.Server, string, string) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/version":
+			w.WriteHeader(versionStatus)
+			fmt.Fprint(w, `{"gitVersion": "v1.28.3", "platform": "linux/amd64"}`)
+		case "/api/v1/namespaces/rstudio":
+			w.WriteHeader(namespaceStatus)
+			fmt.Fprint(w, `{"kind": "Namespace"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	caCertPath := filepath.Join(dir, "ca.crt")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCertPath, caCert, 0600); err != nil {
+		t.Fatalf("issue writing the CA certificate: %v", err)
+	}
+	tokenPath := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenPath, []byte("test-token\n"), 0600); err != nil {
+		t.Fatalf("issue writing the token: %v", err)
+	}
+	return server, caCertPath, tokenPath
+}
+
+func TestVerifyKubernetesConnectivity(t *testing.T) {
+	tests := map[string]struct {
+		versionStatus   int
+		namespaceStatus int
+		namespace       string
+		emptyToken      bool
+		noCACert        bool
+		expectError     string
+	}{
+		"reachable API and existing namespace succeeds": {
+			versionStatus:   http.StatusOK,
+			namespaceStatus: http.StatusOK,
+			namespace:       "rstudio",
+		},
+		"missing namespace fails": {
+			versionStatus:   http.StatusOK,
+			namespaceStatus: http.StatusNotFound,
+			namespace:       "rstudio",
+			expectError:     "the namespace rstudio does not exist in the Kubernetes cluster",
+		},
+		"forbidden namespace fails": {
+			versionStatus:   http.StatusOK,
+			namespaceStatus: http.StatusForbidden,
+			namespace:       "rstudio",
+			expectError:     "the service account token is not authorized to read the namespace rstudio",
+		},
+		"version endpoint error fails": {
+			versionStatus:   http.StatusInternalServerError,
+			namespaceStatus: http.StatusOK,
+			namespace:       "rstudio",
+			expectError:     "error in HTTP status code from",
+		},
+		"empty token file fails": {
+			versionStatus:   http.StatusOK,
+			namespaceStatus: http.StatusOK,
+			namespace:       "rstudio",
+			emptyToken:      true,
+			expectError:     "is empty",
+		},
+		"untrusted cluster certificate fails": {
+			versionStatus:   http.StatusOK,
+			namespaceStatus: http.StatusOK,
+			namespace:       "rstudio",
+			noCACert:        true,
+			expectError:     "error connecting to the Kubernetes API",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			server, caCertPath, tokenPath := newKubernetesAPIServer(t, tc.versionStatus, tc.namespaceStatus)
+			if tc.emptyToken {
+				if err := os.WriteFile(tokenPath, []byte("\n"), 0600); err != nil {
+					t.Fatalf("issue emptying the token: %v", err)
+				}
+			}
+			if tc.noCACert {
+				caCertPath = ""
+			}
+
+			err := VerifyKubernetesConnectivity(KubernetesConfig{
+				APIURL:     server.URL + "/",
+				Namespace:  tc.namespace,
+				TokenPath:  tokenPath,
+				CACertPath: caCertPath,
+			})
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWriteKubernetesConfigRestrictsExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	kubernetesConfigPath = filepath.Join(dir, "launcher.kubernetes.conf")
+	defer func() { kubernetesConfigPath = "/etc/rstudio/launcher.kubernetes.conf" }()
+	if err := os.WriteFile(kubernetesConfigPath, []byte("api-url=https://old.example.com\n"), 0644); err != nil {
+		t.Fatalf("issue writing the config: %v", err)
+	}
+	tokenPath := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenPath, []byte("test-token\n"), 0600); err != nil {
+		t.Fatalf("issue writing the token: %v", err)
+	}
+
+	err := WriteKubernetesConfig(KubernetesConfig{APIURL: "https://k8s.example.com/", Namespace: "rstudio", TokenPath: tokenPath})
+	assert.NoError(t, err)
+
+	info, err := os.Stat(kubernetesConfigPath)
+	if err != nil {
+		t.Fatalf("issue reading the config: %v", err)
+	}
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	contents, err := os.ReadFile(kubernetesConfigPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(contents), "auth-token=test-token")
+	assert.NotContains(t, string(contents), "old.example.com")
+}
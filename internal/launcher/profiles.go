@@ -0,0 +1,350 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// ProfileEntry is a single key=value line in a launcher profiles config file and the comment lines above it
+type ProfileEntry struct {
+	Key      string
+	Value    string
+	Comments []string
+}
+
+// ProfileSection is a [*], [@group] or [user] section in a launcher profiles config file. Comments are the comment lines
+// above the section header and Trailing the comment lines after the last entry of the file. A file with only comments is
+// read as a single section without a name
+type ProfileSection struct {
+	Name     string
+	Comments []string
+	Entries  []ProfileEntry
+	Trailing []string
+}
+
+// ProfileLimits contains the resource limits that can be set for a user or group
+type ProfileLimits struct {
+	DefaultCPUs  int
+	MaxCPUs      int
+	DefaultMemMB int
+	MaxMemMB     int
+	// Allowed is the list of container images for Kubernetes or partitions for Slurm
+	Allowed []string
+}
+
+// ValidProfileClusters returns the cluster types wbi can manage profiles for
+func ValidProfileClusters() []string {
+	return []string{"local", "kubernetes", "slurm"}
+}
+
+// ProfilesConfigPath returns the location of the profiles config file for a cluster type
+func ProfilesConfigPath(clusterType string) (string, error) {
+	switch clusterType {
+	case "local":
+		return "/etc/rstudio/launcher.local.profiles.conf", nil
+	case "kubernetes":
+		return "/etc/rstudio/launcher.kubernetes.profiles.conf", nil
+	case "slurm":
+		return "/etc/rstudio/launcher.slurm.profiles.conf", nil
+	default:
+		return "", errors.New("cluster type " + clusterType + " is not supported")
+	}
+}
+
+// allowedKey returns the profiles key that restricts what a user can pick for a cluster type
+func allowedKey(clusterType string) string {
+	switch clusterType {
+	case "kubernetes":
+		return "container-images"
+	case "slurm":
+		return "allowed-partitions"
+	default:
+		return ""
+	}
+}
+
+// ProfileSectionName converts a user or group into the section name used in profiles config files
+func ProfileSectionName(username string, group string) string {
+	if group != "" {
+		return "@" + group
+	} else if username != "" {
+		return username
+	}
+	return "*"
+}
+
+// ReadProfiles parses a launcher profiles config file, returning no sections if the file doesn't exist
+func ReadProfiles(filepath string) ([]ProfileSection, error) {
+	contents, err := os.ReadFile(filepath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []ProfileSection{}, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return parseProfiles(string(contents), filepath)
+}
+
+// parseProfiles parses the contents of a launcher profiles config file, keeping each comment with the section or entry below it
+func parseProfiles(contents string, filepath string) ([]ProfileSection, error) {
+	sections := []ProfileSection{}
+	var comments []string
+	for _, rawLine := range strings.Split(contents, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			comments = append(comments, line)
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			sections = append(sections, ProfileSection{Name: strings.TrimSpace(line[1 : len(line)-1]), Comments: comments})
+			comments = nil
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, errors.New("invalid line in " + filepath + ": " + line)
+		}
+		if len(sections) == 0 {
+			return nil, errors.New("entry found outside of a section in " + filepath + ": " + line)
+		}
+		current := &sections[len(sections)-1]
+		current.Entries = append(current.Entries, ProfileEntry{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value), Comments: comments})
+		comments = nil
+	}
+	if len(comments) > 0 {
+		if len(sections) == 0 {
+			sections = append(sections, ProfileSection{})
+		}
+		sections[len(sections)-1].Trailing = comments
+	}
+	return sections, nil
+}
+
+// formatProfiles returns the lines of a launcher profiles config file for the sections, including their comments
+func formatProfiles(sections []ProfileSection) []string {
+	var lines []string
+	for i, section := range sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, section.Comments...)
+		if section.Name != "" {
+			lines = append(lines, "["+section.Name+"]")
+		}
+		for _, entry := range section.Entries {
+			lines = append(lines, entry.Comments...)
+			lines = append(lines, entry.Key+"="+entry.Value)
+		}
+		lines = append(lines, section.Trailing...)
+	}
+	return lines
+}
+
+// namedSections returns the sections that have a name, leaving out a comment only file
+func namedSections(sections []ProfileSection) []ProfileSection {
+	return lo.Filter(sections, func(section ProfileSection, _ int) bool {
+		return section.Name != ""
+	})
+}
+
+// Get returns the value of a key in the section
+func (section ProfileSection) Get(key string) string {
+	for _, entry := range section.Entries {
+		if entry.Key == key {
+			return entry.Value
+		}
+	}
+	return ""
+}
+
+func (section *ProfileSection) set(key string, value string) {
+	for i, entry := range section.Entries {
+		if entry.Key == key {
+			section.Entries[i].Value = value
+			return
+		}
+	}
+	section.Entries = append(section.Entries, ProfileEntry{Key: key, Value: value})
+}
+
+// SetProfileLimits sets the limits provided for a section, creating the section if needed. Unset limits are left unchanged.
+func SetProfileLimits(sections []ProfileSection, sectionName string, limits ProfileLimits, clusterType string) ([]ProfileSection, error) {
+	if len(limits.Allowed) > 0 && allowedKey(clusterType) == "" {
+		return nil, errors.New("allowed images or partitions are not supported for " + clusterType + " profiles")
+	}
+
+	index := -1
+	for i, section := range sections {
+		if section.Name == sectionName {
+			index = i
+		}
+	}
+	if index == -1 {
+		sections = append(sections, ProfileSection{Name: sectionName})
+		index = len(sections) - 1
+	}
+
+	section := &sections[index]
+	if limits.DefaultCPUs > 0 {
+		section.set("default-cpus", strconv.Itoa(limits.DefaultCPUs))
+	}
+	if limits.MaxCPUs > 0 {
+		section.set("max-cpus", strconv.Itoa(limits.MaxCPUs))
+	}
+	if limits.DefaultMemMB > 0 {
+		section.set("default-mem-mb", strconv.Itoa(limits.DefaultMemMB))
+	}
+	if limits.MaxMemMB > 0 {
+		section.set("max-mem-mb", strconv.Itoa(limits.MaxMemMB))
+	}
+	if len(limits.Allowed) > 0 {
+		section.set(allowedKey(clusterType), strings.Join(limits.Allowed, ","))
+	}
+	return sections, nil
+}
+
+func sectionInt(section ProfileSection, key string) (int, error) {
+	value := section.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("the %s value %q in section [%s] is not a number", key, value, section.Name)
+	}
+	return number, nil
+}
+
+// ValidateProfiles checks defaults don't exceed maximums and that every user and group referenced exists
+func ValidateProfiles(sections []ProfileSection) error {
+	for _, section := range namedSections(sections) {
+		limitPairs := [][]string{
+			{"default-cpus", "max-cpus"},
+			{"default-mem-mb", "max-mem-mb"},
+		}
+		for _, pair := range limitPairs {
+			defaultValue, err := sectionInt(section, pair[0])
+			if err != nil {
+				return err
+			}
+			maxValue, err := sectionInt(section, pair[1])
+			if err != nil {
+				return err
+			}
+			if maxValue > 0 && defaultValue > maxValue {
+				return fmt.Errorf("the %s value (%d) in section [%s] is greater than the %s value (%d)", pair[0], defaultValue, section.Name, pair[1], maxValue)
+			}
+		}
+
+		if strings.HasPrefix(section.Name, "@") {
+			_, err := user.LookupGroup(strings.TrimPrefix(section.Name, "@"))
+			if err != nil {
+				return fmt.Errorf("the group %s in section [%s] does not exist: %w", strings.TrimPrefix(section.Name, "@"), section.Name, err)
+			}
+		} else if section.Name != "*" {
+			_, err := user.Lookup(section.Name)
+			if err != nil {
+				return fmt.Errorf("the user %s in section [%s] does not exist: %w", section.Name, section.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteProfiles replaces a launcher profiles config file with the sections provided and their comments, backing up the
+// existing file unless it has been backed up before
+func WriteProfiles(filepath string, sections []ProfileSection) error {
+	err := system.MoveToBackup(filepath)
+	if err != nil {
+		return err
+	}
+
+	err = system.WriteStrings(formatProfiles(sections), filepath, 0644, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// PrintProfilesTable prints the limits in each section as a table
+func PrintProfilesTable(sections []ProfileSection, clusterType string) {
+	sections = namedSections(sections)
+	if len(sections) == 0 {
+		system.PrintAndLogInfo("No " + clusterType + " profiles are configured.")
+		return
+	}
+
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	header := "SECTION\tDEFAULT CPUS\tMAX CPUS\tDEFAULT MEM (MB)\tMAX MEM (MB)"
+	if allowedKey(clusterType) != "" {
+		header = header + "\t" + strings.ToUpper(allowedKey(clusterType))
+	}
+	fmt.Fprintln(writer, header)
+	for _, section := range sections {
+		row := []string{"[" + section.Name + "]"}
+		for _, key := range []string{"default-cpus", "max-cpus", "default-mem-mb", "max-mem-mb"} {
+			row = append(row, valueOrDash(section.Get(key)))
+		}
+		if allowedKey(clusterType) != "" {
+			row = append(row, valueOrDash(section.Get(allowedKey(clusterType))))
+		}
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	writer.Flush()
+
+	system.PrintAndLogInfo("\n" + strings.TrimSuffix(builder.String(), "\n"))
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// IsEmpty returns true when no limits have been provided
+func (limits ProfileLimits) IsEmpty() bool {
+	return limits.DefaultCPUs == 0 && limits.MaxCPUs == 0 && limits.DefaultMemMB == 0 && limits.MaxMemMB == 0 && len(limits.Allowed) == 0
+}
+
+// ManageProfiles updates the profile section with the limits provided, then shows every profile for the cluster type
+func ManageProfiles(clusterType string, sectionName string, limits ProfileLimits) error {
+	filepath, err := ProfilesConfigPath(clusterType)
+	if err != nil {
+		return err
+	}
+	sections, err := ReadProfiles(filepath)
+	if err != nil {
+		return fmt.Errorf("issue reading %s: %w", filepath, err)
+	}
+
+	if !limits.IsEmpty() {
+		sections, err = SetProfileLimits(sections, sectionName, limits, clusterType)
+		if err != nil {
+			return fmt.Errorf("issue setting the limits for [%s]: %w", sectionName, err)
+		}
+		err = ValidateProfiles(sections)
+		if err != nil {
+			return fmt.Errorf("issue validating the %s profiles: %w", clusterType, err)
+		}
+		err = WriteProfiles(filepath, sections)
+		if err != nil {
+			return fmt.Errorf("issue writing %s: %w", filepath, err)
+		}
+	}
+
+	PrintProfilesTable(sections, clusterType)
+	return nil
+}
@@ -0,0 +1,103 @@
+package launcher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProfilesRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		contents string
+	}{
+		"comments above sections and entries": {
+			contents: `# limits for everyone
+[*]
+# keep sessions small
+default-cpus=1
+max-cpus=4
+
+# data science team
+[@datascience]
+max-mem-mb=65536
+# reviewed in October`,
+		},
+		"file with only comments": {
+			contents: `# [*]
+# max-cpus=4`,
+		},
+		"sections without comments": {
+			contents: `[*]
+max-cpus=4
+
+[alice]
+max-cpus=8`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sections, err := parseProfiles(tc.contents, "profiles.conf")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.contents, strings.Join(formatProfiles(sections), "\n"))
+		})
+	}
+}
+
+func TestParseProfilesErrors(t *testing.T) {
+	tests := map[string]struct {
+		contents    string
+		expectError string
+	}{
+		"line without a value fails": {
+			contents:    "[*]\nmax-cpus",
+			expectError: "invalid line in profiles.conf: max-cpus",
+		},
+		"entry before any section fails": {
+			contents:    "# header\nmax-cpus=4\n[*]",
+			expectError: "entry found outside of a section in profiles.conf: max-cpus=4",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseProfiles(tc.contents, "profiles.conf")
+			assert.ErrorContains(t, err, tc.expectError)
+		})
+	}
+}
+
+func TestSetProfileLimitsKeepsComments(t *testing.T) {
+	contents := `# limits for everyone
+[*]
+# keep sessions small
+max-cpus=4`
+	sections, err := parseProfiles(contents, "profiles.conf")
+	assert.NoError(t, err)
+
+	sections, err = SetProfileLimits(sections, "*", ProfileLimits{MaxCPUs: 8, DefaultCPUs: 2}, "local")
+	assert.NoError(t, err)
+	sections, err = SetProfileLimits(sections, "@datascience", ProfileLimits{MaxMemMB: 65536}, "local")
+	assert.NoError(t, err)
+
+	expected := `# limits for everyone
+[*]
+# keep sessions small
+max-cpus=8
+default-cpus=2
+
+[@datascience]
+max-mem-mb=65536`
+	assert.Equal(t, expected, strings.Join(formatProfiles(sections), "\n"))
+}
+
+func TestSetProfileLimitsOnCommentOnlyFile(t *testing.T) {
+	sections, err := parseProfiles("# example\n# [*]\n# max-cpus=4\n", "profiles.conf")
+	assert.NoError(t, err)
+
+	sections, err = SetProfileLimits(sections, "*", ProfileLimits{MaxCPUs: 4}, "local")
+	assert.NoError(t, err)
+	assert.Len(t, namedSections(sections), 1)
+	assert.Equal(t, "# example\n# [*]\n# max-cpus=4\n\n[*]\nmax-cpus=4", strings.Join(formatProfiles(sections), "\n"))
+}
@@ -0,0 +1,126 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// Prompt users if they wish to configure the Job Launcher to use Kubernetes or Slurm
+func PromptLauncherClusterChoice() (string, error) {
+	name := ""
+	messageText := "Would you like to configure the Job Launcher to launch sessions in Kubernetes or Slurm? You will need connectivity to the cluster to use this option."
+	prompt := &survey.Select{
+		Message: messageText,
+		Options: []string{"Skip", "Kubernetes", "Slurm"},
+		Default: "Skip",
+	}
+	err := survey.AskOne(prompt, &name)
+	if err != nil {
+		return "", errors.New("there was an issue with the Job Launcher prompt")
+	}
+	log.Info(messageText)
+	log.Info(name)
+	return name, nil
+}
+
+func promptInput(messageText string, defaultValue string) (string, error) {
+	target := ""
+	prompt := &survey.Input{
+		Message: messageText,
+		Default: defaultValue,
+	}
+	err := survey.AskOne(prompt, &target)
+	if err != nil {
+		return "", fmt.Errorf("issue prompting for input: %w", err)
+	}
+	log.Info(messageText)
+	log.Info(target)
+	return target, nil
+}
+
+// PromptKubernetesConfig prompts users for the information needed to configure the Kubernetes Job Launcher plugin
+func PromptKubernetesConfig() (KubernetesConfig, error) {
+	var kubernetesConfig KubernetesConfig
+	var err error
+
+	kubernetesConfig.APIURL, err = promptInput("Enter the Kubernetes API URL (for example https://kubernetes.example.com:6443):", "")
+	if err != nil {
+		return KubernetesConfig{}, fmt.Errorf("issue entering the Kubernetes API URL: %w", err)
+	}
+	kubernetesConfig.Namespace, err = promptInput("Enter the Kubernetes namespace sessions will be launched in:", "rstudio")
+	if err != nil {
+		return KubernetesConfig{}, fmt.Errorf("issue entering the Kubernetes namespace: %w", err)
+	}
+	kubernetesConfig.TokenPath, err = promptInput("Enter the path to a file containing the service account token:", "")
+	if err != nil {
+		return KubernetesConfig{}, fmt.Errorf("issue entering the service account token path: %w", err)
+	}
+	kubernetesConfig.CACertPath, err = promptInput("Enter the path to the cluster CA certificate (leave blank if the API certificate is trusted by the system):", "")
+	if err != nil {
+		return KubernetesConfig{}, fmt.Errorf("issue entering the cluster CA certificate path: %w", err)
+	}
+	kubernetesConfig.Image, err = promptInput("Enter the default session container image:", "rstudio/r-session-complete:ubuntu2204")
+	if err != nil {
+		return KubernetesConfig{}, fmt.Errorf("issue entering the session container image: %w", err)
+	}
+	return kubernetesConfig, nil
+}
+
+// PromptVerifyAndConfigKubernetes prompts for, verifies and writes the Kubernetes Job Launcher config
+func PromptVerifyAndConfigKubernetes() error {
+	kubernetesConfig, err := PromptKubernetesConfig()
+	if err != nil {
+		return err
+	}
+	err = ConfigureKubernetes(kubernetesConfig)
+	if err != nil {
+		return fmt.Errorf("issue configuring the Kubernetes Job Launcher plugin: %w", err)
+	}
+	return nil
+}
+
+// PromptSlurmConfig prompts users for the information needed to configure the Slurm Job Launcher plugin
+func PromptSlurmConfig() (SlurmConfig, error) {
+	var slurmConfig SlurmConfig
+	var err error
+
+	slurmConfig.BinPath, err = promptInput("Enter the directory containing the Slurm binaries (sinfo, squeue, sbatch):", "/usr/bin")
+	if err != nil {
+		return SlurmConfig{}, fmt.Errorf("issue entering the Slurm bin path: %w", err)
+	}
+	slurmConfig.ServiceUser, err = promptInput("Enter the Slurm service user the Job Launcher submits jobs as:", "slurm")
+	if err != nil {
+		return SlurmConfig{}, fmt.Errorf("issue entering the Slurm service user: %w", err)
+	}
+	partitions, err := promptInput("Enter the partitions sessions can be launched in, separated by commas (leave blank to allow all partitions):", "")
+	if err != nil {
+		return SlurmConfig{}, fmt.Errorf("issue entering the Slurm partitions: %w", err)
+	}
+	for _, partition := range strings.Split(partitions, ",") {
+		if strings.TrimSpace(partition) != "" {
+			slurmConfig.Partitions = append(slurmConfig.Partitions, strings.TrimSpace(partition))
+		}
+	}
+	slurmConfig.SharedPath, err = promptInput("Enter the shared filesystem path containing the Workbench session components:", "/usr/lib/rstudio-server")
+	if err != nil {
+		return SlurmConfig{}, fmt.Errorf("issue entering the session components path: %w", err)
+	}
+	return slurmConfig, nil
+}
+
+// PromptVerifyAndConfigSlurm prompts for, verifies and writes the Slurm Job Launcher config
+func PromptVerifyAndConfigSlurm() error {
+	slurmConfig, err := PromptSlurmConfig()
+	if err != nil {
+		return err
+	}
+	err = ConfigureSlurm(slurmConfig)
+	if err != nil {
+		return fmt.Errorf("issue configuring the Slurm Job Launcher plugin: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/system"
+	"github.com/sol-eng/wbi/internal/workbench"
+)
+
+// SlurmConfig contains the information needed to configure the Slurm Job Launcher plugin
+type SlurmConfig struct {
+	BinPath     string
+	ServiceUser string
+	SharedPath  string
+	Partitions  []string
+	MaxCPUs     int
+	MaxMemMB    int
+}
+
+// ScanSlurmPartitions runs sinfo and returns the partitions available in the Slurm cluster
+func ScanSlurmPartitions(binPath string) ([]string, error) {
+	sinfoCommand := system.ShellQuote(filepath.Join(binPath, "sinfo")) + " --noheader --format=%P"
+	output, err := system.RunCommandAndCaptureOutput(sinfoCommand, true, 0, false)
+	if err != nil {
+		return []string{}, fmt.Errorf("issue running sinfo: %w", err)
+	}
+
+	var partitions []string
+	for _, line := range strings.Split(output, "\n") {
+		// the default partition is marked with a trailing *
+		partition := strings.TrimSuffix(strings.TrimSpace(line), "*")
+		if partition != "" {
+			partitions = append(partitions, partition)
+		}
+	}
+	return lo.Uniq(partitions), nil
+}
+
+// VerifySlurmCluster checks the Slurm cluster can be reached and the requested partitions exist
+func VerifySlurmCluster(slurmConfig SlurmConfig) error {
+	partitions, err := ScanSlurmPartitions(slurmConfig.BinPath)
+	if err != nil {
+		return err
+	}
+	if len(partitions) == 0 {
+		return errors.New("no partitions were found in the Slurm cluster")
+	}
+	system.PrintAndLogInfo("\nSlurm partitions found: " + strings.Join(partitions, ", "))
+
+	for _, partition := range slurmConfig.Partitions {
+		if !lo.Contains(partitions, partition) {
+			return errors.New("the partition " + partition + " does not exist in the Slurm cluster")
+		}
+	}
+
+	squeueCommand := system.ShellQuote(filepath.Join(slurmConfig.BinPath, "squeue")) + " --noheader"
+	err = system.RunCommand(squeueCommand, true, 0, false)
+	if err != nil {
+		return fmt.Errorf("issue running squeue: %w", err)
+	}
+	system.PrintAndLogInfo("The Slurm cluster has been successfully validated.")
+	return nil
+}
+
+// CheckSlurmSessionComponents runs a one task job with srun to check the Workbench session components are available on a
+// compute node, and warns when they are missing or the job could not run
+func CheckSlurmSessionComponents(slurmConfig SlurmConfig) {
+	if slurmConfig.SharedPath == "" {
+		return
+	}
+	rsessionPath := filepath.Join(slurmConfig.SharedPath, "bin", "rsession")
+	// --immediate stops srun waiting for a node when the cluster is busy
+	srunCommand := system.ShellQuote(filepath.Join(slurmConfig.BinPath, "srun")) + " --nodes=1 --ntasks=1 --time=1 --immediate=60"
+	if len(slurmConfig.Partitions) > 0 {
+		srunCommand = srunCommand + " --partition=" + system.ShellQuote(slurmConfig.Partitions[0])
+	}
+	srunCommand = srunCommand + " test -x " + system.ShellQuote(rsessionPath)
+
+	_, err := system.RunCommandAndCaptureOutput(srunCommand, true, 0, false)
+	if err != nil {
+		system.PrintAndLogInfo("\nWARNING: the Workbench session components could not be found at " + rsessionPath + " on a Slurm compute node (" + err.Error() + "). " +
+			"Sessions will fail to start on the Slurm compute nodes until the session components are available on the shared filesystem.")
+		return
+	}
+	system.PrintAndLogInfo("The Workbench session components were found at " + rsessionPath + " on a Slurm compute node.")
+}
+
+// WriteSlurmConfig writes the Slurm plugin config for the Job Launcher, replacing any earlier lines so it can be run again
+func WriteSlurmConfig(slurmConfig SlurmConfig) error {
+	filepath := "/etc/rstudio/launcher.slurm.conf"
+	writeLines := []string{
+		"slurm-service-user=" + slurmConfig.ServiceUser,
+		"slurm-bin-path=" + slurmConfig.BinPath,
+	}
+
+	err := system.ReplaceStrings([]string{"slurm-service-user=", "slurm-bin-path="}, writeLines, filepath, 0644, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// WriteSlurmProfilesConfig sets the default resource limits and allowed partitions in the [*] section of the Slurm profiles
+// config, keeping every other section and setting
+func WriteSlurmProfilesConfig(slurmConfig SlurmConfig) error {
+	limits := ProfileLimits{
+		MaxCPUs:  slurmConfig.MaxCPUs,
+		MaxMemMB: slurmConfig.MaxMemMB,
+		Allowed:  slurmConfig.Partitions,
+	}
+	// nothing to restrict so leave the profiles alone
+	if limits.IsEmpty() {
+		return nil
+	}
+
+	filepath, err := ProfilesConfigPath("slurm")
+	if err != nil {
+		return err
+	}
+	sections, err := ReadProfiles(filepath)
+	if err != nil {
+		return fmt.Errorf("issue reading %s: %w", filepath, err)
+	}
+	sections, err = SetProfileLimits(sections, "*", limits, "slurm")
+	if err != nil {
+		return err
+	}
+
+	err = WriteProfiles(filepath, sections)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// ConfigureSlurm verifies the cluster and writes every config file needed for the Slurm Job Launcher plugin
+func ConfigureSlurm(slurmConfig SlurmConfig) error {
+	err := VerifySlurmCluster(slurmConfig)
+	if err != nil {
+		return fmt.Errorf("issue verifying the Slurm cluster: %w", err)
+	}
+	CheckSlurmSessionComponents(slurmConfig)
+
+	// the profiles config is parsed before it is written, so it goes first to fail before any other file is changed
+	err = WriteSlurmProfilesConfig(slurmConfig)
+	if err != nil {
+		return fmt.Errorf("issue writing Slurm launcher profiles config: %w", err)
+	}
+	err = WriteSlurmConfig(slurmConfig)
+	if err != nil {
+		return fmt.Errorf("issue writing Slurm launcher config: %w", err)
+	}
+	err = WriteLauncherClusterConfig("Slurm", "Slurm")
+	if err != nil {
+		return fmt.Errorf("issue writing launcher cluster config: %w", err)
+	}
+	err = workbench.WriteLauncherSessionsConfig("Slurm", "")
+	if err != nil {
+		return fmt.Errorf("issue writing launcher sessions config: %w", err)
+	}
+
+	system.PrintAndLogInfo("\nThe Job Launcher has been configured to launch sessions in Slurm. Restart Workbench and the Job Launcher to apply the changes.")
+	return nil
+}
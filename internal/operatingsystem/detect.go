@@ -2,6 +2,7 @@ package operatingsystem
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"os/user"
 	"runtime"
@@ -49,6 +50,60 @@ func DetectOS() (config.OperatingSystem, error) {
 	}
 }
 
+// Linux distribution families, which share a package manager and package names
+const (
+	DistroFamilyDebian = "debian"
+	DistroFamilyRHEL   = "rhel"
+	DistroFamilySUSE   = "suse"
+)
+
+// DetectDistroFamily reads the ID and ID_LIKE of /etc/os-release to find the family of any Linux distribution, including
+// those DetectOS does not support
+func DetectDistroFamily() (string, error) {
+	osRelease, err := readOSRelease()
+	if err != nil {
+		return "", err
+	}
+	return distroFamily(osRelease)
+}
+
+// readOSRelease reads the fields of /etc/os-release
+func readOSRelease() (map[string]string, error) {
+	contents, err := os.ReadFile("/etc/os-release")
+	if err != nil {
+		return nil, fmt.Errorf("issue reading /etc/os-release: %w", err)
+	}
+	return parseOSRelease(string(contents)), nil
+}
+
+// parseOSRelease returns the KEY=value fields of an os-release file with the quotes removed from the values
+func parseOSRelease(contents string) map[string]string {
+	fields := map[string]string{}
+	for _, line := range strings.Split(contents, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if found && !strings.HasPrefix(key, "#") {
+			fields[key] = strings.Trim(value, `"'`)
+		}
+	}
+	return fields
+}
+
+// distroFamily returns the family of the distribution the ID or ID_LIKE of the os-release fields name
+func distroFamily(osRelease map[string]string) (string, error) {
+	ids := strings.Fields(strings.ToLower(osRelease["ID"] + " " + osRelease["ID_LIKE"]))
+	for _, id := range ids {
+		switch id {
+		case "debian", "ubuntu":
+			return DistroFamilyDebian, nil
+		case "rhel", "centos", "fedora", "rocky", "almalinux", "ol", "amzn":
+			return DistroFamilyRHEL, nil
+		case "suse", "opensuse", "sles", "opensuse-leap", "opensuse-tumbleweed":
+			return DistroFamilySUSE, nil
+		}
+	}
+	return "", errors.New("unsupported Linux distribution, only Debian, RHEL and SUSE based distributions are supported")
+}
+
 func UserLookup(username string) (*user.User, error) {
 	user, err := user.Lookup(username)
 	if err != nil {
@@ -0,0 +1,94 @@
+package operatingsystem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistroFamily(t *testing.T) {
+	tests := map[string]struct {
+		osRelease   string
+		expected    string
+		expectError string
+	}{
+		"Ubuntu": {
+			osRelease: "NAME=\"Ubuntu\"\nVERSION_ID=\"22.04\"\nID=ubuntu\nID_LIKE=debian\n",
+			expected:  DistroFamilyDebian,
+		},
+		"Linux Mint is found through ID_LIKE": {
+			osRelease: "NAME=\"Linux Mint\"\nID=linuxmint\nID_LIKE=\"ubuntu debian\"\n",
+			expected:  DistroFamilyDebian,
+		},
+		"Rocky Linux": {
+			osRelease: "NAME=\"Rocky Linux\"\nID=\"rocky\"\nID_LIKE=\"rhel centos fedora\"\nVERSION_ID=\"9.3\"\n",
+			expected:  DistroFamilyRHEL,
+		},
+		"Amazon Linux": {
+			osRelease: "NAME=\"Amazon Linux\"\nID=\"amzn\"\nID_LIKE=\"fedora\"\n",
+			expected:  DistroFamilyRHEL,
+		},
+		"openSUSE Leap with single quotes": {
+			osRelease: "NAME='openSUSE Leap'\nID='opensuse-leap'\nID_LIKE='suse opensuse'\n",
+			expected:  DistroFamilySUSE,
+		},
+		"commented out ID is ignored": {
+			osRelease:   "#ID=ubuntu\nID=arch\n",
+			expectError: "unsupported Linux distribution",
+		},
+		"empty file fails": {
+			osRelease:   "",
+			expectError: "unsupported Linux distribution",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			family, err := distroFamily(parseOSRelease(tc.osRelease))
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, family)
+		})
+	}
+}
+
+func TestBuilderRepoCommand(t *testing.T) {
+	tests := map[string]struct {
+		id        string
+		versionID string
+		expected  string
+	}{
+		"Rocky Linux 8 enables PowerTools": {
+			id:        "rocky",
+			versionID: "8.9",
+			expected:  "dnf install -y dnf-plugins-core && dnf config-manager --set-enabled powertools",
+		},
+		"AlmaLinux 9 enables CRB": {
+			id:        "almalinux",
+			versionID: "9.3",
+			expected:  "dnf install -y dnf-plugins-core && dnf config-manager --set-enabled crb",
+		},
+		"CentOS Stream 9 enables CRB": {
+			id:        "centos",
+			versionID: "9",
+			expected:  "dnf install -y dnf-plugins-core && dnf config-manager --set-enabled crb",
+		},
+		"CentOS 7 has no CRB repository": {
+			id:        "centos",
+			versionID: "7",
+		},
+		"Fedora has no CRB repository": {
+			id:        "fedora",
+			versionID: "39",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, builderRepoCommand(tc.id, tc.versionID))
+		})
+	}
+}
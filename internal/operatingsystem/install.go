@@ -157,6 +157,58 @@ func EnableCodeReadyRepo(osType config.OperatingSystem, CloudInstall bool) error
 	return nil
 }
 
+// EnableBuilderRepo enables the repository with the development packages of a RHEL based distribution: CodeReady Linux Builder
+// on RHEL, and CRB or PowerTools on Rocky Linux, AlmaLinux and CentOS. Other distributions are left unchanged
+func EnableBuilderRepo() error {
+	osRelease, err := readOSRelease()
+	if err != nil {
+		return err
+	}
+	if osRelease["ID"] == "rhel" {
+		osType, err := DetectOS()
+		if err != nil {
+			return fmt.Errorf("issue detecting the RHEL version: %w", err)
+		}
+		onCloud, err := PromptCloud()
+		if err != nil {
+			return fmt.Errorf("issue asking whether the server runs in the cloud: %w", err)
+		}
+		return EnableCodeReadyRepo(osType, onCloud)
+	}
+
+	enableBuilderCommand := builderRepoCommand(osRelease["ID"], osRelease["VERSION_ID"])
+	if enableBuilderCommand == "" {
+		return nil
+	}
+	err = system.RunCommand(enableBuilderCommand, true, 1, true)
+	if err != nil {
+		return fmt.Errorf("issue enabling the builder repo with the command '%s': %w", enableBuilderCommand, err)
+	}
+	system.PrintAndLogInfo("\nThe builder repository has been successfully enabled!")
+	return nil
+}
+
+// builderRepoCommand returns the command that enables the CRB repository, named PowerTools before version 9, of a RHEL rebuild,
+// or "" if the distribution has no such repository
+func builderRepoCommand(id string, versionID string) string {
+	switch id {
+	case "rocky", "almalinux", "centos":
+	default:
+		return ""
+	}
+	majorVersion, _, _ := strings.Cut(versionID, ".")
+	var repo string
+	switch majorVersion {
+	case "8":
+		repo = "powertools"
+	case "9", "10":
+		repo = "crb"
+	default:
+		return ""
+	}
+	return "dnf install -y dnf-plugins-core && dnf config-manager --set-enabled " + repo
+}
+
 // Enable the Extra Repo
 func EnableExtraRepo() error {
 	extraCommand := "yum-config-manager --enable rhel-7-server-rhui-extras-rpms"
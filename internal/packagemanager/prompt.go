@@ -9,6 +9,7 @@ import (
 	"github.com/samber/lo"
 	log "github.com/sirupsen/logrus"
 	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/languages"
 	"github.com/sol-eng/wbi/internal/system"
 	"github.com/sol-eng/wbi/internal/workbench"
 )
@@ -113,6 +114,11 @@ func InteractivePackageManagerPrompts(osType config.OperatingSystem) error {
 					return fmt.Errorf("failed to write CRAN repo config: %w", err)
 				}
 			}
+			// point R outside of Workbench sessions at the same repo
+			err = languages.ConfigureRprofileSite(languages.RSiteConfig{RepoURL: packageManagerURLFull})
+			if err != nil {
+				return fmt.Errorf("failed to configure Rprofile.site: %w", err)
+			}
 		}
 	}
 
@@ -186,6 +192,11 @@ func VerifyAndBuildPublicPackageManager(osType config.OperatingSystem) error {
 	if err != nil {
 		return fmt.Errorf("failed to write CRAN repo config: %w", err)
 	}
+	// point R outside of Workbench sessions at the same repo
+	err = languages.ConfigureRprofileSite(languages.RSiteConfig{RepoURL: packageManagerURLFull})
+	if err != nil {
+		return fmt.Errorf("failed to configure Rprofile.site: %w", err)
+	}
 	return nil
 }
 
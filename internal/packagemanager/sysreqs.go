@@ -0,0 +1,233 @@
+package packagemanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// PublicPackageManagerURL is used for system requirements when no Posit Package Manager repo is configured
+const PublicPackageManagerURL = "https://packagemanager.posit.co"
+
+var snapshotSegment = regexp.MustCompile(`^(latest|\d{4}-\d{2}-\d{2}|\d+)$`)
+
+// SystemRequirements are the OS packages needed to build and run a set of R packages
+type SystemRequirements struct {
+	// ByPackage lists the OS packages each R package needs, R packages without requirements are left out
+	ByPackage  map[string][]string
+	Packages   []string
+	PreInstall []string
+}
+
+type sysreqsResponse struct {
+	Requirements []struct {
+		Name         string `json:"name"`
+		Requirements struct {
+			Packages   []string `json:"packages"`
+			PreInstall []struct {
+				Command string `json:"command"`
+			} `json:"pre_install"`
+		} `json:"requirements"`
+	} `json:"requirements"`
+}
+
+// SplitRepoURL splits a Posit Package Manager repo URL such as https://ppm.example.com/cran/__linux__/jammy/latest
+// into the server URL and the repo name. ok is false if the URL does not look like a Package Manager repo
+func SplitRepoURL(repoURL string) (serverURL string, repo string, ok bool) {
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil || parsedURL.Host == "" {
+		return "", "", false
+	}
+	segments := lo.Compact(strings.Split(parsedURL.Path, "/"))
+	if i := lo.IndexOf(segments, "__linux__"); i != -1 {
+		segments = segments[:i]
+	} else if len(segments) > 0 && snapshotSegment.MatchString(segments[len(segments)-1]) {
+		segments = segments[:len(segments)-1]
+	} else {
+		return "", "", false
+	}
+	if len(segments) == 0 {
+		return "", "", false
+	}
+	repo = segments[len(segments)-1]
+	serverURL = parsedURL.Scheme + "://" + parsedURL.Host
+	if len(segments) > 1 {
+		serverURL = serverURL + "/" + strings.Join(segments[:len(segments)-1], "/")
+	}
+	return serverURL, repo, true
+}
+
+// ConfiguredRepo returns the Package Manager server and repo configured for Workbench in repos.conf,
+// falling back to the cran repo of the public Package Manager
+func ConfiguredRepo() (string, string) {
+	if serverURL, repo, ok := SplitRepoURL(languages.RepoURLFromReposConf()); ok {
+		return serverURL, repo
+	}
+	return PublicPackageManagerURL, "cran"
+}
+
+// sysreqsDistribution converts the OS name used in Package Manager repo URLs to the distribution and release the
+// system requirements API expects
+func sysreqsDistribution(osType config.OperatingSystem) (string, string, error) {
+	osName, err := ConvertOSTypeToOSName(osType)
+	if err != nil {
+		return "", "", err
+	}
+	switch osName {
+	case "focal":
+		return "ubuntu", "20.04", nil
+	case "jammy":
+		return "ubuntu", "22.04", nil
+	case "centos7":
+		return "redhat", "7", nil
+	case "centos8":
+		return "redhat", "8", nil
+	case "rhel9":
+		return "redhat", "9", nil
+	}
+	return "", "", errors.New("operating system not supported")
+}
+
+// RetrieveSystemRequirements queries the Package Manager system requirements API for the OS packages the R packages need
+func RetrieveSystemRequirements(serverURL string, repo string, packages []string, osType config.OperatingSystem) (SystemRequirements, error) {
+	distribution, release, err := sysreqsDistribution(osType)
+	if err != nil {
+		return SystemRequirements{}, err
+	}
+
+	query := url.Values{}
+	query.Set("all", "false")
+	query.Set("distribution", distribution)
+	query.Set("release", release)
+	for _, name := range packages {
+		query.Add("pkgname", name)
+	}
+	sysreqsURL := cleanPackageManagerURL(serverURL) + "/__api__/repos/" + url.PathEscape(repo) + "/sysreqs?" + query.Encode()
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	req, err := http.NewRequestWithContext(context.Background(),
+		http.MethodGet, sysreqsURL, nil)
+	if err != nil {
+		return SystemRequirements{}, errors.New("error creating request")
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return SystemRequirements{}, fmt.Errorf("error retrieving system requirements from %s: %w", serverURL, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return SystemRequirements{}, fmt.Errorf("error in HTTP status code %d retrieving system requirements from the %s repo", res.StatusCode, repo)
+	}
+
+	var response sysreqsResponse
+	err = json.NewDecoder(res.Body).Decode(&response)
+	if err != nil {
+		return SystemRequirements{}, errors.New("error unmarshalling JSON data")
+	}
+
+	requirements := SystemRequirements{ByPackage: map[string][]string{}}
+	for _, requirement := range response.Requirements {
+		if len(requirement.Requirements.Packages) > 0 {
+			requirements.ByPackage[requirement.Name] = requirement.Requirements.Packages
+		}
+		for _, osPackage := range requirement.Requirements.Packages {
+			requirements.Packages = languages.AppendIfMissing(requirements.Packages, osPackage)
+		}
+		for _, preInstall := range requirement.Requirements.PreInstall {
+			requirements.PreInstall = languages.AppendIfMissing(requirements.PreInstall, preInstall.Command)
+		}
+	}
+	sort.Strings(requirements.Packages)
+	return requirements, nil
+}
+
+// SystemRequirementsCommands returns the commands that install the system requirements. On Ubuntu the package lists are
+// refreshed first, and again after any pre-install commands since those may add repositories
+func SystemRequirementsCommands(requirements SystemRequirements, osType config.OperatingSystem) ([]string, error) {
+	if len(requirements.Packages) == 0 {
+		return nil, nil
+	}
+	var commands []string
+	switch osType {
+	case config.Ubuntu20, config.Ubuntu22:
+		aptUpdateCommand := "DEBIAN_FRONTEND=noninteractive apt-get update"
+		commands = append(commands, aptUpdateCommand)
+		if len(requirements.PreInstall) > 0 {
+			commands = append(commands, requirements.PreInstall...)
+			commands = append(commands, aptUpdateCommand)
+		}
+		commands = append(commands, "DEBIAN_FRONTEND=noninteractive apt-get install -y "+strings.Join(requirements.Packages, " "))
+	case config.Redhat7, config.Redhat8, config.Redhat9:
+		commands = append(commands, requirements.PreInstall...)
+		commands = append(commands, "yum install -y "+strings.Join(requirements.Packages, " "))
+	default:
+		return nil, errors.New("operating system not supported")
+	}
+	return commands, nil
+}
+
+// PrintSystemRequirements prints the OS packages each R package needs as a table
+func PrintSystemRequirements(requirements SystemRequirements) {
+	if len(requirements.ByPackage) == 0 {
+		system.PrintAndLogInfo("\nNone of the R packages have system requirements.")
+		return
+	}
+	rPackages := lo.Keys(requirements.ByPackage)
+	sort.Strings(rPackages)
+
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "R PACKAGE\tSYSTEM PACKAGES")
+	for _, rPackage := range rPackages {
+		fmt.Fprintln(writer, rPackage+"\t"+strings.Join(requirements.ByPackage[rPackage], " "))
+	}
+	writer.Flush()
+	system.PrintAndLogInfo("\n" + strings.TrimSuffix(builder.String(), "\n"))
+}
+
+// InstallSystemRequirements looks up and installs the OS packages the R packages need. With dryRun the commands are
+// only printed
+func InstallSystemRequirements(serverURL string, repo string, packages []string, osType config.OperatingSystem, dryRun bool) error {
+	system.PrintAndLogInfo("\nRetrieving system requirements for " + strings.Join(packages, ", ") + " from the " + repo + " repo of " + serverURL)
+	requirements, err := RetrieveSystemRequirements(serverURL, repo, packages, osType)
+	if err != nil {
+		return fmt.Errorf("issue retrieving system requirements: %w", err)
+	}
+	PrintSystemRequirements(requirements)
+
+	commands, err := SystemRequirementsCommands(requirements, osType)
+	if err != nil {
+		return err
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+
+	if dryRun {
+		system.PrintAndLogInfo("\nThe following commands would be run:\n" + strings.Join(commands, "\n"))
+		return nil
+	}
+	for _, command := range commands {
+		err = system.RunCommand(command, true, 1, true)
+		if err != nil {
+			return fmt.Errorf("issue installing system requirements with the command '%s': %w", command, err)
+		}
+	}
+	system.PrintAndLogInfo("\nThe system requirements have been successfully installed!")
+	return nil
+}
@@ -0,0 +1,149 @@
+package packagemanager
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitRepoURL(t *testing.T) {
+	tests := map[string]struct {
+		repoURL        string
+		expectedServer string
+		expectedRepo   string
+		expectedOK     bool
+	}{
+		"binary repo URL": {
+			repoURL:        "https://ppm.example.com/cran/__linux__/jammy/latest",
+			expectedServer: "https://ppm.example.com",
+			expectedRepo:   "cran",
+			expectedOK:     true,
+		},
+		"source repo URL with a dated snapshot": {
+			repoURL:        "https://ppm.example.com/cran/2024-01-15",
+			expectedServer: "https://ppm.example.com",
+			expectedRepo:   "cran",
+			expectedOK:     true,
+		},
+		"server under a path with a numbered snapshot": {
+			repoURL:        "https://example.com/rspm/internal-cran/__linux__/rhel9/1234/",
+			expectedServer: "https://example.com/rspm",
+			expectedRepo:   "internal-cran",
+			expectedOK:     true,
+		},
+		"CRAN mirror is not a Package Manager repo": {
+			repoURL: "https://cloud.r-project.org",
+		},
+		"repo without a snapshot is not a Package Manager repo": {
+			repoURL: "https://ppm.example.com/cran",
+		},
+		"snapshot without a repo": {
+			repoURL: "https://ppm.example.com/latest",
+		},
+		"empty URL": {
+			repoURL: "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverURL, repo, ok := SplitRepoURL(tc.repoURL)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedServer, serverURL)
+			assert.Equal(t, tc.expectedRepo, repo)
+		})
+	}
+}
+
+func TestRetrieveSystemRequirements(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/__api__/repos/cran/sysreqs" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("distribution") != "ubuntu" || query.Get("release") != "22.04" || query.Get("all") != "false" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, []string{"curl", "xml2", "shiny"}, query["pkgname"])
+		fmt.Fprint(w, `{"requirements": [
+			{"name": "curl", "requirements": {"packages": ["libcurl4-openssl-dev", "libssl-dev"]}},
+			{"name": "xml2", "requirements": {"packages": ["libxml2-dev", "libssl-dev"], "pre_install": [{"command": "add-apt-repository -y universe"}]}},
+			{"name": "shiny", "requirements": {"packages": []}}
+		]}`)
+	}))
+	defer server.Close()
+
+	requirements, err := RetrieveSystemRequirements(server.URL+"/", "cran", []string{"curl", "xml2", "shiny"}, config.Ubuntu22)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"libcurl4-openssl-dev", "libssl-dev", "libxml2-dev"}, requirements.Packages)
+	assert.Equal(t, []string{"add-apt-repository -y universe"}, requirements.PreInstall)
+	assert.Equal(t, map[string][]string{
+		"curl": {"libcurl4-openssl-dev", "libssl-dev"},
+		"xml2": {"libxml2-dev", "libssl-dev"},
+	}, requirements.ByPackage)
+
+	_, err = RetrieveSystemRequirements(server.URL, "bioconductor", []string{"curl"}, config.Ubuntu22)
+	assert.ErrorContains(t, err, "error in HTTP status code 404 retrieving system requirements from the bioconductor repo")
+
+	_, err = RetrieveSystemRequirements(server.URL, "cran", []string{"curl"}, config.Unknown)
+	assert.ErrorContains(t, err, "operating system not supported")
+}
+
+func TestSystemRequirementsCommands(t *testing.T) {
+	tests := map[string]struct {
+		requirements SystemRequirements
+		osType       config.OperatingSystem
+		expected     []string
+		expectError  string
+	}{
+		"Ubuntu updates the package lists before installing": {
+			requirements: SystemRequirements{Packages: []string{"libcurl4-openssl-dev", "libxml2-dev"}},
+			osType:       config.Ubuntu22,
+			expected: []string{
+				"DEBIAN_FRONTEND=noninteractive apt-get update",
+				"DEBIAN_FRONTEND=noninteractive apt-get install -y libcurl4-openssl-dev libxml2-dev",
+			},
+		},
+		"Ubuntu updates the package lists again after pre-install commands": {
+			requirements: SystemRequirements{Packages: []string{"libxml2-dev"}, PreInstall: []string{"add-apt-repository -y universe"}},
+			osType:       config.Ubuntu20,
+			expected: []string{
+				"DEBIAN_FRONTEND=noninteractive apt-get update",
+				"add-apt-repository -y universe",
+				"DEBIAN_FRONTEND=noninteractive apt-get update",
+				"DEBIAN_FRONTEND=noninteractive apt-get install -y libxml2-dev",
+			},
+		},
+		"RHEL runs the pre-install commands then yum": {
+			requirements: SystemRequirements{Packages: []string{"libxml2-devel"}, PreInstall: []string{"dnf install -y epel-release"}},
+			osType:       config.Redhat9,
+			expected:     []string{"dnf install -y epel-release", "yum install -y libxml2-devel"},
+		},
+		"no requirements need no commands": {
+			osType: config.Ubuntu22,
+		},
+		"unknown operating system fails": {
+			requirements: SystemRequirements{Packages: []string{"libxml2-dev"}},
+			osType:       config.Unknown,
+			expectError:  "operating system not supported",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			commands, err := SystemRequirementsCommands(tc.requirements, tc.osType)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, commands)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package positron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/system"
+	"github.com/sol-eng/wbi/internal/vscode"
+	"github.com/sol-eng/wbi/internal/workbench"
+)
+
+// the first Workbench release that ships Positron as an IDE option
+var MinWorkbenchVersion = "2024.12.0"
+
+// the settings applied to every user's Positron session
+var userSettingsPath = "/etc/rstudio/positron-user-settings.json"
+
+// Positron is the Positron IDE shipped with Workbench
+var Positron = vscode.Editor{
+	Name: "Positron",
+	ServerPaths: []string{
+		"/usr/lib/rstudio-server/bin/positron-server/bin/positron-server",
+	},
+	ExtensionsDir:    "/opt/positron-server/extensions",
+	ExtensionsConfig: "/etc/rstudio/positron.extensions.conf",
+}
+
+// DefaultExtensions returns the curated extensions for Positron, R and Python support is built in
+func DefaultExtensions() []string {
+	return []string{
+		"quarto.quarto",
+		"posit.shiny",
+		"posit.publisher",
+	}
+}
+
+// SupportsPositron checks whether the installed Workbench version ships Positron
+func SupportsPositron() (bool, error) {
+	workbenchVersion, err := workbench.RetrieveWorkbenchVersion()
+	if err != nil {
+		return false, fmt.Errorf("issue retrieving the Workbench version: %w", err)
+	}
+	minVersion, err := version.NewVersion(MinWorkbenchVersion)
+	if err != nil {
+		return false, fmt.Errorf("issue parsing the minimum Workbench version: %w", err)
+	}
+	return workbenchVersion.GreaterThanOrEqual(minVersion), nil
+}
+
+// DefaultInterpreters returns the newest R installed in /opt/R or built into the install prefix and the newest Python in /opt/python
+func DefaultInterpreters() (string, string, error) {
+	rPaths, err := languages.ScanForRVersions()
+	if err != nil {
+		return "", "", fmt.Errorf("issue occured in scanning for R versions: %w", err)
+	}
+	pythonPaths, err := languages.ScanForPythonVersions()
+	if err != nil {
+		return "", "", fmt.Errorf("issue occured in scanning for Python versions: %w", err)
+	}
+
+	rPath, pythonPath := defaultInterpreters(rPaths, pythonPaths)
+	return rPath, pythonPath, nil
+}
+
+// defaultInterpreters picks the first R in /opt/R or the install prefix and the first Python in /opt/python from the scanned
+// paths, which list those versions first and newest first
+func defaultInterpreters(rPaths []string, pythonPaths []string) (string, string) {
+	var rPath, pythonPath string
+	for _, path := range rPaths {
+		if lo.SomeBy(config.RInstallRoots(), func(root string) bool { return strings.HasPrefix(path, root+"/") }) {
+			rPath = path
+			break
+		}
+	}
+	for _, path := range pythonPaths {
+		if strings.HasPrefix(path, config.DefaultInstallRoot("python")+"/") {
+			pythonPath = path
+			break
+		}
+	}
+	return rPath, pythonPath
+}
+
+// WriteDefaultInterpreters sets the default R and Python interpreters in the Positron user settings, keeping any existing settings
+func WriteDefaultInterpreters(rPath string, pythonPath string) error {
+	settings := map[string]interface{}{}
+	if system.VerifyFileExists(userSettingsPath) {
+		existing, err := os.ReadFile(userSettingsPath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", userSettingsPath, err)
+		}
+		if len(strings.TrimSpace(string(existing))) > 0 {
+			err = json.Unmarshal(existing, &settings)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", userSettingsPath, err)
+			}
+		}
+	}
+
+	if rPath != "" {
+		settings["positron.r.interpreters.default"] = rPath
+	}
+	if pythonPath != "" {
+		settings["python.defaultInterpreterPath"] = pythonPath
+	}
+
+	settingsJSON, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return errors.New("error marshalling JSON data")
+	}
+
+	system.PrintAndLogInfo("\n=== Writing to the file " + userSettingsPath + " ===")
+	err = os.WriteFile(userSettingsPath, append(settingsJSON, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	system.PrintAndLogInfo(string(settingsJSON))
+	return nil
+}
+
+// ConfigPositron enables Positron sessions, sets the default interpreters and provisions extensions
+func ConfigPositron(extensions []string) error {
+	supported, err := SupportsPositron()
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return errors.New("the installed version of Workbench does not support Positron, Workbench " + MinWorkbenchVersion + " or later is required")
+	}
+
+	// sessions only load the extensions provisioned below when they are started with the global extensions directory
+	args, err := workbench.ReadPositronArgs()
+	if err != nil {
+		return fmt.Errorf("issue reading the Positron config: %w", err)
+	}
+	err = workbench.WritePositronConfig(Positron.SessionArgs(args))
+	if err != nil {
+		return fmt.Errorf("issue writing the Positron config: %w", err)
+	}
+
+	rPath, pythonPath, err := DefaultInterpreters()
+	if err != nil {
+		return err
+	}
+	if rPath == "" && pythonPath == "" {
+		system.PrintAndLogInfo("\nNo R or Python versions were found in " + strings.Join(config.RInstallRoots(), ", ") + " or " + config.DefaultInstallRoot("python") + ", the Positron default interpreters have not been set.")
+	} else {
+		err = WriteDefaultInterpreters(rPath, pythonPath)
+		if err != nil {
+			return fmt.Errorf("issue writing the Positron default interpreters: %w", err)
+		}
+	}
+
+	if len(extensions) > 0 {
+		err = Positron.InstallAndConfigExtensions(extensions)
+		if err != nil {
+			return err
+		}
+	}
+	system.PrintAndLogInfo("\nPositron sessions have been enabled. Restart Workbench to apply the changes.")
+	return nil
+}
@@ -0,0 +1,85 @@
+package positron
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultInterpreters(t *testing.T) {
+	tests := map[string]struct {
+		prefix         string
+		rPaths         []string
+		pythonPaths    []string
+		expectedR      string
+		expectedPython string
+	}{
+		"newest versions in /opt are picked": {
+			rPaths:         []string{"/opt/R/4.3.2/bin/R", "/opt/R/4.2.3/bin/R", "/usr/bin/R"},
+			pythonPaths:    []string{"/opt/python/3.11.6/bin/python", "/usr/bin/python3"},
+			expectedR:      "/opt/R/4.3.2/bin/R",
+			expectedPython: "/opt/python/3.11.6/bin/python",
+		},
+		"system versions are not picked": {
+			rPaths:      []string{"/usr/bin/R", "/usr/lib/R/bin/R"},
+			pythonPaths: []string{"/usr/bin/python3"},
+		},
+		"R built from source into the install prefix is picked": {
+			prefix:         "/apps/posit",
+			rPaths:         []string{"/apps/posit/R/4.3.2/bin/R", "/usr/bin/R"},
+			pythonPaths:    []string{"/apps/posit/python/3.11.6/bin/python", "/opt/python/3.10.13/bin/python"},
+			expectedR:      "/apps/posit/R/4.3.2/bin/R",
+			expectedPython: "/opt/python/3.10.13/bin/python",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := config.SetInstallPrefix(tc.prefix); err != nil {
+				t.Fatalf("issue setting the install prefix: %v", err)
+			}
+			defer config.SetInstallPrefix("")
+
+			rPath, pythonPath := defaultInterpreters(tc.rPaths, tc.pythonPaths)
+			assert.Equal(t, tc.expectedR, rPath)
+			assert.Equal(t, tc.expectedPython, pythonPath)
+		})
+	}
+}
+
+func TestWriteDefaultInterpreters(t *testing.T) {
+	userSettingsPath = filepath.Join(t.TempDir(), "positron-user-settings.json")
+	defer func() { userSettingsPath = "/etc/rstudio/positron-user-settings.json" }()
+	if err := os.WriteFile(userSettingsPath, []byte(`{"editor.fontSize": 14}`), 0644); err != nil {
+		t.Fatalf("issue writing the settings: %v", err)
+	}
+
+	assert.NoError(t, WriteDefaultInterpreters("/opt/R/4.3.2/bin/R", ""))
+	assert.NoError(t, WriteDefaultInterpreters("", "/opt/python/3.11.6/bin/python"))
+
+	contents, err := os.ReadFile(userSettingsPath)
+	if err != nil {
+		t.Fatalf("issue reading the settings: %v", err)
+	}
+	expected := `{
+  "editor.fontSize": 14,
+  "positron.r.interpreters.default": "/opt/R/4.3.2/bin/R",
+  "python.defaultInterpreterPath": "/opt/python/3.11.6/bin/python"
+}
+`
+	assert.Equal(t, expected, string(contents))
+}
+
+func TestWriteDefaultInterpretersInvalidSettings(t *testing.T) {
+	userSettingsPath = filepath.Join(t.TempDir(), "positron-user-settings.json")
+	defer func() { userSettingsPath = "/etc/rstudio/positron-user-settings.json" }()
+	if err := os.WriteFile(userSettingsPath, []byte(`{"editor.fontSize": `), 0644); err != nil {
+		t.Fatalf("issue writing the settings: %v", err)
+	}
+
+	err := WriteDefaultInterpreters("/opt/R/4.3.2/bin/R", "")
+	assert.ErrorContains(t, err, "failed to parse")
+}
@@ -0,0 +1,81 @@
+package positron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// Prompt asking users if they wish to enable Positron sessions
+func PromptPositronChoice() (bool, error) {
+	name := true
+	messageText := "Would you like to enable Positron sessions in Workbench?"
+	prompt := &survey.Confirm{
+		Message: messageText,
+	}
+	err := survey.AskOne(prompt, &name)
+	if err != nil {
+		return false, errors.New("there was an issue with the Positron prompt")
+	}
+	log.Info(messageText)
+	log.Info(fmt.Sprintf("%v", name))
+	return name, nil
+}
+
+// Prompt asking users which of the curated extensions should be installed
+func PromptExtensions() ([]string, error) {
+	var qs = []*survey.Question{
+		{
+			Name: "extensionprompt",
+			Prompt: &survey.MultiSelect{
+				Message: "Which Positron extensions would you like to install for all users? (select none to skip this step)",
+				Options: DefaultExtensions(),
+				Default: DefaultExtensions(),
+			},
+		},
+	}
+	extensionAnswers := struct {
+		Extensions []string `survey:"extensionprompt"`
+	}{}
+
+	err := survey.Ask(qs, &extensionAnswers, survey.WithRemoveSelectAll(), survey.WithRemoveSelectNone())
+	if err != nil {
+		return []string{}, errors.New("there was an issue with the Positron extensions prompt")
+	}
+	log.Info("Which Positron extensions would you like to install for all users?")
+	log.Info(strings.Join(extensionAnswers.Extensions, ", "))
+	return extensionAnswers.Extensions, nil
+}
+
+// CheckPromptAndConfigPositron skips Positron when Workbench doesn't support it, otherwise prompts users to enable it
+func CheckPromptAndConfigPositron() error {
+	supported, err := SupportsPositron()
+	if err != nil {
+		return err
+	}
+	if !supported {
+		system.PrintAndLogInfo("\nThe installed version of Workbench does not support Positron (Workbench " + MinWorkbenchVersion + " or later is required). Skipping the Positron configuration.")
+		return nil
+	}
+
+	positronChoice, err := PromptPositronChoice()
+	if err != nil {
+		return err
+	}
+	if !positronChoice {
+		return nil
+	}
+	extensions, err := PromptExtensions()
+	if err != nil {
+		return err
+	}
+	err = ConfigPositron(extensions)
+	if err != nil {
+		return fmt.Errorf("issue configuring Positron: %w", err)
+	}
+	return nil
+}
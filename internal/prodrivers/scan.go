@@ -0,0 +1,80 @@
+package prodrivers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Driver is an ODBC driver listed in /etc/odbcinst.ini
+type Driver struct {
+	Name      string `json:"name"`
+	Driver    string `json:"driver"`
+	Installer string `json:"installer,omitempty"`
+	Missing   bool   `json:"missing"`
+}
+
+// IsProDriver returns true if the driver was installed by the Posit Pro Drivers
+func (d Driver) IsProDriver() bool {
+	return d.Installer == "RStudio Pro Drivers"
+}
+
+// Inventory describes the ODBC drivers listed in /etc/odbcinst.ini
+type Inventory struct {
+	ProDriversInstalled bool     `json:"pro_drivers_installed"`
+	Drivers             []Driver `json:"drivers"`
+}
+
+// parseODBCInst reads the driver sections of an odbcinst.ini file
+func parseODBCInst(contents string) []Driver {
+	drivers := []Driver{}
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+			// the ODBC section holds unixODBC settings rather than a driver
+			if name != "ODBC" && name != "ODBC Drivers" {
+				drivers = append(drivers, Driver{Name: name})
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || len(drivers) == 0 {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Driver":
+			drivers[len(drivers)-1].Driver = strings.TrimSpace(value)
+		case "Installer":
+			drivers[len(drivers)-1].Installer = strings.TrimSpace(value)
+		}
+	}
+	return drivers
+}
+
+// ScanProDrivers reports the drivers listed in /etc/odbcinst.ini and whether their driver libraries exist
+func ScanProDrivers() (Inventory, error) {
+	inventory := Inventory{Drivers: []Driver{}}
+	contents, err := os.ReadFile("/etc/odbcinst.ini")
+	if errors.Is(err, os.ErrNotExist) {
+		return inventory, nil
+	} else if err != nil {
+		return inventory, fmt.Errorf("issue reading /etc/odbcinst.ini: %w", err)
+	}
+
+	for _, driver := range parseODBCInst(string(contents)) {
+		if driver.Driver != "" {
+			_, err := os.Stat(driver.Driver)
+			driver.Missing = err != nil
+		}
+		if driver.IsProDriver() {
+			inventory.ProDriversInstalled = true
+		}
+		inventory.Drivers = append(inventory.Drivers, driver)
+	}
+	return inventory, nil
+}
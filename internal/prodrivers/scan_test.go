@@ -0,0 +1,51 @@
+package prodrivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseODBCInst(t *testing.T) {
+	tests := map[string]struct {
+		contents string
+		expected []Driver
+	}{
+		"Pro Drivers and a system driver": {
+			contents: `[ODBC]
+Trace = no
+
+# installed by the Pro Drivers
+[PostgreSQL]
+Driver = /opt/rstudio-drivers/postgresql/bin/lib/libpostgresqlodbc_sb64.so
+Installer = RStudio Pro Drivers
+
+[SQLite3]
+Description=SQLite ODBC Driver
+Driver=libsqlite3odbc.so
+`,
+			expected: []Driver{
+				{Name: "PostgreSQL", Driver: "/opt/rstudio-drivers/postgresql/bin/lib/libpostgresqlodbc_sb64.so", Installer: "RStudio Pro Drivers"},
+				{Name: "SQLite3", Driver: "libsqlite3odbc.so"},
+			},
+		},
+		"settings outside of a driver section are skipped": {
+			contents: "Driver = /usr/lib/orphan.so\n[ODBC Drivers]\nPostgreSQL = Installed\n; [Commented]\n",
+			expected: []Driver{},
+		},
+		"empty file": {
+			contents: "",
+			expected: []Driver{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			drivers := parseODBCInst(tc.contents)
+			assert.Equal(t, tc.expected, drivers)
+			for _, driver := range drivers {
+				assert.Equal(t, driver.Installer == "RStudio Pro Drivers", driver.IsProDriver())
+			}
+		})
+	}
+}
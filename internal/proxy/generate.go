@@ -0,0 +1,234 @@
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/sol-eng/wbi/internal/config"
+)
+
+// ProxyConfig contains the information needed to generate a reverse proxy config for Workbench
+type ProxyConfig struct {
+	ServerName string
+	CertPath   string
+	KeyPath    string
+	// UserHeader and SignInURL are only set when generating a config for proxied authentication
+	UserHeader string
+	SignInURL  string
+}
+
+// the address Workbench listens on when it sits behind a reverse proxy
+var workbenchUpstream = "127.0.0.1:8787"
+
+var nginxTemplate = `# This file was generated by the Workbench Installer (wbi)
+map $http_upgrade $connection_upgrade {
+  default upgrade;
+  ''      close;
+}
+
+server {
+  listen 80;
+  server_name {{.ServerName}};
+  return 301 https://$host$request_uri;
+}
+
+server {
+  listen 443 ssl;
+  server_name {{.ServerName}};
+
+  ssl_certificate {{.CertPath}};
+  ssl_certificate_key {{.KeyPath}};
+
+  # allow large uploads, Workbench enforces its own limits
+  client_max_body_size 0;
+
+  location / {
+    proxy_pass http://{{.Upstream}};
+    proxy_http_version 1.1;
+    proxy_set_header Upgrade $http_upgrade;
+    proxy_set_header Connection $connection_upgrade;
+    proxy_set_header Host $host;
+    proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
+    proxy_set_header X-Forwarded-Proto $scheme;
+    proxy_set_header X-Forwarded-Host $host;
+    proxy_read_timeout 20d;
+    proxy_buffering off;
+    proxy_request_buffering off;
+{{- if .UserHeader}}
+
+    # the authenticated username must be placed in this header by the authentication module in front of Workbench
+    proxy_set_header {{.UserHeader}} $remote_user;
+{{- end}}
+{{- if .SignInURL}}
+
+    error_page 401 = @sign_in;
+{{- end}}
+  }
+{{- if .SignInURL}}
+
+  location @sign_in {
+    return 302 {{.SignInURL}};
+  }
+{{- end}}
+}
+`
+
+var apacheTemplate = `# This file was generated by the Workbench Installer (wbi)
+<VirtualHost *:80>
+  ServerName {{.ServerName}}
+  Redirect permanent / https://{{.ServerName}}/
+</VirtualHost>
+
+<VirtualHost *:443>
+  ServerName {{.ServerName}}
+
+  SSLEngine on
+  SSLCertificateFile {{.CertPath}}
+  SSLCertificateKeyFile {{.KeyPath}}
+
+  # allow large uploads, Workbench enforces its own limits
+  LimitRequestBody 0
+
+  RewriteEngine on
+  RewriteCond %{HTTP:Upgrade} =websocket
+  RewriteRule /(.*) ws://{{.Upstream}}/$1 [P,L]
+  RewriteCond %{HTTP:Upgrade} !=websocket
+  RewriteRule /(.*) http://{{.Upstream}}/$1 [P,L]
+
+  ProxyPass / http://{{.Upstream}}/
+  ProxyPassReverse / http://{{.Upstream}}/
+  ProxyPreserveHost On
+  ProxyRequests Off
+  ProxyTimeout 1728000
+  RequestHeader set X-Forwarded-Proto "https"
+  RequestHeader set X-Forwarded-Host "{{.ServerName}}"
+{{- if .UserHeader}}
+
+  # the authenticated username must be placed in this header by the authentication module in front of Workbench
+  RequestHeader set {{.UserHeader}} expr=%{REMOTE_USER}
+{{- end}}
+{{- if .SignInURL}}
+
+  ErrorDocument 401 {{.SignInURL}}
+{{- end}}
+</VirtualHost>
+`
+
+var traefikTemplate = `# This file was generated by the Workbench Installer (wbi)
+http:
+  routers:
+    workbench-http:
+      rule: "Host(` + "`{{.ServerName}}`" + `)"
+      entryPoints:
+        - web
+      middlewares:
+        - workbench-redirect
+      service: workbench
+    workbench:
+      rule: "Host(` + "`{{.ServerName}}`" + `)"
+      entryPoints:
+        - websecure
+      middlewares:
+        - workbench-headers
+      service: workbench
+      tls: {}
+  middlewares:
+    workbench-redirect:
+      redirectScheme:
+        scheme: https
+        permanent: true
+    workbench-headers:
+      headers:
+        customRequestHeaders:
+          X-Forwarded-Proto: "https"
+  services:
+    workbench:
+      loadBalancer:
+        passHostHeader: true
+        servers:
+          - url: "http://{{.Upstream}}"
+
+tls:
+  certificates:
+    - certFile: {{.CertPath}}
+      keyFile: {{.KeyPath}}
+`
+
+// ValidProxyServers returns the reverse proxy servers wbi can generate a config for
+func ValidProxyServers() []string {
+	return []string{"nginx", "apache", "traefik"}
+}
+
+// ServerNameFromURL removes the scheme, port and any path from a server URL
+func ServerNameFromURL(serverURL string) (string, error) {
+	if !strings.Contains(serverURL, "://") {
+		serverURL = "https://" + serverURL
+	}
+	parsedURL, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("issue parsing the server URL %s: %w", serverURL, err)
+	}
+	if parsedURL.Hostname() == "" {
+		return "", errors.New("no hostname found in the server URL " + serverURL)
+	}
+	return parsedURL.Hostname(), nil
+}
+
+// GenerateProxyConfig renders the reverse proxy config for the chosen proxy server
+func GenerateProxyConfig(proxyServer string, proxyConfig ProxyConfig) (string, error) {
+	var proxyTemplate string
+	switch proxyServer {
+	case "nginx":
+		proxyTemplate = nginxTemplate
+	case "apache":
+		proxyTemplate = apacheTemplate
+	case "traefik":
+		if proxyConfig.UserHeader != "" {
+			return "", errors.New("proxied authentication is not supported for traefik")
+		}
+		proxyTemplate = traefikTemplate
+	default:
+		return "", errors.New("proxy server " + proxyServer + " is not supported")
+	}
+
+	parsedTemplate, err := template.New(proxyServer).Parse(proxyTemplate)
+	if err != nil {
+		return "", fmt.Errorf("issue parsing the %s template: %w", proxyServer, err)
+	}
+	templateData := struct {
+		ProxyConfig
+		Upstream string
+	}{proxyConfig, workbenchUpstream}
+
+	var buf bytes.Buffer
+	err = parsedTemplate.Execute(&buf, templateData)
+	if err != nil {
+		return "", fmt.Errorf("issue rendering the %s template: %w", proxyServer, err)
+	}
+	return buf.String(), nil
+}
+
+// ProxyConfigPath returns the location of the generated proxy config based on the operating system
+func ProxyConfigPath(proxyServer string, osType config.OperatingSystem) (string, error) {
+	switch proxyServer {
+	case "nginx":
+		return "/etc/nginx/conf.d/rstudio-workbench.conf", nil
+	case "traefik":
+		return "/etc/traefik/dynamic/rstudio-workbench.yml", nil
+	case "apache":
+		switch osType {
+		case config.Ubuntu20, config.Ubuntu22:
+			return "/etc/apache2/sites-available/rstudio-workbench.conf", nil
+		case config.Redhat7, config.Redhat8, config.Redhat9:
+			return "/etc/httpd/conf.d/rstudio-workbench.conf", nil
+		default:
+			return "", errors.New("operating system not supported")
+		}
+	default:
+		return "", errors.New("proxy server " + proxyServer + " is not supported")
+	}
+}
@@ -0,0 +1,151 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateProxyConfig(t *testing.T) {
+	baseConfig := ProxyConfig{
+		ServerName: "workbench.example.com",
+		CertPath:   "/etc/ssl/workbench.crt",
+		KeyPath:    "/etc/ssl/workbench.key",
+	}
+	authConfig := baseConfig
+	authConfig.UserHeader = "X-Auth-User"
+	authConfig.SignInURL = "https://sso.example.com/login"
+
+	tests := map[string]struct {
+		proxyServer string
+		config      ProxyConfig
+		contains    []string
+		notContains []string
+		expectError string
+	}{
+		"nginx without proxied authentication terminates TLS and redirects http": {
+			proxyServer: "nginx",
+			config:      baseConfig,
+			contains: []string{
+				"return 301 https://$host$request_uri;",
+				"listen 443 ssl;",
+				"proxy_set_header Upgrade $http_upgrade;",
+				"proxy_pass http://127.0.0.1:8787;",
+			},
+			notContains: []string{"$remote_user", "@sign_in"},
+		},
+		"apache without proxied authentication terminates TLS and redirects http": {
+			proxyServer: "apache",
+			config:      baseConfig,
+			contains: []string{
+				"Redirect permanent / https://workbench.example.com/",
+				"SSLEngine on",
+				"ProxyPass / http://127.0.0.1:8787/",
+				`RequestHeader set X-Forwarded-Host "workbench.example.com"`,
+			},
+			notContains: []string{"REMOTE_USER", "ErrorDocument"},
+		},
+		"traefik routes the host to Workbench with the certificate": {
+			proxyServer: "traefik",
+			config:      baseConfig,
+			contains: []string{
+				"rule: \"Host(`workbench.example.com`)\"",
+				"- url: \"http://127.0.0.1:8787\"",
+				"- certFile: /etc/ssl/workbench.crt",
+				"keyFile: /etc/ssl/workbench.key",
+				"scheme: https",
+			},
+		},
+		"nginx with proxied authentication sets the user header and sign in redirect": {
+			proxyServer: "nginx",
+			config:      authConfig,
+			contains: []string{
+				"server_name workbench.example.com;",
+				"ssl_certificate /etc/ssl/workbench.crt;",
+				"ssl_certificate_key /etc/ssl/workbench.key;",
+				"proxy_pass http://127.0.0.1:8787;",
+				"proxy_set_header X-Auth-User $remote_user;",
+				"error_page 401 = @sign_in;",
+				"return 302 https://sso.example.com/login;",
+			},
+		},
+		"nginx with a user header and no sign in URL has no sign in redirect": {
+			proxyServer: "nginx",
+			config:      ProxyConfig{ServerName: "wb", CertPath: "c", KeyPath: "k", UserHeader: "X-Auth-User"},
+			contains:    []string{"proxy_set_header X-Auth-User $remote_user;"},
+			notContains: []string{"@sign_in"},
+		},
+		"apache with proxied authentication sets the user header and sign in document": {
+			proxyServer: "apache",
+			config:      authConfig,
+			contains: []string{
+				"ServerName workbench.example.com",
+				"SSLCertificateFile /etc/ssl/workbench.crt",
+				"SSLCertificateKeyFile /etc/ssl/workbench.key",
+				"RewriteRule /(.*) ws://127.0.0.1:8787/$1 [P,L]",
+				"RequestHeader set X-Auth-User expr=%{REMOTE_USER}",
+				"ErrorDocument 401 https://sso.example.com/login",
+			},
+		},
+		"traefik with proxied authentication fails": {
+			proxyServer: "traefik",
+			config:      authConfig,
+			expectError: "proxied authentication is not supported for traefik",
+		},
+		"unknown proxy server fails": {
+			proxyServer: "caddy",
+			config:      baseConfig,
+			expectError: "proxy server caddy is not supported",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			generated, err := GenerateProxyConfig(tc.proxyServer, tc.config)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			for _, expected := range tc.contains {
+				assert.Contains(t, generated, expected)
+			}
+			for _, unexpected := range tc.notContains {
+				assert.NotContains(t, generated, unexpected)
+			}
+		})
+	}
+}
+
+func TestServerNameFromURL(t *testing.T) {
+	tests := map[string]struct {
+		serverURL   string
+		expected    string
+		expectError string
+	}{
+		"https URL with a port and path": {
+			serverURL: "https://workbench.example.com:8443/path",
+			expected:  "workbench.example.com",
+		},
+		"hostname without a scheme": {
+			serverURL: "workbench.example.com",
+			expected:  "workbench.example.com",
+		},
+		"URL without a hostname fails": {
+			serverURL:   "https://",
+			expectError: "no hostname found",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverName, err := ServerNameFromURL(tc.serverURL)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, serverName)
+		})
+	}
+}
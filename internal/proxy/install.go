@@ -0,0 +1,126 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// ubuntuApacheSiteEnabled is where a2ensite links the Workbench site on Ubuntu
+var ubuntuApacheSiteEnabled = "/etc/apache2/sites-enabled/rstudio-workbench.conf"
+
+// TestProxyConfig runs the proxy server's own config test if the proxy server is installed
+func TestProxyConfig(proxyServer string, osType config.OperatingSystem) error {
+	testBinary, testArgs, err := proxyTestCommand(proxyServer, osType)
+	if err != nil {
+		return err
+	}
+	if testBinary == "" {
+		system.PrintAndLogInfo("\nSkipping the config test, " + proxyServer + " does not provide a config test command")
+		return nil
+	}
+
+	if _, err := exec.LookPath(testBinary); err != nil {
+		system.PrintAndLogInfo("\nSkipping the config test, " + proxyServer + " is not installed")
+		return nil
+	}
+
+	testCommand := testBinary + " " + testArgs
+	err = system.RunCommand(testCommand, true, 0, true)
+	if err != nil {
+		return fmt.Errorf("the %s config test failed with the command '%s': %w", proxyServer, testCommand, err)
+	}
+	return nil
+}
+
+// proxyTestCommand returns the binary and arguments that test a proxy server's config, or an empty binary if the proxy server
+// has no config test. The Workbench site isn't loaded on Ubuntu until it is enabled, so Apache includes it for the test
+func proxyTestCommand(proxyServer string, osType config.OperatingSystem) (string, string, error) {
+	switch proxyServer {
+	case "nginx":
+		return "nginx", "-t", nil
+	case "apache":
+		switch osType {
+		case config.Ubuntu20, config.Ubuntu22:
+			if system.VerifyFileExists(ubuntuApacheSiteEnabled) {
+				return "apache2ctl", "configtest", nil
+			}
+			configPath, err := ProxyConfigPath(proxyServer, osType)
+			if err != nil {
+				return "", "", err
+			}
+			return "apache2ctl", "-t -c " + system.ShellQuote("Include "+configPath), nil
+		default:
+			return "apachectl", "configtest", nil
+		}
+	default:
+		return "", "", nil
+	}
+}
+
+// proxyServerCommands returns the commands that install a proxy server package with the modules Workbench needs, and the
+// commands that enable the Workbench site and start or restart the service
+func proxyServerCommands(proxyServer string, osType config.OperatingSystem) ([]string, []string, error) {
+	switch osType {
+	case config.Ubuntu20, config.Ubuntu22:
+		switch proxyServer {
+		case "nginx":
+			return []string{"apt-get install -y nginx"},
+				[]string{"systemctl enable nginx", "systemctl restart nginx"}, nil
+		case "apache":
+			return []string{"apt-get install -y apache2", "a2enmod proxy proxy_http proxy_wstunnel rewrite headers ssl"},
+				[]string{"a2ensite rstudio-workbench", "systemctl enable apache2", "systemctl restart apache2"}, nil
+		}
+	case config.Redhat7, config.Redhat8, config.Redhat9:
+		switch proxyServer {
+		case "nginx":
+			return []string{"yum install -y nginx"},
+				[]string{"systemctl enable nginx", "systemctl restart nginx"}, nil
+		case "apache":
+			return []string{"yum install -y httpd mod_ssl"},
+				[]string{"systemctl enable httpd", "systemctl restart httpd"}, nil
+		}
+	default:
+		return nil, nil, errors.New("operating system not supported")
+	}
+	return nil, nil, errors.New(proxyServer + " is not available from the operating system package repositories, please install it manually")
+}
+
+// InstallProxyServer installs the proxy server package and the modules Workbench needs, without enabling the Workbench site
+// or restarting the service
+func InstallProxyServer(proxyServer string, osType config.OperatingSystem) error {
+	installCommands, _, err := proxyServerCommands(proxyServer, osType)
+	if err != nil {
+		return err
+	}
+	for _, command := range installCommands {
+		err := system.RunCommand(command, true, 1, true)
+		if err != nil {
+			return fmt.Errorf("issue installing %s with the command '%s': %w", proxyServer, command, err)
+		}
+	}
+
+	system.PrintAndLogInfo("\n" + proxyServer + " has been successfully installed!")
+	return nil
+}
+
+// EnableProxyServer enables the Workbench site and the proxy server service, then restarts it to load the config. It should
+// only be run once the config has passed its test
+func EnableProxyServer(proxyServer string, osType config.OperatingSystem) error {
+	_, enableCommands, err := proxyServerCommands(proxyServer, osType)
+	if err != nil {
+		return err
+	}
+	for _, command := range enableCommands {
+		err := system.RunCommand(command, true, 1, true)
+		if err != nil {
+			return fmt.Errorf("issue enabling %s with the command '%s': %w", proxyServer, command, err)
+		}
+	}
+
+	system.PrintAndLogInfo("\n" + proxyServer + " has been successfully enabled!")
+	return nil
+}
@@ -0,0 +1,78 @@
+package proxy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyServerCommands(t *testing.T) {
+	tests := map[string]struct {
+		proxyServer     string
+		osType          config.OperatingSystem
+		expectedInstall []string
+		expectedEnable  []string
+		expectError     string
+	}{
+		"apache on Ubuntu enables the modules on install and the site only on enable": {
+			proxyServer:     "apache",
+			osType:          config.Ubuntu22,
+			expectedInstall: []string{"apt-get install -y apache2", "a2enmod proxy proxy_http proxy_wstunnel rewrite headers ssl"},
+			expectedEnable:  []string{"a2ensite rstudio-workbench", "systemctl enable apache2", "systemctl restart apache2"},
+		},
+		"nginx on RHEL": {
+			proxyServer:     "nginx",
+			osType:          config.Redhat9,
+			expectedInstall: []string{"yum install -y nginx"},
+			expectedEnable:  []string{"systemctl enable nginx", "systemctl restart nginx"},
+		},
+		"traefik fails": {
+			proxyServer: "traefik",
+			osType:      config.Ubuntu22,
+			expectError: "traefik is not available from the operating system package repositories",
+		},
+		"unknown operating system fails": {
+			proxyServer: "nginx",
+			osType:      config.Unknown,
+			expectError: "operating system not supported",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			installCommands, enableCommands, err := proxyServerCommands(tc.proxyServer, tc.osType)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedInstall, installCommands)
+			assert.Equal(t, tc.expectedEnable, enableCommands)
+		})
+	}
+}
+
+func TestProxyTestCommand(t *testing.T) {
+	ubuntuApacheSiteEnabled = filepath.Join(t.TempDir(), "rstudio-workbench.conf")
+	defer func() { ubuntuApacheSiteEnabled = "/etc/apache2/sites-enabled/rstudio-workbench.conf" }()
+
+	// the site is included for the test until it is enabled
+	testBinary, testArgs, err := proxyTestCommand("apache", config.Ubuntu22)
+	assert.NoError(t, err)
+	assert.Equal(t, "apache2ctl", testBinary)
+	assert.Equal(t, "-t -c 'Include /etc/apache2/sites-available/rstudio-workbench.conf'", testArgs)
+
+	if err := os.WriteFile(ubuntuApacheSiteEnabled, nil, 0644); err != nil {
+		t.Fatalf("issue enabling the site: %v", err)
+	}
+	testBinary, testArgs, err = proxyTestCommand("apache", config.Ubuntu22)
+	assert.NoError(t, err)
+	assert.Equal(t, "apache2ctl configtest", testBinary+" "+testArgs)
+
+	testBinary, _, err = proxyTestCommand("traefik", config.Ubuntu22)
+	assert.NoError(t, err)
+	assert.Equal(t, "", testBinary)
+}
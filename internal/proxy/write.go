@@ -0,0 +1,111 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/ssl"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// VerifyProxyCertAndKey verifies the TLS/SSL cert and key used by the proxy the same way the ssl step does
+func VerifyProxyCertAndKey(certPath string, keyPath string) error {
+	err := ssl.VerifySSLCertAndKeyMD5Match(certPath, keyPath)
+	if err != nil {
+		return fmt.Errorf("could not verify the SSL cert: %w", err)
+	}
+	_, _, _, err = ssl.ParseCertificateChain(certPath)
+	if err != nil {
+		return fmt.Errorf("could not parse the certificate chain: %w", err)
+	}
+	return nil
+}
+
+// WriteProxyConfig generates the reverse proxy config, writes it to the proxy server's config directory and tests it. The
+// previous config is put back when the test fails
+func WriteProxyConfig(proxyServer string, proxyConfig ProxyConfig, osType config.OperatingSystem) (string, error) {
+	proxyConfigContent, err := GenerateProxyConfig(proxyServer, proxyConfig)
+	if err != nil {
+		return "", fmt.Errorf("issue generating the %s config: %w", proxyServer, err)
+	}
+	configPath, err := ProxyConfigPath(proxyServer, osType)
+	if err != nil {
+		return "", fmt.Errorf("issue determining the %s config location: %w", proxyServer, err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(configPath), 0755)
+	if err != nil {
+		return "", fmt.Errorf("error creating directory: %w", err)
+	}
+
+	// keep the current config so it can be put back if the new one fails its test
+	previousConfig, err := os.ReadFile(configPath)
+	hadConfig := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("issue reading %s: %w", configPath, err)
+	}
+	err = system.MoveToBackup(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	writeLines := strings.Split(strings.TrimSuffix(proxyConfigContent, "\n"), "\n")
+	err = system.WriteStrings(writeLines, configPath, 0644, true, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to write config: %w", err)
+	}
+
+	err = TestProxyConfig(proxyServer, osType)
+	if err != nil {
+		restoreErr := restoreProxyConfig(configPath, previousConfig, hadConfig)
+		if restoreErr != nil {
+			return "", fmt.Errorf("%w, and the previous config could not be restored: %v", err, restoreErr)
+		}
+		return "", fmt.Errorf("%w, the previous config has been restored", err)
+	}
+	return configPath, nil
+}
+
+// restoreProxyConfig puts the previous contents of a proxy config back, or removes the config if there was none before
+func restoreProxyConfig(configPath string, previousConfig []byte, hadConfig bool) error {
+	if !hadConfig {
+		err := os.Remove(configPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("issue removing %s: %w", configPath, err)
+		}
+		return nil
+	}
+	err := os.WriteFile(configPath, previousConfig, 0644)
+	if err != nil {
+		return fmt.Errorf("issue restoring %s: %w", configPath, err)
+	}
+	return nil
+}
+
+// ProxyNextSteps returns the steps needed to enable the generated proxy config
+func ProxyNextSteps(proxyServer string, osType config.OperatingSystem) string {
+	if proxyServer == "apache" {
+		switch osType {
+		case config.Ubuntu20, config.Ubuntu22:
+			return "\nApache next steps:\nEnable the required modules and the site, then restart Apache:\n" +
+				"  a2enmod proxy proxy_http proxy_wstunnel rewrite headers ssl\n" +
+				"  a2ensite rstudio-workbench\n" +
+				"  systemctl restart apache2"
+		default:
+			return "\nApache next steps:\nEnsure mod_ssl is installed, then restart Apache:\n" +
+				"  yum install -y mod_ssl\n" +
+				"  systemctl restart httpd"
+		}
+	}
+	if proxyServer == "traefik" {
+		return "\ntraefik next steps:\nEnsure the traefik static configuration defines the web (:80) and websecure (:443) entrypoints\n" +
+			"and loads dynamic configuration from /etc/traefik/dynamic, then restart traefik."
+	}
+	return "\nnginx next steps:\nTest the config and restart nginx:\n" +
+		"  nginx -t\n" +
+		"  systemctl restart nginx"
+}
@@ -0,0 +1,27 @@
+package proxy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreProxyConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "rstudio-workbench.conf")
+	if err := os.WriteFile(configPath, []byte("broken"), 0644); err != nil {
+		t.Fatalf("issue writing the config: %v", err)
+	}
+
+	// the previous contents are put back
+	assert.NoError(t, restoreProxyConfig(configPath, []byte("server {}\n"), true))
+	contents, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "server {}\n", string(contents))
+
+	// a config that didn't exist before is removed
+	assert.NoError(t, restoreProxyConfig(configPath, nil, false))
+	assert.NoFileExists(t, configPath)
+	assert.NoError(t, restoreProxyConfig(configPath, nil, false))
+}
@@ -3,7 +3,10 @@ package quarto
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/sol-eng/wbi/internal/config"
 	"github.com/sol-eng/wbi/internal/system"
 )
 
@@ -39,7 +42,7 @@ func CheckAndSetQuartoSymlink(quartoPath string) error {
 			return fmt.Errorf("issue setting Quarto symlinks: %w", err)
 		}
 	} else {
-		system.PrintAndLogInfo("Quarto symlink already exist, skipping symlink creation")
+		system.PrintAndLogInfo("Quarto symlink already exist, skipping symlink creation. Use \"wbi default quarto [version]\" to change the default Quarto version.")
 	}
 	return nil
 }
@@ -66,3 +69,21 @@ func checkIfQuartoSymlinkExists() bool {
 	system.PrintAndLogInfo("\nAn existing Quarto symlink has been detected (/usr/local/bin/quarto)")
 	return true
 }
+
+// MoveQuartoSymlink moves the Quarto symlink from one installed Quarto version to another if it currently points at the old version
+func MoveQuartoSymlink(oldVersion string, newVersion string) (bool, error) {
+	target, err := filepath.EvalSymlinks("/usr/local/bin/quarto")
+	if err != nil || !strings.HasPrefix(target, config.InstallDir("quarto", oldVersion)+"/") {
+		return false, nil
+	}
+	removeCommand := "rm -f /usr/local/bin/quarto"
+	err = system.RunCommand(removeCommand, true, 0, true)
+	if err != nil {
+		return false, fmt.Errorf("error removing the Quarto symlink with the command '%s': %w", removeCommand, err)
+	}
+	err = setQuartoSymlinks(QuartoBinary(newVersion), true)
+	if err != nil {
+		return false, fmt.Errorf("issue setting Quarto symlinks: %w", err)
+	}
+	return true, nil
+}
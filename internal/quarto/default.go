@@ -0,0 +1,34 @@
+package quarto
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// CurrentDefaultQuarto returns the Quarto install /usr/local/bin/quarto points to, or "" if it doesn't exist
+func CurrentDefaultQuarto() string {
+	target, err := filepath.EvalSymlinks("/usr/local/bin/quarto")
+	if err != nil {
+		return ""
+	}
+	return target
+}
+
+// SetDefaultQuarto points /usr/local/bin/quarto at an installed Quarto version, replacing any existing symlink
+func SetDefaultQuarto(version string) error {
+	quartoPath, err := languages.InstalledVersionPath("Quarto", config.InstallRoot("quarto"), version, "quarto")
+	if err != nil {
+		return err
+	}
+
+	err = system.ReplaceSymlink(quartoPath, "/usr/local/bin/quarto")
+	if err != nil {
+		return fmt.Errorf("issue setting the Quarto symlink: %w", err)
+	}
+	system.PrintAndLogInfo("\n/usr/local/bin/quarto now points to Quarto " + version)
+	return nil
+}
@@ -1,29 +1,53 @@
 package quarto
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hashicorp/go-version"
 	"github.com/samber/lo"
 	log "github.com/sirupsen/logrus"
 	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/languages"
 	cmdlog "github.com/sol-eng/wbi/internal/logging"
 	"github.com/sol-eng/wbi/internal/system"
 )
 
-func RetrieveValidQuartoVersions() ([]string, error) {
+var quartoTarballName = regexp.MustCompile(`^quarto-(\d+\.\d+\.\d+)-`)
+
+// QuartoBinary returns the path to the quarto binary of a version installed in the install prefix, such as /opt/quarto/<version>/bin/quarto
+func QuartoBinary(quartoVersion string) string {
+	return filepath.Join(config.InstallDir("quarto", quartoVersion), "bin", "quarto")
+}
+
+func RetrieveValidQuartoVersions(osType config.OperatingSystem) ([]string, error) {
 	// TODO automate the retrieving the list of valid versions
-	return []string{"1.3.340", "1.2.475", "1.1.189", "1.0.38"}, nil
+	versions, err := languages.ConvertStringSliceToVersionSlice([]string{"1.3.340", "1.2.475", "1.1.189", "1.0.38"})
+	if err != nil {
+		return []string{}, fmt.Errorf("issue converting string slice to version slice: %w", err)
+	}
+
+	versions, err = languages.FilterCompatibleVersions("quarto", versions, osType)
+	if err != nil {
+		return []string{}, fmt.Errorf("issue removing Quarto versions unavailable on this operating system: %w", err)
+	}
+
+	return languages.ConvertVersionSliceToStringSlice(languages.SortVersionsDesc(versions)), nil
 }
 
-func ValidateQuartoVersions(quartoVersions []string) error {
-	availQuartoVersions, err := RetrieveValidQuartoVersions()
+func ValidateQuartoVersions(quartoVersions []string, osType config.OperatingSystem) error {
+	availQuartoVersions, err := RetrieveValidQuartoVersions(osType)
 	if err != nil {
 		return fmt.Errorf("error retrieving valid Quarto versions: %w", err)
 	}
@@ -35,6 +59,20 @@ func ValidateQuartoVersions(quartoVersions []string) error {
 	return nil
 }
 
+// ResolveQuartoVersions resolves Quarto version values such as 1.3, latest, latest-3 or >=1.2,<1.4 to exact versions
+func ResolveQuartoVersions(quartoVersions []string, osType config.OperatingSystem) ([]string, error) {
+	availQuartoVersions, err := RetrieveValidQuartoVersions(osType)
+	if err != nil {
+		return []string{}, fmt.Errorf("error retrieving valid Quarto versions: %w", err)
+	}
+	resolutions, err := languages.ResolveVersions("Quarto", quartoVersions, availQuartoVersions)
+	if err != nil {
+		return []string{}, err
+	}
+	languages.PrintVersionResolutions("Quarto", resolutions)
+	return languages.ResolvedVersions(resolutions), nil
+}
+
 func DownloadAndInstallQuartoVersions(quartoVersions []string, osType config.OperatingSystem) error {
 	for _, quartoVersion := range quartoVersions {
 		err := DownloadAndInstallQuarto(quartoVersion, osType)
@@ -59,10 +97,10 @@ func DownloadAndInstallQuarto(quartoVersion string, osType config.OperatingSyste
 		return fmt.Errorf("InstallQuarto: %w", err)
 	}
 	// save to command log
-	quartoPath := fmt.Sprintf("/opt/quarto/%s", quartoVersion)
+	quartoPath := config.InstallDir("quarto", quartoVersion)
 	cmdlog.Info("curl -o quarto.tar.gz -L " + quartoURL)
 	cmdlog.Info("mkdir -p " + quartoPath)
-	cmdlog.Info(fmt.Sprintf(`tar -zxvf quarto.tar.gz -C "%s" --strip-components=1`, quartoPath))
+	cmdlog.Info("tar -zxvf quarto.tar.gz -C " + system.ShellQuote(quartoPath) + " --strip-components=1")
 	cmdlog.Info("rm quarto.tar.gz")
 	return nil
 }
@@ -115,10 +153,80 @@ func downloadFileQuarto(url string, version string, osType config.OperatingSyste
 	return tmpFile.Name(), nil
 }
 
+// QuartoVersionFromTarball reads the Quarto version from the share/version file of a Quarto release tarball, falling back
+// to the version in the file name
+func QuartoVersionFromTarball(tarballPath string) (string, error) {
+	file, err := os.Open(tarballPath)
+	if err != nil {
+		return "", fmt.Errorf("issue opening %s: %w", tarballPath, err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return "", fmt.Errorf("issue reading %s as a gzip archive: %w", tarballPath, err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("issue reading %s as a tar archive: %w", tarballPath, err)
+		}
+		// the version file is share/version below the top level quarto-<version> directory
+		segments := strings.Split(strings.TrimPrefix(header.Name, "./"), "/")
+		if len(segments) != 3 || segments[1] != "share" || segments[2] != "version" {
+			continue
+		}
+		contents, err := io.ReadAll(io.LimitReader(tarReader, 256))
+		if err != nil {
+			return "", fmt.Errorf("issue reading the Quarto version from %s: %w", tarballPath, err)
+		}
+		return validateQuartoFileVersion(strings.TrimSpace(string(contents)), tarballPath)
+	}
+
+	match := quartoTarballName.FindStringSubmatch(filepath.Base(tarballPath))
+	if match == nil {
+		return "", errors.New("the Quarto version could not be found in " + tarballPath)
+	}
+	return validateQuartoFileVersion(match[1], tarballPath)
+}
+
+// validateQuartoFileVersion returns an error if the version read from a tarball is not a valid version
+func validateQuartoFileVersion(quartoVersion string, tarballPath string) (string, error) {
+	if _, err := version.NewVersion(quartoVersion); err != nil {
+		return "", fmt.Errorf("issue parsing the Quarto version %s found in %s: %w", quartoVersion, tarballPath, err)
+	}
+	return quartoVersion, nil
+}
+
+// InstallQuartoFromFile installs Quarto from a local release tarball, such as one pre-scanned by a security team, and
+// returns the version read from the tarball
+func InstallQuartoFromFile(tarballPath string, osType config.OperatingSystem) (string, error) {
+	quartoVersion, err := QuartoVersionFromTarball(tarballPath)
+	if err != nil {
+		return "", err
+	}
+	system.PrintAndLogInfo("Installing Quarto " + quartoVersion + " from the local tarball " + tarballPath)
+	err = installQuarto(tarballPath, osType, quartoVersion, true)
+	if err != nil {
+		return "", fmt.Errorf("InstallQuarto: %w", err)
+	}
+	// save to command log
+	quartoPath := config.InstallDir("quarto", quartoVersion)
+	cmdlog.Info("mkdir -p " + quartoPath)
+	cmdlog.Info("tar -zxvf " + system.ShellQuote(tarballPath) + " -C " + system.ShellQuote(quartoPath) + " --strip-components=1")
+	return quartoVersion, nil
+}
+
 // Installs Quarto
 func installQuarto(filepath string, osType config.OperatingSystem, version string, save bool) error {
-	// create the /opt/quarto directory if it doesn't exist
-	path := fmt.Sprintf("/opt/quarto/%s", version)
+	// create the version's directory in the install prefix if it doesn't exist
+	path := config.InstallDir("quarto", version)
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		err := os.MkdirAll(path, 0755)
 		if err != nil {
@@ -126,12 +234,16 @@ func installQuarto(filepath string, osType config.OperatingSystem, version strin
 		}
 	}
 
-	installCommand := fmt.Sprintf(`tar -zxvf "%s" -C "%s" --strip-components=1`, filepath, path)
+	installCommand := "tar -zxvf " + system.ShellQuote(filepath) + " -C " + system.ShellQuote(path) + " --strip-components=1"
 
 	err := system.RunCommand(installCommand, false, 0, false)
 	if err != nil {
 		return fmt.Errorf("the command '%s' failed to run: %w", installCommand, err)
 	}
+	err = config.SaveInstallPrefix()
+	if err != nil {
+		return fmt.Errorf("issue saving the install prefix: %w", err)
+	}
 
 	successMessage := "\nQuarto version " + version + " successfully installed!\n"
 	system.PrintAndLogInfo(successMessage)
@@ -0,0 +1,49 @@
+package quarto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuartoVersionFromTarball(t *testing.T) {
+	notGzip := filepath.Join(t.TempDir(), "quarto-1.4.550-linux-amd64.tar.gz")
+	assert.NoError(t, os.WriteFile(notGzip, []byte("not a tarball"), 0644))
+
+	tests := map[string]struct {
+		tarballPath string
+		expected    string
+		expectError string
+	}{
+		"version read from share/version": {
+			tarballPath: filepath.Join("testdata", "quarto-linux-amd64.tar.gz"),
+			expected:    "1.4.550",
+		},
+		"version read from the file name without share/version": {
+			tarballPath: filepath.Join("testdata", "quarto-1.3.450-linux-amd64.tar.gz"),
+			expected:    "1.3.450",
+		},
+		"missing tarball fails": {
+			tarballPath: filepath.Join("testdata", "quarto-9.9.9-linux-amd64.tar.gz"),
+			expectError: "issue opening",
+		},
+		"file that is not gzipped fails": {
+			tarballPath: notGzip,
+			expectError: "as a gzip archive",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, err := QuartoVersionFromTarball(tc.tarballPath)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, version)
+		})
+	}
+}
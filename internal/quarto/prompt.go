@@ -33,7 +33,7 @@ func ScanAndHandleQuartoVersions(osType config.OperatingSystem) error {
 
 	if quartoInstall {
 		// retrieve other versions and present them to the user
-		validQuartoVersions, err := RetrieveValidQuartoVersions()
+		validQuartoVersions, err := RetrieveValidQuartoVersions(osType)
 		if err != nil {
 			return fmt.Errorf("there was an issue retrieving valid Quarto versions: %w", err)
 		}
@@ -77,11 +77,11 @@ func ScanAndHandleQuartoVersions(osType config.OperatingSystem) error {
 	return nil
 }
 
-// quartoVersionsToPaths converts Quarto versions to full paths in /opt
+// quartoVersionsToPaths converts Quarto versions to full paths in the install prefix
 func quartoVersionsToPaths(quartoVersions []string) []string {
 	quartoPaths := []string{}
 	for _, version := range quartoVersions {
-		quartoPaths = append(quartoPaths, "/opt/quarto/"+version+"/bin/quarto")
+		quartoPaths = append(quartoPaths, QuartoBinary(version))
 	}
 	return quartoPaths
 }
@@ -0,0 +1,87 @@
+package quarto
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/languages"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+const bundledQuartoPath = "/usr/lib/rstudio-server/bin/quarto/bin/quarto"
+
+// Installation is a Quarto install found on the server
+type Installation struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Origin  string `json:"origin"`
+	Problem string `json:"problem,omitempty"`
+}
+
+// Inventory describes the Quarto installs on the server and which one /usr/local/bin/quarto points to
+type Inventory struct {
+	Installations []Installation `json:"installations"`
+	SymlinkTarget string         `json:"symlink_target"`
+}
+
+// scanTimeout is how long a Quarto binary is given to report its version
+const scanTimeout = 15 * time.Second
+
+var quartoVersionLine = regexp.MustCompile(`^\d+\.\d+\.\d+\S*$`)
+
+// parseQuartoVersion returns the version printed by quarto --version, skipping any warnings printed before it
+func parseQuartoVersion(output string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); quartoVersionLine.MatchString(line) {
+			return line, nil
+		}
+	}
+	return "", errors.New("unable to determine the version from quarto --version")
+}
+
+// inspectQuarto runs a Quarto binary to determine its version
+func inspectQuarto(path string, origin string) Installation {
+	installation := Installation{Path: path, Origin: origin}
+	versionOutput, err := system.RunCommandWithTimeout(scanTimeout, path, "--version")
+	if err != nil {
+		installation.Problem = fmt.Sprintf("issue running %s --version: %v", path, err)
+		return installation
+	}
+	installation.Version, err = parseQuartoVersion(versionOutput)
+	if err != nil {
+		installation.Problem = err.Error()
+	}
+	return installation
+}
+
+// ScanQuarto reports the Quarto versions in the install prefix, the version bundled with Workbench and the /usr/local/bin/quarto symlink target
+func ScanQuarto() (Inventory, error) {
+	inventory := Inventory{Installations: []Installation{}}
+
+	optVersions, err := languages.ScanOptVersions(config.InstallRoot("quarto"))
+	if err != nil {
+		return inventory, fmt.Errorf("issue scanning %s: %w", config.InstallRoot("quarto"), err)
+	}
+	for _, optVersion := range optVersions {
+		inventory.Installations = append(inventory.Installations, inspectQuarto(QuartoBinary(optVersion), config.InstallPrefix()))
+	}
+
+	if _, err := os.Stat(bundledQuartoPath); err == nil {
+		inventory.Installations = append(inventory.Installations, inspectQuarto(bundledQuartoPath, "bundled with Workbench"))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return inventory, fmt.Errorf("issue checking for %s: %w", bundledQuartoPath, err)
+	}
+
+	target, err := filepath.EvalSymlinks("/usr/local/bin/quarto")
+	if err == nil {
+		inventory.SymlinkTarget = target
+	}
+	return inventory, nil
+}
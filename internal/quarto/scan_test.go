@@ -0,0 +1,44 @@
+package quarto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuartoVersion(t *testing.T) {
+	tests := map[string]struct {
+		output      string
+		expected    string
+		expectError string
+	}{
+		"release version": {
+			output:   "1.4.550\n",
+			expected: "1.4.550",
+		},
+		"version after a warning": {
+			output:   "WARNING: deno cache is out of date\n1.3.340\n",
+			expected: "1.3.340",
+		},
+		"pre-release version": {
+			output:   "99.9.9-dev",
+			expected: "99.9.9-dev",
+		},
+		"output without a version fails": {
+			output:      "quarto: cannot execute binary file",
+			expectError: "unable to determine the version from quarto --version",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, err := parseQuartoVersion(tc.output)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, version)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// MoveToBackup moves a file that is about to be rewritten to a .bak next to it. An existing backup is never replaced, so
+// the .bak keeps the file as it was before wbi first changed it and the current file is removed instead
+func MoveToBackup(path string) error {
+	if !VerifyFileExists(path) {
+		return nil
+	}
+	backupPath := path + ".bak"
+	if VerifyFileExists(backupPath) {
+		PrintAndLogInfo("Keeping the existing backup " + backupPath)
+		err := os.Remove(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("issue removing %s: %w", path, err)
+		}
+		return nil
+	}
+
+	PrintAndLogInfo("Backing up " + path + " to " + backupPath)
+	err := os.Rename(path, backupPath)
+	if err != nil {
+		return fmt.Errorf("issue backing up %s: %w", path, err)
+	}
+	return nil
+}
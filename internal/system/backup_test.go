@@ -0,0 +1,34 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveToBackupKeepsTheFirstBackup(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "launcher.kubernetes.profiles.conf")
+
+	for _, contents := range []string{"original\n", "first edit\n"} {
+		err := os.WriteFile(configPath, []byte(contents), 0644)
+		if err != nil {
+			t.Fatalf("issue writing the config: %v", err)
+		}
+		assert.NoError(t, MoveToBackup(configPath))
+		assert.NoFileExists(t, configPath)
+	}
+
+	backup, err := os.ReadFile(configPath + ".bak")
+	if err != nil {
+		t.Fatalf("issue reading the backup: %v", err)
+	}
+	assert.Equal(t, "original\n", string(backup))
+}
+
+func TestMoveToBackupWithoutFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "missing.conf")
+	assert.NoError(t, MoveToBackup(configPath))
+	assert.NoFileExists(t, configPath+".bak")
+}
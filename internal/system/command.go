@@ -2,10 +2,13 @@ package system
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -67,3 +70,24 @@ func RunCommandAndCaptureOutput(command string, displayCommand bool, delay time.
 
 	return string(out), nil
 }
+
+// ShellQuote quotes a string so it is passed to /bin/sh as a single argument
+func ShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// ErrTimeout is returned by RunCommandWithTimeout when the command is killed for running too long
+var ErrTimeout = errors.New("timed out")
+
+// RunCommandWithTimeout runs a binary without a shell and returns its combined output, killing it if it does not finish within timeout.
+// The output is returned even when the command fails so callers can explain the failure.
+func RunCommandWithTimeout(timeout time.Duration, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return string(output), fmt.Errorf("%w after %s", ErrTimeout, timeout)
+	}
+	return string(output), err
+}
@@ -0,0 +1,23 @@
+package system
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCommandWithTimeout(t *testing.T) {
+	output, err := RunCommandWithTimeout(time.Minute, "echo", "it's", "quoted")
+	assert.NoError(t, err)
+	assert.Equal(t, "it's quoted\n", output)
+
+	// the output of a failed command is returned so it can be explained
+	output, err = RunCommandWithTimeout(time.Minute, "sh", "-c", "echo broken >&2; exit 3")
+	assert.ErrorContains(t, err, "exit status 3")
+	assert.Equal(t, "broken", strings.TrimSpace(output))
+
+	_, err = RunCommandWithTimeout(100*time.Millisecond, "sleep", "5")
+	assert.ErrorContains(t, err, "timed out after 100ms")
+}
@@ -23,15 +23,20 @@ func DeleteStrings(lines []string, filepath string, perm fs.FileMode) error {
 	fileScanner := bufio.NewScanner(file)
 
 	for fileScanner.Scan() {
+		matched := false
 		for _, line := range lines {
 			if strings.Contains(fileScanner.Text(), line) {
-				continue
-			}
-			_, err = buf.WriteString(fileScanner.Text() + "\n")
-			if err != nil {
-				return fmt.Errorf("failed to write line: %w", err)
+				matched = true
+				break
 			}
 		}
+		if matched {
+			continue
+		}
+		_, err = buf.WriteString(fileScanner.Text() + "\n")
+		if err != nil {
+			return fmt.Errorf("failed to write line: %w", err)
+		}
 	}
 
 	err = os.WriteFile(filepath, buf.Bytes(), perm)
@@ -1,6 +1,11 @@
 package system
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+
+	cmdlog "github.com/sol-eng/wbi/internal/logging"
+)
 
 // AddToPATH adds a path to the PATH environment variable in a profile.d script
 func AddToPATH(path string, filename string) error {
@@ -11,3 +16,45 @@ func AddToPATH(path string, filename string) error {
 	}
 	return nil
 }
+
+// ReplacePATH rewrites a profile.d script so it only adds the given path to PATH. The new file is written
+// next to the old one and moved into place so a login never sees a partially written file
+func ReplacePATH(path string, filename string) error {
+	fullFileName := "/etc/profile.d/wbi_" + filename + ".sh"
+	tmpFileName := fullFileName + ".wbi-tmp"
+	err := os.Remove(tmpFileName)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("issue removing %s: %w", tmpFileName, err)
+	}
+	err = WriteStrings([]string{"PATH=" + path + ":$PATH"}, tmpFileName, 0644, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmpFileName, err)
+	}
+	err = os.Rename(tmpFileName, fullFileName)
+	if err != nil {
+		return fmt.Errorf("issue replacing %s: %w", fullFileName, err)
+	}
+	cmdlog.Info("mv -f " + ShellQuote(tmpFileName) + " " + ShellQuote(fullFileName))
+	return nil
+}
+
+// ReplaceSymlink points a symlink at a new target. The new symlink is created next to the old one and renamed
+// over it so the link never disappears, even briefly
+func ReplaceSymlink(target string, linkPath string) error {
+	tmpLinkPath := linkPath + ".wbi-tmp"
+	err := os.Remove(tmpLinkPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("issue removing %s: %w", tmpLinkPath, err)
+	}
+	err = os.Symlink(target, tmpLinkPath)
+	if err != nil {
+		return fmt.Errorf("issue creating a symlink from %s to %s: %w", tmpLinkPath, target, err)
+	}
+	err = os.Rename(tmpLinkPath, linkPath)
+	if err != nil {
+		os.Remove(tmpLinkPath)
+		return fmt.Errorf("issue pointing %s at %s: %w", linkPath, target, err)
+	}
+	cmdlog.Info("ln -sfn " + ShellQuote(target) + " " + ShellQuote(linkPath))
+	return nil
+}
@@ -0,0 +1,25 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceSymlink(t *testing.T) {
+	dir := t.TempDir()
+	// paths with spaces and quotes must be handled as they are
+	oldTarget := filepath.Join(dir, "R 4.2.3", "bin", "R")
+	newTarget := filepath.Join(dir, "it's R 4.3.2", "bin", "R")
+	linkPath := filepath.Join(dir, "local bin R")
+
+	assert.NoError(t, ReplaceSymlink(oldTarget, linkPath))
+	assert.NoError(t, ReplaceSymlink(newTarget, linkPath))
+
+	target, err := os.Readlink(linkPath)
+	assert.NoError(t, err)
+	assert.Equal(t, newTarget, target)
+	assert.NoFileExists(t, linkPath+".wbi-tmp")
+}
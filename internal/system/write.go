@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"strings"
 
 	cmdlog "github.com/sol-eng/wbi/internal/logging"
 )
@@ -36,3 +37,15 @@ func WriteStrings(lines []string, filepath string, perm fs.FileMode, print bool,
 
 	return nil
 }
+
+// ReplaceStrings removes the lines containing any of the keys from a file and appends the new lines, so writing the same
+// settings again replaces them instead of failing or duplicating them. The file is created if it doesn't exist
+func ReplaceStrings(keys []string, lines []string, filepath string, perm fs.FileMode, save bool) error {
+	if VerifyFileExists(filepath) {
+		err := DeleteStrings(keys, filepath, perm)
+		if err != nil {
+			return fmt.Errorf("failed to delete the existing %s lines: %w", strings.Join(keys, ", "), err)
+		}
+	}
+	return WriteStrings(lines, filepath, perm, true, save)
+}
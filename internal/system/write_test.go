@@ -0,0 +1,42 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceStrings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "rserver.conf")
+	err := os.WriteFile(configPath, []byte("www-port=8787\nauth-proxy=0\n"), 0644)
+	if err != nil {
+		t.Fatalf("issue writing the config: %v", err)
+	}
+
+	keys := []string{"auth-proxy=", "auth-proxy-user-header="}
+	// replacing twice must leave a single copy of each line and keep the unrelated lines
+	for i := 0; i < 2; i++ {
+		err = ReplaceStrings(keys, []string{"auth-proxy=1", "auth-proxy-user-header=X-Auth-User"}, configPath, 0644, false)
+		assert.NoError(t, err)
+	}
+
+	contents, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("issue reading the config: %v", err)
+	}
+	assert.Equal(t, "www-port=8787\nauth-proxy=1\nauth-proxy-user-header=X-Auth-User\n", string(contents))
+}
+
+func TestReplaceStringsCreatesFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "launcher.kubernetes.conf")
+	err := ReplaceStrings([]string{"api-url="}, []string{"api-url=https://k8s.example.com"}, configPath, 0600, false)
+	assert.NoError(t, err)
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		t.Fatalf("issue reading the config: %v", err)
+	}
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
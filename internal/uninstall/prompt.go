@@ -0,0 +1,55 @@
+package uninstall
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// PrintSummary prints the actions an uninstall will take
+func PrintSummary(target string, actions []Action) {
+	system.PrintAndLogInfo("\nUninstalling " + target + " will:")
+	for _, action := range actions {
+		system.PrintAndLogInfo("  - " + action.Description)
+	}
+}
+
+// Prompt asking users to confirm the uninstall
+func PromptConfirmUninstall(target string) (bool, error) {
+	name := false
+	messageText := "Would you like to continue uninstalling " + target + "?"
+	prompt := &survey.Confirm{
+		Message: messageText,
+	}
+	err := survey.AskOne(prompt, &name)
+	if err != nil {
+		return false, errors.New("there was an issue with the uninstall confirmation prompt")
+	}
+	log.Info(messageText)
+	log.Info(fmt.Sprintf("%v", name))
+	return name, nil
+}
+
+// ConfirmAndRun prints the summary, confirms unless skipConfirm is set, then runs the actions
+func ConfirmAndRun(target string, actions []Action, skipConfirm bool) error {
+	PrintSummary(target, actions)
+	if !skipConfirm {
+		confirmed, err := PromptConfirmUninstall(target)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			system.PrintAndLogInfo("\nUninstall of " + target + " cancelled.")
+			return nil
+		}
+	}
+	err := RunActions(actions)
+	if err != nil {
+		return err
+	}
+	system.PrintAndLogInfo("\n" + target + " has been successfully uninstalled!")
+	return nil
+}
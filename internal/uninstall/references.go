@@ -0,0 +1,175 @@
+package uninstall
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/jupyter"
+	cmdlog "github.com/sol-eng/wbi/internal/logging"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// Reference is something outside of an install directory that still points into it
+type Reference struct {
+	Description string
+	// Fix removes the reference so the install directory can be safely deleted
+	Fix Action
+}
+
+// InstallDir returns the directory a language version is installed in. Python is always in /opt/python, R in /opt/R
+// unless it was built from source into the install prefix, and Quarto in the install prefix
+func InstallDir(language string, version string) string {
+	switch language {
+	case "r":
+		return config.RInstallDir(version)
+	case "python":
+		return config.DefaultInstallDir("python", version)
+	case "quarto":
+		return config.InstallDir("quarto", version)
+	default:
+		return ""
+	}
+}
+
+// InstallRoots returns the directories the versions of a language are installed in, such as /opt/R
+func InstallRoots(language string) []string {
+	switch language {
+	case "r":
+		return config.RInstallRoots()
+	case "python":
+		return []string{config.DefaultInstallRoot("python")}
+	case "quarto":
+		return []string{config.InstallRoot("quarto")}
+	default:
+		return []string{}
+	}
+}
+
+func pointsInto(path string, installDir string) bool {
+	return strings.HasPrefix(path, installDir+"/")
+}
+
+// symlinkReference checks whether a symlink resolves to a location inside the install directory
+func symlinkReference(linkPath string, installDir string) []Reference {
+	target, err := filepath.EvalSymlinks(linkPath)
+	if err != nil || !pointsInto(target, installDir) {
+		return []Reference{}
+	}
+	return []Reference{{
+		Description: "the symlink " + linkPath + " points to " + target,
+		Fix: Action{
+			Description: "remove the symlink " + linkPath,
+			Run: func() error {
+				err := os.Remove(linkPath)
+				if err != nil && !errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("issue removing %s: %w", linkPath, err)
+				}
+				cmdlog.Info("rm -f " + system.ShellQuote(linkPath))
+				return nil
+			},
+		},
+	}}
+}
+
+// profileDReferences checks the wbi PATH entries in /etc/profile.d for the install directory
+func profileDReferences(installDir string) []Reference {
+	references := []Reference{}
+	profileFiles, err := filepath.Glob("/etc/profile.d/wbi_*.sh")
+	if err != nil {
+		return references
+	}
+	for _, profileFile := range profileFiles {
+		profileFile := profileFile
+		matched, err := system.CheckStringExists(installDir+"/", profileFile)
+		if err != nil || !matched {
+			continue
+		}
+		references = append(references, Reference{
+			Description: "the PATH entry in " + profileFile + " includes " + installDir,
+			Fix: Action{
+				Description: "remove the " + installDir + " PATH entry from " + profileFile,
+				Run: func() error {
+					return system.DeleteStrings([]string{installDir + "/"}, profileFile, 0644)
+				},
+			},
+		})
+	}
+	return references
+}
+
+// resetJupyterExe removes jupyter-exe from the Workbench Jupyter config, restoring the commented default
+func resetJupyterExe() error {
+	filepath := "/etc/rstudio/jupyter.conf"
+	err := system.DeleteStrings([]string{"jupyter-exe="}, filepath, 0644)
+	if err != nil {
+		return err
+	}
+	return system.WriteStrings([]string{"# jupyter-exe=/usr/local/bin/jupyter"}, filepath, 0644, true, true)
+}
+
+// jupyterExeReference checks whether Workbench runs Jupyter from the install directory
+func jupyterExeReference(installDir string) []Reference {
+	jupyterExe := jupyter.ConfiguredJupyterExe()
+	if !pointsInto(jupyterExe, installDir) {
+		return []Reference{}
+	}
+	return []Reference{{
+		Description: "jupyter-exe in /etc/rstudio/jupyter.conf is set to " + jupyterExe,
+		Fix: Action{
+			Description: "remove jupyter-exe=" + jupyterExe + " from /etc/rstudio/jupyter.conf",
+			Run:         resetJupyterExe,
+		},
+	}}
+}
+
+// KernelsUsing returns the registered Jupyter kernel directories that run from the install directory
+func KernelsUsing(installDir string) []string {
+	kernels := []string{}
+	for _, kernel := range jupyter.RegisteredKernels() {
+		if pointsInto(kernel.Executable, installDir) {
+			kernels = append(kernels, kernel.Path)
+		}
+	}
+	return kernels
+}
+
+// kernelReferences checks for registered Jupyter kernels that run from the install directory
+func kernelReferences(installDir string) []Reference {
+	references := []Reference{}
+	for _, kernelPath := range KernelsUsing(installDir) {
+		kernelPath := kernelPath
+		references = append(references, Reference{
+			Description: "the Jupyter kernel " + kernelPath + " runs from " + installDir,
+			Fix: Action{
+				Description: "remove the Jupyter kernel " + kernelPath,
+				Run: func() error {
+					return removeAll(kernelPath)
+				},
+			},
+		})
+	}
+	return references
+}
+
+// FindReferences returns everything that still points into a language version's install directory
+func FindReferences(language string, version string) []Reference {
+	installDir := InstallDir(language, version)
+	references := []Reference{}
+	switch language {
+	case "r":
+		references = append(references, symlinkReference("/usr/local/bin/R", installDir)...)
+		references = append(references, symlinkReference("/usr/local/bin/Rscript", installDir)...)
+	case "python":
+		references = append(references, profileDReferences(installDir)...)
+		references = append(references, jupyterExeReference(installDir)...)
+		references = append(references, kernelReferences(installDir)...)
+	case "quarto":
+		references = append(references, symlinkReference("/usr/local/bin/quarto", installDir)...)
+		references = append(references, profileDReferences(installDir)...)
+	}
+	return references
+}
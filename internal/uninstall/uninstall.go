@@ -0,0 +1,213 @@
+package uninstall
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/sol-eng/wbi/internal/jupyter"
+	cmdlog "github.com/sol-eng/wbi/internal/logging"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// Action is a single step of an uninstall
+type Action struct {
+	Description string
+	Run         func() error
+}
+
+// RetrieveRemoveCommand creates the proper command to remove a package based on the operating system
+func RetrieveRemoveCommand(packageName string, osType config.OperatingSystem) (string, error) {
+	switch osType {
+	case config.Ubuntu22, config.Ubuntu20:
+		return "DEBIAN_FRONTEND=noninteractive apt-get remove -y " + packageName, nil
+	case config.Redhat7, config.Redhat8, config.Redhat9:
+		return "yum remove -y " + packageName, nil
+	default:
+		return "", errors.New("operating system not supported")
+	}
+}
+
+// languagePackageName returns the name of the package the Posit R and Python builds are installed with
+func languagePackageName(language string, version string, osType config.OperatingSystem) string {
+	// the RHEL R packages use an uppercase "R"
+	if language == "r" && (osType == config.Redhat7 || osType == config.Redhat8 || osType == config.Redhat9) {
+		return "R-" + version
+	}
+	return language + "-" + version
+}
+
+func runCommandAction(description string, command string) Action {
+	return Action{
+		Description: description,
+		Run: func() error {
+			err := system.RunCommand(command, true, 0, true)
+			if err != nil {
+				return fmt.Errorf("issue running the command '%s': %w", command, err)
+			}
+			return nil
+		},
+	}
+}
+
+// PlanLanguageUninstall returns the actions needed to remove an R, Python or Quarto version.
+// If anything still points at the version it is refused unless force is set, in which case the references are fixed up first.
+func PlanLanguageUninstall(language string, version string, osType config.OperatingSystem, force bool) ([]Action, error) {
+	installDir := InstallDir(language, version)
+	if installDir == "" {
+		return nil, errors.New("language " + language + " is not supported")
+	}
+	// a version such as .. would resolve to the install root itself or somewhere outside of it
+	if !lo.Contains(InstallRoots(language), filepath.Dir(installDir)) || filepath.Base(installDir) != version {
+		return nil, errors.New("the version " + version + " is not a valid " + language + " version")
+	}
+	if !system.VerifyFileExists(installDir) {
+		return nil, errors.New("version " + version + " is not installed in " + installDir)
+	}
+
+	references := FindReferences(language, version)
+	if len(references) > 0 && !force {
+		var descriptions []string
+		for _, reference := range references {
+			descriptions = append(descriptions, "  "+reference.Description)
+		}
+		return nil, errors.New("version " + version + " is still in use:\n" + strings.Join(descriptions, "\n") +
+			"\nUpdate these first or use the --force flag to remove them as part of the uninstall")
+	}
+
+	var actions []Action
+	for _, reference := range references {
+		actions = append(actions, reference.Fix)
+	}
+
+	if language == "r" || language == "python" {
+		removeCommand, err := RetrieveRemoveCommand(languagePackageName(language, version, osType), osType)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, runCommandAction("remove the package "+languagePackageName(language, version, osType), removeCommand))
+	}
+	// remove anything left behind such as packages installed into the version's library
+	actions = append(actions, Action{
+		Description: "delete the directory " + installDir,
+		Run:         func() error { return removeAll(installDir) },
+	})
+	return actions, nil
+}
+
+// removeAll deletes a directory and everything in it, recording the equivalent command in the command log
+func removeAll(path string) error {
+	err := os.RemoveAll(path)
+	if err != nil {
+		return fmt.Errorf("issue removing %s: %w", path, err)
+	}
+	cmdlog.Info("rm -rf " + system.ShellQuote(path))
+	return nil
+}
+
+// PlanWorkbenchUninstall returns the actions needed to remove Workbench and its configuration
+func PlanWorkbenchUninstall(osType config.OperatingSystem) ([]Action, error) {
+	removeCommand, err := RetrieveRemoveCommand("rstudio-server", osType)
+	if err != nil {
+		return nil, err
+	}
+	actions := []Action{
+		runCommandAction("stop Workbench and the Job Launcher", "rstudio-server stop; rstudio-launcher stop"),
+		runCommandAction("remove the package rstudio-server", removeCommand),
+	}
+	if system.VerifyFileExists("/etc/rstudio") {
+		actions = append(actions, backupDirectoryAction("/etc/rstudio", time.Now()))
+	}
+	return actions, nil
+}
+
+// backupDirectoryAction moves a directory to a backup named after the time, such as /etc/rstudio.bak-20240102-150405, so
+// earlier backups are kept. The action fails rather than replace a backup that already exists
+func backupDirectoryAction(directory string, now time.Time) Action {
+	backupPath := directory + ".bak-" + now.Format("20060102-150405")
+	return Action{
+		Description: "back up " + directory + " to " + backupPath,
+		Run: func() error {
+			if system.VerifyFileExists(backupPath) {
+				return errors.New("the backup " + backupPath + " already exists")
+			}
+			err := os.Rename(directory, backupPath)
+			if err != nil {
+				return fmt.Errorf("issue moving %s to %s: %w", directory, backupPath, err)
+			}
+			cmdlog.Info("mv " + directory + " " + backupPath)
+			return nil
+		},
+	}
+}
+
+// PlanProDriversUninstall returns the actions needed to remove the Pro Drivers and restore odbcinst.ini
+func PlanProDriversUninstall(osType config.OperatingSystem) ([]Action, error) {
+	removeCommand, err := RetrieveRemoveCommand("rstudio-drivers", osType)
+	if err != nil {
+		return nil, err
+	}
+	actions := []Action{
+		runCommandAction("remove the package rstudio-drivers", removeCommand),
+	}
+	if system.VerifyFileExists("/etc/odbcinst.ini.bak") {
+		actions = append(actions, Action{
+			Description: "restore /etc/odbcinst.ini from /etc/odbcinst.ini.bak",
+			Run: func() error {
+				err := os.Rename("/etc/odbcinst.ini.bak", "/etc/odbcinst.ini")
+				if err != nil {
+					return fmt.Errorf("issue moving /etc/odbcinst.ini.bak to /etc/odbcinst.ini: %w", err)
+				}
+				cmdlog.Info("mv /etc/odbcinst.ini.bak /etc/odbcinst.ini")
+				return nil
+			},
+		})
+	} else {
+		actions = append(actions, Action{
+			Description: "leave /etc/odbcinst.ini in place, no backup was found so the Pro Drivers entries must be removed manually",
+			Run:         func() error { return nil },
+		})
+	}
+	return actions, nil
+}
+
+// PlanJupyterUninstall returns the actions needed to remove the Jupyter install wbi configured for Workbench
+func PlanJupyterUninstall() ([]Action, error) {
+	jupyterExe := jupyter.ConfiguredJupyterExe()
+	if jupyterExe == "" {
+		return nil, errors.New("jupyter-exe is not set in /etc/rstudio/jupyter.conf, no Jupyter install to remove")
+	}
+	binDir := strings.TrimSuffix(jupyterExe, "/jupyter")
+	pythonPath := binDir + "/python"
+	pythonDir := strings.TrimSuffix(binDir, "/bin")
+
+	uninstallCommand := "PIP_ROOT_USER_ACTION=ignore " + pythonPath + " -m pip uninstall -y --disable-pip-version-check jupyter jupyterlab rsp_jupyter rsconnect_jupyter workbench_jupyterlab"
+	actions := []Action{
+		runCommandAction("uninstall Jupyter and the Workbench extensions from "+pythonPath, uninstallCommand),
+		{
+			Description: "remove jupyter-exe=" + jupyterExe + " from /etc/rstudio/jupyter.conf",
+			Run:         resetJupyterExe,
+		},
+	}
+	for _, reference := range kernelReferences(pythonDir) {
+		actions = append(actions, reference.Fix)
+	}
+	return actions, nil
+}
+
+// RunActions runs each action in order, stopping at the first failure
+func RunActions(actions []Action) error {
+	for _, action := range actions {
+		system.PrintAndLogInfo("\n=== " + action.Description + " ===")
+		err := action.Run()
+		if err != nil {
+			return fmt.Errorf("issue trying to %s: %w", action.Description, err)
+		}
+	}
+	return nil
+}
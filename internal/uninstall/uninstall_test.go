@@ -0,0 +1,144 @@
+package uninstall
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sol-eng/wbi/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupDirectoryActionKeepsEarlierBackups(t *testing.T) {
+	directory := filepath.Join(t.TempDir(), "rstudio")
+	first := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	for i, now := range []time.Time{first, second} {
+		if err := os.MkdirAll(directory, 0755); err != nil {
+			t.Fatalf("issue creating %s: %v", directory, err)
+		}
+		if err := os.WriteFile(filepath.Join(directory, "rserver.conf"), []byte{byte('0' + i)}, 0644); err != nil {
+			t.Fatalf("issue writing rserver.conf: %v", err)
+		}
+		assert.NoError(t, backupDirectoryAction(directory, now).Run())
+		assert.NoDirExists(t, directory)
+	}
+
+	firstBackup, err := os.ReadFile(filepath.Join(directory+".bak-20240102-150405", "rserver.conf"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0", string(firstBackup))
+	secondBackup, err := os.ReadFile(filepath.Join(directory+".bak-20240102-160405", "rserver.conf"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", string(secondBackup))
+}
+
+func TestBackupDirectoryActionRefusesExistingBackup(t *testing.T) {
+	directory := filepath.Join(t.TempDir(), "rstudio")
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	for _, path := range []string{directory, directory + ".bak-20240102-150405"} {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatalf("issue creating %s: %v", path, err)
+		}
+	}
+
+	err := backupDirectoryAction(directory, now).Run()
+	assert.ErrorContains(t, err, "already exists")
+	assert.DirExists(t, directory)
+}
+
+func TestRunActionsStopsAtFirstFailure(t *testing.T) {
+	var ran []string
+	actions := []Action{
+		{Description: "first", Run: func() error { ran = append(ran, "first"); return nil }},
+		{Description: "second", Run: func() error { ran = append(ran, "second"); return errors.New("boom") }},
+		{Description: "third", Run: func() error { ran = append(ran, "third"); return nil }},
+	}
+
+	err := RunActions(actions)
+	assert.ErrorContains(t, err, "issue trying to second: boom")
+	assert.Equal(t, []string{"first", "second"}, ran)
+}
+
+func TestPlanLanguageUninstall(t *testing.T) {
+	prefix := t.TempDir()
+	if err := config.SetInstallPrefix(prefix); err != nil {
+		t.Fatalf("issue setting the install prefix: %v", err)
+	}
+	defer config.SetInstallPrefix("")
+
+	installDir := filepath.Join(prefix, "quarto", "1.3.340")
+	if err := os.MkdirAll(filepath.Join(installDir, "bin"), 0755); err != nil {
+		t.Fatalf("issue creating %s: %v", installDir, err)
+	}
+
+	actions, err := PlanLanguageUninstall("quarto", "1.3.340", config.Ubuntu22, false)
+	assert.NoError(t, err)
+	if assert.Len(t, actions, 1) {
+		assert.Equal(t, "delete the directory "+installDir, actions[0].Description)
+	}
+	assert.NoError(t, RunActions(actions))
+	assert.NoDirExists(t, installDir)
+
+	_, err = PlanLanguageUninstall("quarto", "1.3.340", config.Ubuntu22, false)
+	assert.ErrorContains(t, err, "version 1.3.340 is not installed in "+installDir)
+
+	// a version that resolves to the install root or outside of it is refused even if the directory exists
+	for _, version := range []string{"..", ".", "../quarto", "1.3.340/../.."} {
+		_, err = PlanLanguageUninstall("quarto", version, config.Ubuntu22, false)
+		assert.ErrorContains(t, err, "is not a valid quarto version")
+	}
+	assert.DirExists(t, prefix)
+
+	_, err = PlanLanguageUninstall("julia", "1.10.2", config.Ubuntu22, false)
+	assert.ErrorContains(t, err, "language julia is not supported")
+}
+
+func TestRetrieveRemoveCommand(t *testing.T) {
+	tests := map[string]struct {
+		language    string
+		version     string
+		osType      config.OperatingSystem
+		expected    string
+		expectError string
+	}{
+		"R on Ubuntu": {
+			language: "r",
+			version:  "4.3.2",
+			osType:   config.Ubuntu22,
+			expected: "DEBIAN_FRONTEND=noninteractive apt-get remove -y r-4.3.2",
+		},
+		"R on RHEL uses an uppercase package name": {
+			language: "r",
+			version:  "4.3.2",
+			osType:   config.Redhat9,
+			expected: "yum remove -y R-4.3.2",
+		},
+		"Python on RHEL": {
+			language: "python",
+			version:  "3.11.6",
+			osType:   config.Redhat8,
+			expected: "yum remove -y python-3.11.6",
+		},
+		"unknown operating system fails": {
+			language:    "r",
+			version:     "4.3.2",
+			osType:      config.Unknown,
+			expectError: "operating system not supported",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			command, err := RetrieveRemoveCommand(languagePackageName(tc.language, tc.version, tc.osType), tc.osType)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, command)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package vscode
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/system"
+	"github.com/sol-eng/wbi/internal/workbench"
+)
+
+// Editor describes a code-server based IDE shipped with Workbench that can have extensions provisioned
+type Editor struct {
+	Name string
+	// the server binary locations shipped with Workbench, newest first
+	ServerPaths []string
+	// the global directory extensions are installed into so every user's session has them available
+	ExtensionsDir string
+	// the config file listing the extensions Workbench installs for each user
+	ExtensionsConfig string
+}
+
+// VSCode is the VS Code IDE shipped with Workbench
+var VSCode = Editor{
+	Name: "VS Code",
+	ServerPaths: []string{
+		"/usr/lib/rstudio-server/bin/pwb-code-server/bin/code-server",
+		"/usr/lib/rstudio-server/bin/code-server/bin/code-server",
+	},
+	ExtensionsDir:    "/opt/code-server/extensions",
+	ExtensionsConfig: "/etc/rstudio/vscode.extensions.conf",
+}
+
+// DefaultExtensions returns the curated extensions for R, Python and Quarto development
+func DefaultExtensions() []string {
+	return []string{
+		"REditorSupport.r",
+		"ms-python.python",
+		"ms-toolsai.jupyter",
+		"quarto.quarto",
+		"posit.shiny",
+		"posit.publisher",
+	}
+}
+
+// FindServer returns the location of the editor's server binary shipped with Workbench
+func (editor Editor) FindServer() (string, error) {
+	for _, serverPath := range editor.ServerPaths {
+		if system.VerifyFileExists(serverPath) {
+			return serverPath, nil
+		}
+	}
+	return "", errors.New("the " + editor.Name + " server was not found, please ensure a version of Workbench that includes " + editor.Name + " is installed")
+}
+
+// ExtensionID returns the extension id for an extension id or a .vsix file named publisher.name-version.vsix
+func ExtensionID(extension string) string {
+	if !strings.HasSuffix(extension, ".vsix") {
+		return extension
+	}
+	name := strings.TrimSuffix(filepath.Base(extension), ".vsix")
+	// remove the version suffix if present
+	versionSuffix := regexp.MustCompile(`-\d+\.\d+\.\d+.*$`)
+	return versionSuffix.ReplaceAllString(name, "")
+}
+
+// ValidateExtensions ensures extensions are either publisher.name ids or .vsix files that exist
+func ValidateExtensions(extensions []string) error {
+	validID := regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*\.[A-Za-z0-9][A-Za-z0-9-]*$`)
+	for _, extension := range extensions {
+		if strings.HasSuffix(extension, ".vsix") {
+			if !system.VerifyFileExists(extension) {
+				return errors.New("the extension file " + extension + " does not exist")
+			}
+		} else if !validID.MatchString(extension) {
+			return errors.New("the extension " + extension + " is not a valid extension id, ids are in the format publisher.name")
+		}
+	}
+	return nil
+}
+
+// InstallExtensions installs extensions into the global extensions directory
+func (editor Editor) InstallExtensions(extensions []string) error {
+	serverPath, err := editor.FindServer()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(editor.ExtensionsDir, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	for _, extension := range extensions {
+		installCommand := editor.installExtensionCommand(serverPath, extension)
+		err = system.RunCommand(installCommand, true, 0, true)
+		if err != nil {
+			return fmt.Errorf("issue installing the extension %s: %w", extension, err)
+		}
+	}
+	return nil
+}
+
+// installExtensionCommand returns the command that installs an extension id or .vsix file into the global extensions directory
+func (editor Editor) installExtensionCommand(serverPath string, extension string) string {
+	return system.ShellQuote(serverPath) + " --extensions-dir " + system.ShellQuote(editor.ExtensionsDir) + " --install-extension " + system.ShellQuote(extension)
+}
+
+// VerifyExtensions checks every extension is present in the global extensions directory
+func (editor Editor) VerifyExtensions(extensions []string) error {
+	serverPath, err := editor.FindServer()
+	if err != nil {
+		return err
+	}
+	listCommand := system.ShellQuote(serverPath) + " --extensions-dir " + system.ShellQuote(editor.ExtensionsDir) + " --list-extensions"
+	output, err := system.RunCommandAndCaptureOutput(listCommand, false, 0, false)
+	if err != nil {
+		return fmt.Errorf("issue listing the installed extensions: %w", err)
+	}
+
+	installed := lo.Map(strings.Split(output, "\n"), func(line string, _ int) string {
+		return strings.ToLower(strings.TrimSpace(line))
+	})
+	var missing []string
+	for _, extension := range extensions {
+		if !lo.Contains(installed, strings.ToLower(ExtensionID(extension))) {
+			missing = append(missing, extension)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New("the following extensions were not installed: " + strings.Join(missing, ", "))
+	}
+	system.PrintAndLogInfo("\nThe following " + editor.Name + " extensions have been successfully installed: " + strings.Join(extensions, ", "))
+	return nil
+}
+
+// WriteExtensionsConfig lists the extensions in the extensions config so Workbench installs them for each user
+func (editor Editor) WriteExtensionsConfig(extensions []string) error {
+	filepath := editor.ExtensionsConfig
+	var writeLines []string
+	for _, extension := range extensions {
+		lineExists, err := system.CheckStringExists(extension, filepath)
+		if err != nil {
+			return fmt.Errorf("failed to check if line exists: %w", err)
+		}
+		if !lineExists {
+			writeLines = append(writeLines, extension)
+		}
+	}
+	if len(writeLines) == 0 {
+		return nil
+	}
+
+	err := system.WriteStrings(writeLines, filepath, 0644, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// InstallAndConfigExtensions installs, registers and verifies extensions
+func (editor Editor) InstallAndConfigExtensions(extensions []string) error {
+	err := ValidateExtensions(extensions)
+	if err != nil {
+		return fmt.Errorf("issue validating extensions: %w", err)
+	}
+	err = editor.InstallExtensions(extensions)
+	if err != nil {
+		return fmt.Errorf("issue installing extensions: %w", err)
+	}
+	err = editor.WriteExtensionsConfig(extensions)
+	if err != nil {
+		return fmt.Errorf("issue writing the %s extensions config: %w", editor.Name, err)
+	}
+	err = editor.VerifyExtensions(extensions)
+	if err != nil {
+		return fmt.Errorf("issue verifying extensions: %w", err)
+	}
+	return nil
+}
+
+// SessionArgs returns the arguments sessions are started with, adding the global extensions directory so sessions load the
+// extensions installed there unless the arguments already set one
+func (editor Editor) SessionArgs(args string) string {
+	if args == "" {
+		args = "--host=0.0.0.0"
+	}
+	if !strings.Contains(args, "--extensions-dir") {
+		args = args + " --extensions-dir " + editor.ExtensionsDir
+	}
+	return args
+}
+
+// ConfigVSCode enables VS Code sessions and provisions extensions
+func ConfigVSCode(args string, extensions []string) error {
+	_, err := VSCode.FindServer()
+	if err != nil {
+		return err
+	}
+	err = workbench.WriteVSCodeConfig(VSCode.SessionArgs(args))
+	if err != nil {
+		return fmt.Errorf("issue writing the VS Code config: %w", err)
+	}
+	if len(extensions) > 0 {
+		err = VSCode.InstallAndConfigExtensions(extensions)
+		if err != nil {
+			return err
+		}
+	}
+	system.PrintAndLogInfo("\nVS Code sessions have been enabled. Restart Workbench to apply the changes.")
+	return nil
+}
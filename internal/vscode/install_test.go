@@ -0,0 +1,38 @@
+package vscode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionArgs(t *testing.T) {
+	tests := map[string]struct {
+		args     string
+		expected string
+	}{
+		"default arguments load the global extensions": {
+			args:     "",
+			expected: "--host=0.0.0.0 --extensions-dir /opt/code-server/extensions",
+		},
+		"custom arguments load the global extensions": {
+			args:     "--host=0.0.0.0 --verbose",
+			expected: "--host=0.0.0.0 --verbose --extensions-dir /opt/code-server/extensions",
+		},
+		"an extensions directory in the arguments is kept": {
+			args:     "--host=0.0.0.0 --extensions-dir /srv/extensions",
+			expected: "--host=0.0.0.0 --extensions-dir /srv/extensions",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, VSCode.SessionArgs(tc.args))
+		})
+	}
+}
+
+func TestInstallExtensionCommand(t *testing.T) {
+	command := VSCode.installExtensionCommand("/usr/lib/rstudio-server/bin/code-server/bin/code-server", "/tmp/my ext $(id).vsix")
+	assert.Equal(t, "'/usr/lib/rstudio-server/bin/code-server/bin/code-server' --extensions-dir '/opt/code-server/extensions' --install-extension '/tmp/my ext $(id).vsix'", command)
+}
@@ -0,0 +1,71 @@
+package vscode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// Prompt asking users if they wish to enable VS Code sessions
+func PromptVSCodeChoice() (bool, error) {
+	name := true
+	messageText := "Would you like to enable VS Code sessions in Workbench?"
+	prompt := &survey.Confirm{
+		Message: messageText,
+	}
+	err := survey.AskOne(prompt, &name)
+	if err != nil {
+		return false, errors.New("there was an issue with the VS Code prompt")
+	}
+	log.Info(messageText)
+	log.Info(fmt.Sprintf("%v", name))
+	return name, nil
+}
+
+// Prompt asking users which of the curated extensions should be installed
+func PromptExtensions() ([]string, error) {
+	var qs = []*survey.Question{
+		{
+			Name: "extensionprompt",
+			Prompt: &survey.MultiSelect{
+				Message: "Which VS Code extensions would you like to install for all users? (select none to skip this step)",
+				Options: DefaultExtensions(),
+				Default: DefaultExtensions(),
+			},
+		},
+	}
+	extensionAnswers := struct {
+		Extensions []string `survey:"extensionprompt"`
+	}{}
+
+	err := survey.Ask(qs, &extensionAnswers, survey.WithRemoveSelectAll(), survey.WithRemoveSelectNone())
+	if err != nil {
+		return []string{}, errors.New("there was an issue with the VS Code extensions prompt")
+	}
+	log.Info("Which VS Code extensions would you like to install for all users?")
+	log.Info(strings.Join(extensionAnswers.Extensions, ", "))
+	return extensionAnswers.Extensions, nil
+}
+
+// PromptAndConfigVSCode prompts users to enable VS Code sessions and select extensions
+func PromptAndConfigVSCode() error {
+	vscodeChoice, err := PromptVSCodeChoice()
+	if err != nil {
+		return err
+	}
+	if !vscodeChoice {
+		return nil
+	}
+	extensions, err := PromptExtensions()
+	if err != nil {
+		return err
+	}
+	err = ConfigVSCode("", extensions)
+	if err != nil {
+		return fmt.Errorf("issue configuring VS Code: %w", err)
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package workbench
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/samber/lo"
 	"github.com/sol-eng/wbi/internal/system"
 )
 
@@ -118,6 +122,170 @@ func WriteSSLConfig(certPath string, keyPath string, serverURL string) error {
 	return nil
 }
 
+// VerifySSLDisabled checks that Workbench isn't serving TLS itself, which it must not do behind a TLS terminating proxy
+func VerifySSLDisabled() error {
+	// TLS is terminated at the proxy so Workbench must not also be serving TLS
+	sslEnabled, err := system.CheckStringExists("ssl-enabled=1", "/etc/rstudio/rserver.conf")
+	if err != nil {
+		return fmt.Errorf("failed to check if line exists: %w", err)
+	}
+	if sslEnabled {
+		return fmt.Errorf("ssl-enabled=1 exists in rserver.conf, remove the ssl-* lines before placing Workbench behind a TLS terminating proxy")
+	}
+	return nil
+}
+
+// WriteProxyListenConfig configures Workbench to only listen locally behind a TLS terminating reverse proxy
+func WriteProxyListenConfig(serverURL string) error {
+	// clean the serverURL
+	serverURLClean := cleanServerURL(serverURL)
+	finalServerURL := "https://" + serverURLClean
+
+	filepath := "/etc/rstudio/rserver.conf"
+	err := VerifySSLDisabled()
+	if err != nil {
+		return err
+	}
+
+	// remove the existing address, port and callback lines
+	err = system.DeleteStrings([]string{"www-address=", "www-port=", "launcher-sessions-callback-address="}, filepath, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to delete the existing www-address, www-port and launcher-sessions-callback-address: %w", err)
+	}
+
+	writeLines := []string{
+		"",
+		"www-address=127.0.0.1",
+		"www-port=8787",
+		"launcher-sessions-callback-address=" + finalServerURL,
+	}
+
+	err = system.WriteStrings(writeLines, filepath, 0644, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// WriteAuthProxyConfig writes the proxied authentication config to the Workbench config file, replacing any earlier
+// auth-proxy lines so it can be run again
+func WriteAuthProxyConfig(userHeader string, signInURL string) error {
+	filepath := "/etc/rstudio/rserver.conf"
+	writeLines := []string{
+		"auth-proxy=1",
+		"auth-proxy-user-header=" + userHeader,
+	}
+	if signInURL != "" {
+		writeLines = append(writeLines, "auth-proxy-sign-in-url="+signInURL)
+	}
+
+	err := system.ReplaceStrings([]string{"auth-proxy=", "auth-proxy-user-header=", "auth-proxy-sign-in-url="}, writeLines, filepath, 0644, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// readConfigValue returns the value of a key in a key=value config file, or an empty string if the file or key doesn't exist
+func readConfigValue(filepath string, key string) (string, error) {
+	contents, err := os.ReadFile(filepath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", filepath, err)
+	}
+	value := ""
+	for _, line := range strings.Split(string(contents), "\n") {
+		lineKey, lineValue, found := strings.Cut(strings.TrimSpace(line), "=")
+		if found && strings.TrimSpace(lineKey) == key {
+			value = strings.TrimSpace(lineValue)
+		}
+	}
+	return value, nil
+}
+
+// mergeLauncherClusters adds a cluster to the clusters sessions can be launched in, returning the default cluster and every
+// cluster. The existing default cluster is kept, and becomes the first of the clusters when they aren't listed yet
+func mergeLauncherClusters(defaultCluster string, sessionsClusters string, cluster string) (string, []string) {
+	var clusters []string
+	for _, existingCluster := range strings.Split(sessionsClusters, ",") {
+		if strings.TrimSpace(existingCluster) != "" {
+			clusters = append(clusters, strings.TrimSpace(existingCluster))
+		}
+	}
+	if defaultCluster == "" {
+		defaultCluster = cluster
+	} else if len(clusters) == 0 {
+		clusters = []string{defaultCluster}
+	}
+	if !lo.Contains(clusters, cluster) {
+		clusters = append(clusters, cluster)
+	}
+	return defaultCluster, clusters
+}
+
+// WriteLauncherSessionsConfig writes the config needed to launch sessions with the Job Launcher to the Workbench config file.
+// When the Job Launcher is already enabled for another cluster the existing default cluster is kept and the cluster is added
+// to launcher-sessions-clusters, so each cluster can be configured in turn or again
+func WriteLauncherSessionsConfig(cluster string, image string) error {
+	filepath := "/etc/rstudio/rserver.conf"
+	defaultCluster, err := readConfigValue(filepath, "launcher-default-cluster")
+	if err != nil {
+		return err
+	}
+	sessionsClusters, err := readConfigValue(filepath, "launcher-sessions-clusters")
+	if err != nil {
+		return err
+	}
+
+	defaultCluster, clusters := mergeLauncherClusters(defaultCluster, sessionsClusters, cluster)
+
+	keys := []string{"launcher-sessions-enabled=", "launcher-address=", "launcher-port=", "launcher-default-cluster=", "launcher-sessions-clusters="}
+	writeLines := []string{
+		"launcher-sessions-enabled=1",
+		"launcher-address=127.0.0.1",
+		"launcher-port=5559",
+		"launcher-default-cluster=" + defaultCluster,
+	}
+	// a single cluster doesn't need to be listed since sessions use the default cluster
+	if len(clusters) > 1 {
+		writeLines = append(writeLines, "launcher-sessions-clusters="+strings.Join(clusters, ","))
+	}
+	if image != "" {
+		keys = append(keys, "launcher-sessions-container-image=")
+		writeLines = append(writeLines, "launcher-sessions-container-image="+image)
+	}
+
+	err = system.ReplaceStrings(keys, writeLines, filepath, 0644, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// WriteDefaultRConfig sets the R version Workbench starts sessions with when users haven't chosen one
+func WriteDefaultRConfig(rPath string) error {
+	filepath := "/etc/rstudio/rserver.conf"
+	// remove the existing line
+	if system.VerifyFileExists(filepath) {
+		err := system.DeleteStrings([]string{"rsession-which-r="}, filepath, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to delete the old rsession-which-r=: %w", err)
+		}
+	}
+
+	writeLines := []string{
+		"rsession-which-r=" + rPath,
+	}
+
+	err := system.WriteStrings(writeLines, filepath, 0644, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
 // WriteConnectURLConfig writes the Connect URL config to the Workbench config file
 func WriteConnectURLConfig(url string) error {
 	// check to ensure the line doesn't already exist
@@ -162,3 +330,58 @@ func WriteJupyterConfig(jupyterPath string) error {
 
 	return nil
 }
+
+// WriteVSCodeConfig enables VS Code sessions and sets the session arguments in the Workbench config file
+func WriteVSCodeConfig(args string) error {
+	filepath := "/etc/rstudio/vscode.conf"
+	// remove the existing lines
+	if system.VerifyFileExists(filepath) {
+		err := system.DeleteStrings([]string{"enabled=", "args="}, filepath, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to delete the old enabled= and args=: %w", err)
+		}
+	}
+
+	if args == "" {
+		args = "--host=0.0.0.0"
+	}
+	writeLines := []string{
+		"enabled=1",
+		"args=" + args,
+	}
+
+	err := system.WriteStrings(writeLines, filepath, 0644, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// ReadPositronArgs returns the arguments Positron sessions are started with in the Workbench config file, or an empty string
+// if none are set
+func ReadPositronArgs() (string, error) {
+	return readConfigValue("/etc/rstudio/positron.conf", "args")
+}
+
+// WritePositronConfig enables Positron sessions in the Workbench config file with the arguments sessions are started with
+func WritePositronConfig(args string) error {
+	filepath := "/etc/rstudio/positron.conf"
+	// remove the existing lines
+	if system.VerifyFileExists(filepath) {
+		err := system.DeleteStrings([]string{"enabled=", "args="}, filepath, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to delete the old enabled= and args=: %w", err)
+		}
+	}
+
+	writeLines := []string{
+		"enabled=1",
+		"args=" + args,
+	}
+
+	err := system.WriteStrings(writeLines, filepath, 0644, true, true)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package workbench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLauncherClusters(t *testing.T) {
+	tests := map[string]struct {
+		defaultCluster   string
+		sessionsClusters string
+		cluster          string
+		expectDefault    string
+		expectClusters   []string
+	}{
+		"first cluster becomes the default": {
+			cluster:        "Kubernetes",
+			expectDefault:  "Kubernetes",
+			expectClusters: []string{"Kubernetes"},
+		},
+		"second cluster is added after the existing default": {
+			defaultCluster: "Kubernetes",
+			cluster:        "Slurm",
+			expectDefault:  "Kubernetes",
+			expectClusters: []string{"Kubernetes", "Slurm"},
+		},
+		"configuring a listed cluster again changes nothing": {
+			defaultCluster:   "Kubernetes",
+			sessionsClusters: "Kubernetes, Slurm",
+			cluster:          "Kubernetes",
+			expectDefault:    "Kubernetes",
+			expectClusters:   []string{"Kubernetes", "Slurm"},
+		},
+		"cluster is added to the listed clusters": {
+			defaultCluster:   "Local",
+			sessionsClusters: "Local,Kubernetes",
+			cluster:          "Slurm",
+			expectDefault:    "Local",
+			expectClusters:   []string{"Local", "Kubernetes", "Slurm"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			defaultCluster, clusters := mergeLauncherClusters(tc.defaultCluster, tc.sessionsClusters, tc.cluster)
+			assert.Equal(t, tc.expectDefault, defaultCluster)
+			assert.Equal(t, tc.expectClusters, clusters)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"time"
 
 	"github.com/sol-eng/wbi/internal/config"
@@ -88,6 +89,42 @@ func DownloadAndInstallWorkbench(osType config.OperatingSystem) error {
 	return nil
 }
 
+// InstallWorkbenchFromFile installs Workbench from a local .deb or .rpm package, such as one pre-scanned by a security team
+func InstallWorkbenchFromFile(packagePath string, osType config.OperatingSystem) error {
+	if VerifyWorkbench() {
+		return fmt.Errorf("workbench is already installed")
+	}
+	err := install.VerifyPackageFormat(packagePath, osType)
+	if err != nil {
+		return err
+	}
+	metadata, err := install.ReadPackageMetadata(packagePath)
+	if err != nil {
+		return err
+	}
+	if metadata.Name != "rstudio-server" && metadata.Name != "rstudio-workbench" {
+		return errors.New("the package " + metadata.Name + " is not a Workbench package")
+	}
+	system.PrintAndLogInfo("Installing Workbench " + metadata.Version + " from the local package " + packagePath)
+
+	// gdebi and yum need a path to treat the argument as a file rather than a package name
+	packagePath, err = filepath.Abs(packagePath)
+	if err != nil {
+		return fmt.Errorf("issue finding %s: %w", packagePath, err)
+	}
+	err = InstallWorkbench(packagePath, osType)
+	if err != nil {
+		return fmt.Errorf("InstallWorkbench: %w", err)
+	}
+	// save to command log
+	installCommand, err := RetrieveInstallCommandForWorkbench(packagePath, osType)
+	if err != nil {
+		return fmt.Errorf("RetrieveInstallCommand: %w", err)
+	}
+	cmdlog.Info(installCommand)
+	return nil
+}
+
 // Installs Workbench in a certain way based on the operating system
 func InstallWorkbench(filepath string, osType config.OperatingSystem) error {
 	installCommand, err := RetrieveInstallCommandForWorkbench(filepath, osType)
@@ -108,9 +145,9 @@ func InstallWorkbench(filepath string, osType config.OperatingSystem) error {
 func RetrieveInstallCommandForWorkbench(filepath string, osType config.OperatingSystem) (string, error) {
 	switch osType {
 	case config.Ubuntu22, config.Ubuntu20:
-		return "DEBIAN_FRONTEND=noninteractive gdebi -n " + filepath, nil
+		return "DEBIAN_FRONTEND=noninteractive gdebi -n " + system.ShellQuote(filepath), nil
 	case config.Redhat7, config.Redhat8, config.Redhat9:
-		return "yum install -y " + filepath, nil
+		return "yum install -y " + system.ShellQuote(filepath), nil
 	default:
 		return "", errors.New("operating system not supported")
 	}
@@ -0,0 +1,79 @@
+package workbench
+
+import (
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sol-eng/wbi/internal/system"
+)
+
+// scanTimeout is how long each Workbench command is given to report its state
+const scanTimeout = 15 * time.Second
+
+// Inventory describes the installed Workbench, its license and the state of its services
+type Inventory struct {
+	Installed bool              `json:"installed"`
+	Version   string            `json:"version"`
+	License   string            `json:"license"`
+	Services  map[string]string `json:"services"`
+}
+
+// parseLicenseStatus returns the Status line of the license manager output, e.g. "Activated"
+func parseLicenseStatus(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Status:") {
+			if status := strings.TrimSpace(strings.TrimPrefix(line, "Status:")); status != "" {
+				return status
+			}
+		}
+	}
+	return "unknown"
+}
+
+// serviceStates are the states systemctl is-active prints for a unit
+var serviceStates = []string{"active", "reloading", "inactive", "failed", "activating", "deactivating", "maintenance"}
+
+// parseServiceStatus returns the state printed by systemctl is-active, e.g. "active" or "inactive", ignoring any errors printed alongside it
+func parseServiceStatus(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if status := strings.TrimSpace(line); lo.Contains(serviceStates, status) {
+			return status
+		}
+	}
+	return "unknown"
+}
+
+// licenseStatus returns the Status line reported by the license manager
+func licenseStatus() string {
+	output, err := system.RunCommandWithTimeout(scanTimeout, "rstudio-server", "license-manager", "status")
+	if err != nil {
+		return "unknown"
+	}
+	return parseLicenseStatus(output)
+}
+
+// serviceStatus returns the systemd state of a service
+func serviceStatus(service string) string {
+	// systemctl is-active exits non-zero for anything but active while still printing the state
+	output, _ := system.RunCommandWithTimeout(scanTimeout, "systemctl", "is-active", service)
+	return parseServiceStatus(output)
+}
+
+// ScanWorkbench reports the Workbench version, license status and the state of the rstudio-server and rstudio-launcher services
+func ScanWorkbench() Inventory {
+	inventory := Inventory{Services: map[string]string{}}
+
+	output, err := system.RunCommandWithTimeout(scanTimeout, "rstudio-server", "version")
+	if err != nil {
+		return inventory
+	}
+	inventory.Installed = true
+	inventory.Version = strings.TrimSpace(output)
+	inventory.License = licenseStatus()
+	for _, service := range []string{"rstudio-server", "rstudio-launcher"} {
+		inventory.Services[service] = serviceStatus(service)
+	}
+	return inventory
+}
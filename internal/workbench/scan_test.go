@@ -0,0 +1,72 @@
+package workbench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLicenseStatus(t *testing.T) {
+	tests := map[string]struct {
+		output   string
+		expected string
+	}{
+		"activated license": {
+			output: `Product-Key: XXXX-XXXX-XXXX-XXXX-XXXX-XXXX-XXXX
+Status: Activated
+Product: RStudio Workbench`,
+			expected: "Activated",
+		},
+		"expired license": {
+			output:   "  Status:   Expired  \n",
+			expected: "Expired",
+		},
+		"output without a status line": {
+			output:   "license-manager: command not found",
+			expected: "unknown",
+		},
+		"empty status": {
+			output:   "Status:",
+			expected: "unknown",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseLicenseStatus(tc.output))
+		})
+	}
+}
+
+func TestParseServiceStatus(t *testing.T) {
+	tests := map[string]struct {
+		output   string
+		expected string
+	}{
+		"active service": {
+			output:   "active\n",
+			expected: "active",
+		},
+		"inactive service": {
+			output:   "inactive",
+			expected: "inactive",
+		},
+		"state printed after an error": {
+			output:   "Warning: The unit file changed on disk.\nfailed\n",
+			expected: "failed",
+		},
+		"systemd is not running": {
+			output:   "System has not been booted with systemd as init system (PID 1). Can't operate.",
+			expected: "unknown",
+		},
+		"no output": {
+			expected: "unknown",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseServiceStatus(tc.output))
+		})
+	}
+}
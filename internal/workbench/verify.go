@@ -1,8 +1,12 @@
 package workbench
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/go-version"
 
 	"github.com/sol-eng/wbi/internal/system"
 )
@@ -20,6 +24,31 @@ func VerifyWorkbench() bool {
 	}
 }
 
+// ParseWorkbenchVersion extracts the version from the output of rstudio-server version, e.g. "2024.12.1+563.pro5 Workbench (Kousa Dogwood) for Ubuntu Jammy"
+func ParseWorkbenchVersion(versionOutput string) (*version.Version, error) {
+	fields := strings.Fields(versionOutput)
+	if len(fields) == 0 {
+		return nil, errors.New("no Workbench version found")
+	}
+	// remove the build metadata so the version can be compared
+	rawVersion := strings.Split(fields[0], "+")[0]
+	workbenchVersion, err := version.NewVersion(rawVersion)
+	if err != nil {
+		return nil, fmt.Errorf("issue parsing the Workbench version %s: %w", rawVersion, err)
+	}
+	return workbenchVersion, nil
+}
+
+// RetrieveWorkbenchVersion returns the version of the installed Workbench
+func RetrieveWorkbenchVersion() (*version.Version, error) {
+	cmd := exec.Command("/bin/sh", "-c", "rstudio-server version")
+	stdout, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("issue running rstudio-server version, please ensure Workbench is installed: %w", err)
+	}
+	return ParseWorkbenchVersion(string(stdout))
+}
+
 // Runs verify-installation command
 func VerifyInstallation(username string) error {
 	// stop rstudio-server
@@ -0,0 +1,44 @@
+package workbench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWorkbenchVersion(t *testing.T) {
+	tests := map[string]struct {
+		versionOutput string
+		expected      string
+		expectError   string
+	}{
+		"release with build metadata": {
+			versionOutput: "2024.12.1+563.pro5 Workbench (Kousa Dogwood) for Ubuntu Jammy\n",
+			expected:      "2024.12.1",
+		},
+		"daily build": {
+			versionOutput: "2025.03.0-daily+226.pro2 Workbench (Cucumberleaf Sunflower) for RHEL 9",
+			expected:      "2025.3.0-daily",
+		},
+		"empty output fails": {
+			versionOutput: "  \n",
+			expectError:   "no Workbench version found",
+		},
+		"output without a version fails": {
+			versionOutput: "rstudio-server: command not found",
+			expectError:   "issue parsing the Workbench version rstudio-server:",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			workbenchVersion, err := ParseWorkbenchVersion(tc.versionOutput)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, workbenchVersion.String())
+		})
+	}
+}